@@ -0,0 +1,97 @@
+package analytics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteJSON writes the whole report as indented JSON.
+func (report Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write report JSON: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes the overall totals and every breakdown as one table, with the
+// dimension in the first column ("total", "day", "status", "channel" or "payment_method").
+func (report Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{{"dimension", "key", "orders", "revenue", "net_revenue", "average_order_value", "tax", "discounts", "refunds", "shipping", "units", "currency"}}
+	rows = append(rows, breakdownRow("total", Breakdown{Key: "all", Totals: report.Totals, AverageOrderValue: report.AverageOrderValue}, report.Currency))
+
+	dimensions := []struct {
+		name       string
+		breakdowns []Breakdown
+	}{
+		{"day", report.ByDay},
+		{"status", report.ByStatus},
+		{"channel", report.ByChannel},
+		{"payment_method", report.ByPaymentMethod},
+	}
+	for _, dimension := range dimensions {
+		for _, breakdown := range dimension.breakdowns {
+			rows = append(rows, breakdownRow(dimension.name, breakdown, report.Currency))
+		}
+	}
+
+	return writeCSVRows(writer, rows)
+}
+
+// WriteSKUCSV writes units sold per SKU.
+func (report Report) WriteSKUCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{{"sku", "name", "units", "units_refunded", "revenue", "currency"}}
+	for _, sku := range report.SKUs {
+		rows = append(rows, []string{sku.SKU, sku.Name, strconv.Itoa(sku.Units), strconv.Itoa(sku.UnitsRefunded), formatAmount(sku.Revenue), report.Currency})
+	}
+
+	return writeCSVRows(writer, rows)
+}
+
+// WriteCouponCSV writes coupon usage.
+func (report Report) WriteCouponCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{{"code", "type", "orders", "discount", "currency"}}
+	for _, coupon := range report.Coupons {
+		rows = append(rows, []string{coupon.Code, coupon.Type, strconv.Itoa(coupon.Orders), formatAmount(coupon.Discount), report.Currency})
+	}
+
+	return writeCSVRows(writer, rows)
+}
+
+func breakdownRow(dimension string, breakdown Breakdown, currency string) []string {
+	return []string{
+		dimension,
+		breakdown.Key,
+		strconv.Itoa(breakdown.Orders),
+		formatAmount(breakdown.Revenue),
+		formatAmount(breakdown.NetRevenue),
+		formatAmount(breakdown.AverageOrderValue),
+		formatAmount(breakdown.Tax),
+		formatAmount(breakdown.Discounts),
+		formatAmount(breakdown.Refunds),
+		formatAmount(breakdown.Shipping),
+		strconv.Itoa(breakdown.Units),
+		currency,
+	}
+}
+
+func writeCSVRows(writer *csv.Writer, rows [][]string) error {
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write report CSV: %w", err)
+	}
+	return nil
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
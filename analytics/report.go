@@ -0,0 +1,335 @@
+// Package analytics aggregates BigCommerce orders into sales reports.
+//
+// Orders are read with the client's order iterators, their products and coupons
+// are loaded as order bundles, and the results are summed overall and broken down
+// by day, status, channel and payment method.
+//
+// Example usage:
+//
+//	report, err := analytics.Run(client.V2, bigcommerce.OrderQueryParams{MinDateCreated: "2024-01-01"}, analytics.Options{})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	report.WriteCSV(os.Stdout)
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	bigcommerce "github.com/seanomeara96/go-bigcommerce"
+)
+
+type Options struct {
+	// ConvertToDefaultCurrency divides every amount of an order placed in another
+	// currency by the order's currency_exchange_rate, so all amounts are reported in
+	// the store's default currency.
+	ConvertToDefaultCurrency bool
+	// Location is the time zone used to bucket orders by day. Defaults to UTC.
+	Location *time.Location
+	// ExcludeStatusIDs lists order statuses left out of the report, such as
+	// 0 (Incomplete), 5 (Cancelled) or 6 (Declined).
+	ExcludeStatusIDs []int
+	// Concurrency is passed to the order bundle loader. Defaults to 4.
+	Concurrency int
+}
+
+// Totals are the summed figures of a set of orders
+type Totals struct {
+	Orders     int     `json:"orders"`
+	Revenue    float64 `json:"revenue"`
+	NetRevenue float64 `json:"net_revenue"`
+	Tax        float64 `json:"tax"`
+	Discounts  float64 `json:"discounts"`
+	Refunds    float64 `json:"refunds"`
+	Shipping   float64 `json:"shipping"`
+	Units      int     `json:"units"`
+}
+
+// AverageOrderValue is revenue divided by the number of orders.
+func (totals Totals) AverageOrderValue() float64 {
+	if totals.Orders == 0 {
+		return 0
+	}
+	return round(totals.Revenue / float64(totals.Orders))
+}
+
+// Breakdown is the totals for one value of a report dimension, such as one day
+type Breakdown struct {
+	Key string `json:"key"`
+	Totals
+	AverageOrderValue float64 `json:"average_order_value"`
+}
+
+type SKUUnits struct {
+	SKU           string  `json:"sku"`
+	Name          string  `json:"name"`
+	Units         int     `json:"units"`
+	UnitsRefunded int     `json:"units_refunded"`
+	Revenue       float64 `json:"revenue"`
+}
+
+type CouponUsage struct {
+	Code     string  `json:"code"`
+	Type     string  `json:"type"`
+	Orders   int     `json:"orders"`
+	Discount float64 `json:"discount"`
+}
+
+type Report struct {
+	// Currency is the store's default currency when amounts were converted, the
+	// shared currency when every order used the same one, and empty otherwise.
+	Currency          string        `json:"currency"`
+	Totals            Totals        `json:"totals"`
+	AverageOrderValue float64       `json:"average_order_value"`
+	ByDay             []Breakdown   `json:"by_day"`
+	ByStatus          []Breakdown   `json:"by_status"`
+	ByChannel         []Breakdown   `json:"by_channel"`
+	ByPaymentMethod   []Breakdown   `json:"by_payment_method"`
+	SKUs              []SKUUnits    `json:"skus"`
+	Coupons           []CouponUsage `json:"coupons"`
+}
+
+// Aggregator accumulates order bundles into a Report. It is not safe for
+// concurrent use.
+type Aggregator struct {
+	opts            Options
+	currencies      map[string]bool
+	totals          Totals
+	byDay           map[string]*Totals
+	byStatus        map[string]*Totals
+	byChannel       map[string]*Totals
+	byPaymentMethod map[string]*Totals
+	skus            map[string]*SKUUnits
+	coupons         map[string]*CouponUsage
+}
+
+func NewAggregator(opts Options) *Aggregator {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	return &Aggregator{
+		opts:            opts,
+		currencies:      map[string]bool{},
+		byDay:           map[string]*Totals{},
+		byStatus:        map[string]*Totals{},
+		byChannel:       map[string]*Totals{},
+		byPaymentMethod: map[string]*Totals{},
+		skus:            map[string]*SKUUnits{},
+		coupons:         map[string]*CouponUsage{},
+	}
+}
+
+// Add includes an order bundle in the report. Bundles without products count their
+// units from the order's items_total.
+func (aggregator *Aggregator) Add(bundle bigcommerce.OrderBundle) error {
+	order := bundle.Order
+	for _, id := range aggregator.opts.ExcludeStatusIDs {
+		if order.StatusID == id {
+			return nil
+		}
+	}
+
+	rate := 1.0
+	currency := order.CurrencyCode
+	if aggregator.opts.ConvertToDefaultCurrency {
+		currency = order.DefaultCurrencyCode
+		if order.CurrencyCode != order.DefaultCurrencyCode {
+			rate = parseAmount(order.CurrencyExchangeRate)
+			if rate <= 0 {
+				return fmt.Errorf("order %d has invalid currency exchange rate %q", order.ID, order.CurrencyExchangeRate)
+			}
+		}
+	}
+	aggregator.currencies[currency] = true
+
+	convert := func(amount float64) float64 { return amount / rate }
+
+	created, err := bigcommerce.ParseOrderDate(order.DateCreated)
+	if err != nil {
+		return fmt.Errorf("order %d has invalid date_created: %w", order.ID, err)
+	}
+
+	orderTotals := Totals{
+		Orders:    1,
+		Revenue:   convert(parseAmount(order.TotalIncTax)),
+		Tax:       convert(parseAmount(order.TotalTax)),
+		Discounts: convert(parseAmount(order.DiscountAmount) + parseAmount(order.CouponDiscount)),
+		Refunds:   convert(parseAmount(order.RefundedAmount)),
+		Shipping:  convert(parseAmount(order.ShippingCostIncTax)),
+		Units:     order.ItemsTotal,
+	}
+
+	if len(bundle.Products) > 0 {
+		orderTotals.Units = 0
+		for _, product := range bundle.Products {
+			orderTotals.Units += product.Quantity
+
+			key := product.SKU
+			if key == "" {
+				key = "product:" + strconv.Itoa(product.ProductID)
+			}
+			sku, ok := aggregator.skus[key]
+			if !ok {
+				sku = &SKUUnits{SKU: product.SKU, Name: product.Name}
+				aggregator.skus[key] = sku
+			}
+			sku.Units += product.Quantity
+			sku.UnitsRefunded += product.QuantityRefunded
+			sku.Revenue += convert(parseAmount(product.TotalIncTax))
+		}
+	}
+	orderTotals.NetRevenue = orderTotals.Revenue - orderTotals.Refunds
+
+	for i := range bundle.Coupons {
+		coupon := bundle.Coupons[i]
+		usage, ok := aggregator.coupons[coupon.Code]
+		if !ok {
+			usage = &CouponUsage{Code: coupon.Code, Type: coupon.TypeName()}
+			aggregator.coupons[coupon.Code] = usage
+		}
+		usage.Orders++
+		usage.Discount += convert(coupon.Discount)
+	}
+
+	addTotals(&aggregator.totals, orderTotals)
+	addBreakdown(aggregator.byDay, created.In(aggregator.opts.Location).Format("2006-01-02"), orderTotals)
+	addBreakdown(aggregator.byStatus, order.Status, orderTotals)
+	addBreakdown(aggregator.byChannel, strconv.Itoa(order.ChannelID), orderTotals)
+	addBreakdown(aggregator.byPaymentMethod, order.PaymentMethod, orderTotals)
+
+	return nil
+}
+
+// Report returns the figures accumulated so far, with amounts rounded to two decimals.
+func (aggregator *Aggregator) Report() Report {
+	report := Report{
+		Totals:          roundTotals(aggregator.totals),
+		ByDay:           breakdowns(aggregator.byDay),
+		ByStatus:        breakdowns(aggregator.byStatus),
+		ByChannel:       breakdowns(aggregator.byChannel),
+		ByPaymentMethod: breakdowns(aggregator.byPaymentMethod),
+		SKUs:            []SKUUnits{},
+		Coupons:         []CouponUsage{},
+	}
+	report.AverageOrderValue = aggregator.totals.AverageOrderValue()
+
+	if len(aggregator.currencies) == 1 {
+		for currency := range aggregator.currencies {
+			report.Currency = currency
+		}
+	}
+
+	for _, sku := range aggregator.skus {
+		s := *sku
+		s.Revenue = round(s.Revenue)
+		report.SKUs = append(report.SKUs, s)
+	}
+	sort.Slice(report.SKUs, func(i, j int) bool {
+		if report.SKUs[i].Units != report.SKUs[j].Units {
+			return report.SKUs[i].Units > report.SKUs[j].Units
+		}
+		return report.SKUs[i].SKU < report.SKUs[j].SKU
+	})
+
+	for _, coupon := range aggregator.coupons {
+		c := *coupon
+		c.Discount = round(c.Discount)
+		report.Coupons = append(report.Coupons, c)
+	}
+	sort.Slice(report.Coupons, func(i, j int) bool {
+		if report.Coupons[i].Orders != report.Coupons[j].Orders {
+			return report.Coupons[i].Orders > report.Coupons[j].Orders
+		}
+		return report.Coupons[i].Code < report.Coupons[j].Code
+	})
+
+	return report
+}
+
+// Run builds a report over every order matching params, loading each page of
+// orders' products and coupons concurrently.
+func Run(client *bigcommerce.V2Client, params bigcommerce.OrderQueryParams, opts Options) (Report, error) {
+	aggregator := NewAggregator(opts)
+	bundleOpts := bigcommerce.OrderBundleOptions{
+		Include:     []bigcommerce.OrderSubResource{bigcommerce.OrderSubResourceProducts, bigcommerce.OrderSubResourceCoupons},
+		Concurrency: opts.Concurrency,
+	}
+
+	err := client.ForEachOrderPage(params, func(orders []bigcommerce.Order) error {
+		bundles, err := client.LoadOrderBundles(orders, bundleOpts)
+		if err != nil {
+			return err
+		}
+		for _, bundle := range bundles {
+			if err := aggregator.Add(bundle); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to build sales report: %w", err)
+	}
+
+	return aggregator.Report(), nil
+}
+
+func addTotals(dst *Totals, src Totals) {
+	dst.Orders += src.Orders
+	dst.Revenue += src.Revenue
+	dst.NetRevenue += src.NetRevenue
+	dst.Tax += src.Tax
+	dst.Discounts += src.Discounts
+	dst.Refunds += src.Refunds
+	dst.Shipping += src.Shipping
+	dst.Units += src.Units
+}
+
+func addBreakdown(dimension map[string]*Totals, key string, totals Totals) {
+	if key == "" {
+		key = "unknown"
+	}
+	t, ok := dimension[key]
+	if !ok {
+		t = &Totals{}
+		dimension[key] = t
+	}
+	addTotals(t, totals)
+}
+
+func breakdowns(dimension map[string]*Totals) []Breakdown {
+	result := make([]Breakdown, 0, len(dimension))
+	for key, totals := range dimension {
+		result = append(result, Breakdown{
+			Key:               key,
+			Totals:            roundTotals(*totals),
+			AverageOrderValue: totals.AverageOrderValue(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+func roundTotals(totals Totals) Totals {
+	totals.Revenue = round(totals.Revenue)
+	totals.NetRevenue = round(totals.NetRevenue)
+	totals.Tax = round(totals.Tax)
+	totals.Discounts = round(totals.Discounts)
+	totals.Refunds = round(totals.Refunds)
+	totals.Shipping = round(totals.Shipping)
+	return totals
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func parseAmount(s string) float64 {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return f
+}
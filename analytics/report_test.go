@@ -0,0 +1,121 @@
+package analytics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	bigcommerce "github.com/seanomeara96/go-bigcommerce"
+)
+
+func testBundles() []bigcommerce.OrderBundle {
+	return []bigcommerce.OrderBundle{
+		{
+			Order: bigcommerce.Order{
+				ID: 1, StatusID: 11, Status: "Awaiting Fulfillment", ChannelID: 1, PaymentMethod: "Credit Card",
+				DateCreated: "Fri, 01 Mar 2024 10:00:00 +0000", TotalIncTax: "120.00", TotalTax: "20.00",
+				DiscountAmount: "5.00", CouponDiscount: "10.00", ShippingCostIncTax: "8.00",
+				CurrencyCode: "EUR", DefaultCurrencyCode: "EUR", CurrencyExchangeRate: "1.0000",
+			},
+			Products: []bigcommerce.OrderProduct{
+				{SKU: "A", Name: "Alpha", Quantity: 2, TotalIncTax: "80.00"},
+				{SKU: "B", Name: "Beta", Quantity: 1, TotalIncTax: "32.00"},
+			},
+			Coupons: []bigcommerce.OrderCoupon{{Code: "SPRING", Type: 1, Discount: 10}},
+		},
+		{
+			Order: bigcommerce.Order{
+				ID: 2, StatusID: 2, Status: "Shipped", ChannelID: 1, PaymentMethod: "PayPal",
+				DateCreated: "Fri, 01 Mar 2024 23:30:00 +0000", TotalIncTax: "50.00", TotalTax: "10.00",
+				RefundedAmount: "25.00", CurrencyCode: "USD", DefaultCurrencyCode: "EUR", CurrencyExchangeRate: "1.2500",
+			},
+			Products: []bigcommerce.OrderProduct{
+				{SKU: "A", Name: "Alpha", Quantity: 1, QuantityRefunded: 1, TotalIncTax: "50.00"},
+			},
+		},
+		{
+			Order: bigcommerce.Order{ID: 3, StatusID: 0, DateCreated: "Sat, 02 Mar 2024 09:00:00 +0000", TotalIncTax: "999.00"},
+		},
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	aggregator := NewAggregator(Options{ConvertToDefaultCurrency: true, ExcludeStatusIDs: []int{0}})
+	for _, bundle := range testBundles() {
+		if err := aggregator.Add(bundle); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report := aggregator.Report()
+
+	if report.Currency != "EUR" {
+		t.Errorf("expected EUR, got %q", report.Currency)
+	}
+	if report.Totals.Orders != 2 || report.Totals.Revenue != 160 || report.Totals.Refunds != 20 || report.Totals.NetRevenue != 140 {
+		t.Errorf("unexpected totals: %+v", report.Totals)
+	}
+	if report.Totals.Discounts != 15 || report.Totals.Tax != 28 || report.Totals.Units != 4 {
+		t.Errorf("unexpected totals: %+v", report.Totals)
+	}
+	if report.AverageOrderValue != 80 {
+		t.Errorf("expected AOV 80, got %v", report.AverageOrderValue)
+	}
+	if len(report.ByDay) != 1 || report.ByDay[0].Key != "2024-03-01" || report.ByDay[0].Orders != 2 {
+		t.Errorf("unexpected day breakdown: %+v", report.ByDay)
+	}
+	if len(report.ByPaymentMethod) != 2 {
+		t.Errorf("unexpected payment method breakdown: %+v", report.ByPaymentMethod)
+	}
+	if len(report.SKUs) != 2 || report.SKUs[0].SKU != "A" || report.SKUs[0].Units != 3 || report.SKUs[0].UnitsRefunded != 1 || report.SKUs[0].Revenue != 120 {
+		t.Errorf("unexpected SKU units: %+v", report.SKUs)
+	}
+	if len(report.Coupons) != 1 || report.Coupons[0].Type != "percentage_discount" || report.Coupons[0].Discount != 10 {
+		t.Errorf("unexpected coupon usage: %+v", report.Coupons)
+	}
+}
+
+func TestAggregator_Location(t *testing.T) {
+	aggregator := NewAggregator(Options{Location: mustLoadLocation(t, "Asia/Tokyo")})
+	for _, bundle := range testBundles()[:2] {
+		if err := aggregator.Add(bundle); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report := aggregator.Report()
+	if len(report.ByDay) != 2 || report.ByDay[1].Key != "2024-03-02" {
+		t.Errorf("expected the late order on the next day in Tokyo, got %+v", report.ByDay)
+	}
+	if report.Currency != "" {
+		t.Errorf("expected no single currency for mixed orders, got %q", report.Currency)
+	}
+}
+
+func TestReport_WriteCSV(t *testing.T) {
+	aggregator := NewAggregator(Options{ConvertToDefaultCurrency: true})
+	for _, bundle := range testBundles()[:2] {
+		if err := aggregator.Add(bundle); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := aggregator.Report().WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[1] != "total,all,2,160.00,140.00,80.00,28.00,15.00,20.00,8.00,4,EUR" {
+		t.Errorf("unexpected total row: %s", lines[1])
+	}
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone %s unavailable: %v", name, err)
+	}
+	return location
+}
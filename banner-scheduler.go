@@ -0,0 +1,188 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// BannerTimestamp formats t the way the V2 banners API expects DateFrom and DateTo.
+func BannerTimestamp(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+// Window returns the dates a banner with custom dates is shown between. ok is
+// false for banners that are always shown or have no valid dates.
+func (banner Banner) Window() (from, to time.Time, ok bool) {
+	if banner.DateType != BannerDateTypeCustom {
+		return from, to, false
+	}
+
+	fromUnix, err := strconv.ParseInt(banner.DateFrom, 10, 64)
+	if err != nil {
+		return from, to, false
+	}
+	toUnix, err := strconv.ParseInt(banner.DateTo, 10, 64)
+	if err != nil {
+		return from, to, false
+	}
+
+	return time.Unix(fromUnix, 0).UTC(), time.Unix(toUnix, 0).UTC(), true
+}
+
+// IsExpired reports whether a banner with custom dates ended before now.
+func (banner Banner) IsExpired(now time.Time) bool {
+	_, to, ok := banner.Window()
+	return ok && !now.Before(to)
+}
+
+// Params returns params that recreate the banner, to be changed and passed to
+// UpdateBanner or CreateBanner.
+func (banner Banner) Params() CreateUpdateBannerParams {
+	return CreateUpdateBannerParams{
+		Name:     banner.Name,
+		Content:  banner.Content,
+		Page:     banner.Page,
+		Location: banner.Location,
+		DateType: banner.DateType,
+		DateFrom: banner.DateFrom,
+		DateTo:   banner.DateTo,
+		Visible:  banner.Visible,
+		ItemID:   banner.ItemID,
+	}
+}
+
+// GetAllBanners retrieves every banner. The Page and Limit fields of params are overwritten.
+func (client *V2Client) GetAllBanners(params GetBannersParams) ([]Banner, error) {
+	var banners []Banner
+	params.Page = 1
+	params.Limit = 250
+
+	for {
+		b, _, err := client.GetBanners(params)
+		if err != nil {
+			return nil, fmt.Errorf("GetAllBanners: failed at page %d: %w", params.Page, err)
+		}
+		banners = append(banners, b...)
+
+		if len(b) < params.Limit {
+			break
+		}
+
+		params.Page++
+	}
+
+	return banners, nil
+}
+
+// SetBannerWindow makes a banner show only between from and to.
+func (client *V2Client) SetBannerWindow(bannerID int, from, to time.Time) (Banner, error) {
+	banner, err := client.GetBanner(bannerID)
+	if err != nil {
+		return banner, fmt.Errorf("SetBannerWindow: %w", err)
+	}
+
+	params := banner.Params()
+	params.DateType = BannerDateTypeCustom
+	params.DateFrom = BannerTimestamp(from)
+	params.DateTo = BannerTimestamp(to)
+
+	return client.UpdateBanner(bannerID, params)
+}
+
+type BannerScheduleResult struct {
+	Enabled  []Banner
+	Disabled []Banner
+}
+
+// ApplyBannerSchedule shows every banner with custom dates whose window contains
+// now and hides the ones whose window does not. Banners that are always shown and
+// banners already in the right state are left alone.
+func (client *V2Client) ApplyBannerSchedule(now time.Time) (BannerScheduleResult, error) {
+	var result BannerScheduleResult
+
+	banners, err := client.GetAllBanners(GetBannersParams{})
+	if err != nil {
+		return result, fmt.Errorf("ApplyBannerSchedule: %w", err)
+	}
+
+	for _, banner := range banners {
+		from, to, ok := banner.Window()
+		if !ok {
+			continue
+		}
+
+		visible := BannerHidden
+		if !now.Before(from) && now.Before(to) {
+			visible = BannerVisible
+		}
+		if banner.Visible == visible {
+			continue
+		}
+
+		params := banner.Params()
+		params.Visible = visible
+
+		updated, err := client.UpdateBanner(banner.ID, params)
+		if err != nil {
+			return result, fmt.Errorf("ApplyBannerSchedule: %w", err)
+		}
+
+		if visible == BannerVisible {
+			result.Enabled = append(result.Enabled, updated)
+		} else {
+			result.Disabled = append(result.Disabled, updated)
+		}
+	}
+
+	return result, nil
+}
+
+// CloneBanner copies a banner onto the category or brand pages with the given
+// IDs. The banners created before an error are returned with it.
+func (client *V2Client) CloneBanner(bannerID int, page BannerPage, itemIDs []int) ([]Banner, error) {
+	if page != BannerPageCategory && page != BannerPageBrand {
+		return nil, fmt.Errorf("CloneBanner: page must be category_page or brand_page, got %q", page)
+	}
+
+	banner, err := client.GetBanner(bannerID)
+	if err != nil {
+		return nil, fmt.Errorf("CloneBanner: %w", err)
+	}
+
+	var clones []Banner
+	for _, itemID := range itemIDs {
+		params := banner.Params()
+		params.Page = page
+		params.ItemID = strconv.Itoa(itemID)
+
+		clone, err := client.CreateBanner(params)
+		if err != nil {
+			return clones, fmt.Errorf("CloneBanner: failed to clone banner %d onto %s %d: %w", bannerID, page, itemID, err)
+		}
+		clones = append(clones, clone)
+	}
+
+	return clones, nil
+}
+
+// ExpiredBanners returns the banners with custom dates that ended before now.
+func ExpiredBanners(banners []Banner, now time.Time) []Banner {
+	var expired []Banner
+	for _, banner := range banners {
+		if banner.IsExpired(now) {
+			expired = append(expired, banner)
+		}
+	}
+	return expired
+}
+
+// GetExpiredBanners returns every banner with custom dates that ended before now.
+func (client *V2Client) GetExpiredBanners(now time.Time) ([]Banner, error) {
+	banners, err := client.GetAllBanners(GetBannersParams{})
+	if err != nil {
+		return nil, fmt.Errorf("GetExpiredBanners: %w", err)
+	}
+
+	return ExpiredBanners(banners, now), nil
+}
@@ -0,0 +1,108 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeBannerStore struct {
+	mu      sync.Mutex
+	banners map[int]Banner
+	nextID  int
+}
+
+func (store *fakeBannerStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	id, _ := strconv.Atoi(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+
+	switch {
+	case r.Method == http.MethodGet && id == 0:
+		if r.URL.Query().Get("page") != "1" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		var banners []Banner
+		for i := 1; i <= store.nextID; i++ {
+			if banner, ok := store.banners[i]; ok {
+				banners = append(banners, banner)
+			}
+		}
+		json.NewEncoder(w).Encode(banners)
+	case r.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(store.banners[id])
+	case r.Method == http.MethodPost || r.Method == http.MethodPut:
+		var params CreateUpdateBannerParams
+		json.NewDecoder(r.Body).Decode(&params)
+		if id == 0 {
+			store.nextID++
+			id = store.nextID
+		}
+		banner := Banner{
+			ID: id, Name: params.Name, Content: params.Content, Page: params.Page, Location: params.Location,
+			DateType: params.DateType, DateFrom: params.DateFrom, DateTo: params.DateTo, Visible: params.Visible, ItemID: params.ItemID,
+		}
+		store.banners[id] = banner
+		json.NewEncoder(w).Encode(banner)
+	}
+}
+
+func TestApplyBannerSchedule(t *testing.T) {
+	now := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+	window := func(from, to time.Time) Banner {
+		return Banner{
+			Name: "Sale", Content: "<p>Sale</p>", Page: BannerPageHome, Location: BannerLocationTop,
+			DateType: BannerDateTypeCustom, DateFrom: BannerTimestamp(from), DateTo: BannerTimestamp(to),
+		}
+	}
+
+	current := window(now.Add(-time.Hour), now.Add(time.Hour))
+	current.ID, current.Visible = 1, BannerHidden
+	expired := window(now.Add(-48*time.Hour), now.Add(-24*time.Hour))
+	expired.ID, expired.Visible = 2, BannerVisible
+	always := Banner{ID: 3, Name: "Always", Content: "x", Page: BannerPageHome, Location: BannerLocationTop, DateType: BannerDateTypeAlways, Visible: BannerHidden}
+
+	store := &fakeBannerStore{nextID: 3, banners: map[int]Banner{1: current, 2: expired, 3: always}}
+	client := newTestServerClient(t, store)
+
+	result, err := client.V2.ApplyBannerSchedule(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Enabled) != 1 || result.Enabled[0].ID != 1 || len(result.Disabled) != 1 || result.Disabled[0].ID != 2 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if store.banners[3].Visible != BannerHidden {
+		t.Error("expected banners without custom dates to be left alone")
+	}
+
+	expiredBanners, err := client.V2.GetExpiredBanners(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expiredBanners) != 1 || expiredBanners[0].ID != 2 {
+		t.Errorf("expected banner 2 to be expired, got %+v", expiredBanners)
+	}
+}
+
+func TestCloneBanner(t *testing.T) {
+	store := &fakeBannerStore{nextID: 1, banners: map[int]Banner{1: {
+		ID: 1, Name: "Brand", Content: "x", Page: BannerPageHome, Location: BannerLocationBottom,
+		DateType: BannerDateTypeAlways, Visible: BannerVisible,
+	}}}
+	client := newTestServerClient(t, store)
+
+	clones, err := client.V2.CloneBanner(1, BannerPageBrand, []int{4, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(clones) != 2 || clones[1].Page != BannerPageBrand || clones[1].ItemID != "5" || clones[1].Location != BannerLocationBottom {
+		t.Errorf("unexpected clones %+v", clones)
+	}
+}
@@ -5,18 +5,60 @@ import (
 	"strconv"
 )
 
+// BannerPage is the type of storefront page a banner is shown on
+type BannerPage string
+
+const (
+	BannerPageHome     BannerPage = "home_page"
+	BannerPageCategory BannerPage = "category_page"
+	BannerPageBrand    BannerPage = "brand_page"
+	BannerPageSearch   BannerPage = "search_page"
+)
+
+// AllowedBannerPages is a slice containing all the possible values for BannerPage.
+var AllowedBannerPages = []BannerPage{
+	BannerPageHome,
+	BannerPageCategory,
+	BannerPageBrand,
+	BannerPageSearch,
+}
+
+type BannerLocation string
+
+const (
+	BannerLocationTop    BannerLocation = "top"
+	BannerLocationBottom BannerLocation = "bottom"
+)
+
+// BannerDateType decides whether a banner is always shown or only between
+// DateFrom and DateTo
+type BannerDateType string
+
+const (
+	BannerDateTypeAlways BannerDateType = "always"
+	BannerDateTypeCustom BannerDateType = "custom"
+)
+
+// BannerVisibility is sent by the V2 API as the strings "1" and "0"
+type BannerVisibility string
+
+const (
+	BannerVisible BannerVisibility = "1"
+	BannerHidden  BannerVisibility = "0"
+)
+
 type Banner struct {
-	ID          int    `json:"id"`
-	DateCreated string `json:"date_created"`
-	Name        string `json:"name"`
-	Content     string `json:"content"`
-	Page        string `json:"page"`
-	Location    string `json:"location"`
-	DateType    string `json:"date_type"`
-	DateFrom    string `json:"date_from,omitempty"`
-	DateTo      string `json:"date_to,omitempty"`
-	Visible     string `json:"visible"`
-	ItemID      string `json:"item_id,omitempty"`
+	ID          int              `json:"id"`
+	DateCreated string           `json:"date_created"`
+	Name        string           `json:"name"`
+	Content     string           `json:"content"`
+	Page        BannerPage       `json:"page"`
+	Location    BannerLocation   `json:"location"`
+	DateType    BannerDateType   `json:"date_type"`
+	DateFrom    string           `json:"date_from,omitempty"`
+	DateTo      string           `json:"date_to,omitempty"`
+	Visible     BannerVisibility `json:"visible"`
+	ItemID      string           `json:"item_id,omitempty"`
 }
 type GetBannersParams struct {
 	MinID int `url:"min_id,omitempty"`
@@ -26,15 +68,16 @@ type GetBannersParams struct {
 }
 
 type CreateUpdateBannerParams struct {
-	Name     string `json:"name" binding:"required"`
-	Content  string `json:"content" binding:"required"`
-	Page     string `json:"page" binding:"required"`
-	Location string `json:"location" binding:"required"`
-	DateType string `json:"date_type" binding:"required"`
-	DateFrom string `json:"date_from,omitempty"`
-	DateTo   string `json:"date_to,omitempty"`
-	Visible  string `json:"visible,omitempty"`
-	ItemID   string `json:"item_id,omitempty"`
+	Name     string         `json:"name" binding:"required"`
+	Content  string         `json:"content" binding:"required"`
+	Page     BannerPage     `json:"page" binding:"required"`
+	Location BannerLocation `json:"location" binding:"required"`
+	DateType BannerDateType `json:"date_type" binding:"required"`
+	// DateFrom and DateTo are unix timestamps, see BannerTimestamp.
+	DateFrom string           `json:"date_from,omitempty"`
+	DateTo   string           `json:"date_to,omitempty"`
+	Visible  BannerVisibility `json:"visible,omitempty"`
+	ItemID   string           `json:"item_id,omitempty"`
 }
 
 type ValidationErrors []string
@@ -54,32 +97,60 @@ func validateBannerParams(params CreateUpdateBannerParams) error {
 		errors = append(errors, "Content is required")
 	}
 
-	if params.Page == "" {
+	switch params.Page {
+	case "":
 		errors = append(errors, "Page is required")
+	case BannerPageHome, BannerPageCategory, BannerPageBrand, BannerPageSearch:
+	default:
+		errors = append(errors, fmt.Sprintf("Page %q is not one of %v", params.Page, AllowedBannerPages))
 	}
 
-	if params.Location == "" {
+	switch params.Location {
+	case "":
 		errors = append(errors, "Location is required")
+	case BannerLocationTop, BannerLocationBottom:
+	default:
+		errors = append(errors, fmt.Sprintf("Location %q must be 'top' or 'bottom'", params.Location))
 	}
 
-	if params.DateType == "" {
+	switch params.DateType {
+	case "":
 		errors = append(errors, "DateType is required")
+	case BannerDateTypeAlways, BannerDateTypeCustom:
+	default:
+		errors = append(errors, fmt.Sprintf("DateType %q must be 'always' or 'custom'", params.DateType))
 	}
 
-	if params.DateType == "custom" {
+	if params.DateType == BannerDateTypeCustom {
 		if params.DateFrom == "" {
 			errors = append(errors, "DateFrom is required when DateType is 'custom'")
 		}
 		if params.DateTo == "" {
 			errors = append(errors, "DateTo is required when DateType is 'custom'")
 		}
+
+		from, fromErr := strconv.ParseInt(params.DateFrom, 10, 64)
+		if params.DateFrom != "" && fromErr != nil {
+			errors = append(errors, fmt.Sprintf("DateFrom %q must be a unix timestamp", params.DateFrom))
+		}
+		to, toErr := strconv.ParseInt(params.DateTo, 10, 64)
+		if params.DateTo != "" && toErr != nil {
+			errors = append(errors, fmt.Sprintf("DateTo %q must be a unix timestamp", params.DateTo))
+		}
+		if fromErr == nil && toErr == nil && to <= from {
+			errors = append(errors, "DateTo must be after DateFrom")
+		}
 	}
 
-	if params.Visible == "" {
+	switch params.Visible {
+	case "":
 		errors = append(errors, "Visible is required")
+	case BannerVisible, BannerHidden:
+	default:
+		errors = append(errors, fmt.Sprintf("Visible %q must be '1' or '0'", params.Visible))
 	}
 
-	if params.ItemID == "" && (params.Page == "category_page" || params.Page == "brand_page") {
+	if params.ItemID == "" && (params.Page == BannerPageCategory || params.Page == BannerPageBrand) {
 		errors = append(errors, "ItemID is required for category_page or brand_page")
 	}
 
@@ -0,0 +1,380 @@
+package bigcommerce
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/seanomeara96/go-bigcommerce/internal/frontmatter"
+	"github.com/seanomeara96/go-bigcommerce/internal/markdown"
+)
+
+// BlogMarkdown is a blog post as written to a Markdown file: YAML front matter
+// holding the post's fields followed by the body converted to Markdown.
+type BlogMarkdown struct {
+	Title           string
+	URL             string
+	Tags            []string
+	Author          string
+	PublishedDate   time.Time
+	IsPublished     bool
+	MetaDescription string
+	MetaKeywords    string
+	ThumbnailPath   string
+	// Body is Markdown.
+	Body string
+}
+
+// NewBlogMarkdown converts a blog post for writing to a Markdown file.
+func NewBlogMarkdown(blog Blog) BlogMarkdown {
+	published, _ := parseBlogDate(blog.PublishedDateISO8601)
+
+	return BlogMarkdown{
+		Title:           blog.Title,
+		URL:             blog.URL,
+		Tags:            blog.Tags,
+		Author:          blog.Author,
+		PublishedDate:   published,
+		IsPublished:     blog.IsPublished,
+		MetaDescription: blog.MetaDescription,
+		MetaKeywords:    blog.MetaKeywords,
+		ThumbnailPath:   blog.ThumbnailPath,
+		Body:            markdown.FromHTML(blog.Body),
+	}
+}
+
+// Marshal writes the post as front matter and Markdown.
+func (post BlogMarkdown) Marshal() []byte {
+	var b bytes.Buffer
+
+	b.WriteString("---\n")
+	writeFrontMatterString(&b, "title", post.Title)
+	writeFrontMatterString(&b, "url", post.URL)
+	b.WriteString("tags: [")
+	for i, tag := range post.Tags {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(frontmatter.Quote(tag))
+	}
+	b.WriteString("]\n")
+	writeFrontMatterString(&b, "author", post.Author)
+	if !post.PublishedDate.IsZero() {
+		writeFrontMatterString(&b, "published_date", post.PublishedDate.Format(time.RFC3339))
+	}
+	fmt.Fprintf(&b, "is_published: %t\n", post.IsPublished)
+	writeFrontMatterString(&b, "meta_description", post.MetaDescription)
+	writeFrontMatterString(&b, "meta_keywords", post.MetaKeywords)
+	writeFrontMatterString(&b, "thumbnail_path", post.ThumbnailPath)
+	b.WriteString("---\n\n")
+
+	b.WriteString(strings.TrimSpace(post.Body))
+	b.WriteString("\n")
+
+	return b.Bytes()
+}
+
+func writeFrontMatterString(b *bytes.Buffer, key, value string) {
+	fmt.Fprintf(b, "%s: %s\n", key, frontmatter.Quote(value))
+}
+
+// ParseBlogMarkdown reads a post written by BlogMarkdown.Marshal. The front matter
+// may also be edited by hand using plain, single or double quoted scalars and
+// flow or block lists. Unknown keys are ignored.
+func ParseBlogMarkdown(data []byte) (BlogMarkdown, error) {
+	var post BlogMarkdown
+
+	frontMatter, body, err := frontmatter.Split(string(data))
+	if err != nil {
+		return post, err
+	}
+	post.Body = strings.TrimSpace(body)
+
+	fields, err := frontmatter.Parse(frontMatter)
+	if err != nil {
+		return post, err
+	}
+
+	for key, value := range fields {
+		switch key {
+		case "title":
+			post.Title = value.Scalar
+		case "url":
+			post.URL = value.Scalar
+		case "tags":
+			post.Tags = value.List
+			if value.List == nil && value.Scalar != "" {
+				post.Tags = []string{value.Scalar}
+			}
+		case "author":
+			post.Author = value.Scalar
+		case "published_date":
+			if value.Scalar != "" {
+				post.PublishedDate, err = parseBlogDate(value.Scalar)
+				if err != nil {
+					return post, fmt.Errorf("published_date: %w", err)
+				}
+			}
+		case "is_published":
+			post.IsPublished, err = strconv.ParseBool(value.Scalar)
+			if err != nil {
+				return post, fmt.Errorf("is_published must be true or false, got %q", value.Scalar)
+			}
+		case "meta_description":
+			post.MetaDescription = value.Scalar
+		case "meta_keywords":
+			post.MetaKeywords = value.Scalar
+		case "thumbnail_path":
+			post.ThumbnailPath = value.Scalar
+		}
+	}
+
+	if post.Title == "" {
+		return post, fmt.Errorf("title is required")
+	}
+
+	return post, nil
+}
+
+func parseBlogDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.RFC1123Z, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised date %q, use a date such as 2024-03-01T10:00:00Z", value)
+}
+
+// CreateParams returns params creating the post.
+func (post BlogMarkdown) CreateParams() CreateBlogParams {
+	params := CreateBlogParams{
+		Title:           post.Title,
+		URL:             post.URL,
+		Body:            markdown.ToHTML(post.Body),
+		Tags:            post.Tags,
+		IsPublished:     post.IsPublished,
+		MetaDescription: post.MetaDescription,
+		MetaKeywords:    post.MetaKeywords,
+		Author:          post.Author,
+		ThumbnailPath:   post.ThumbnailPath,
+	}
+	if !post.PublishedDate.IsZero() {
+		params.PublishedDate = post.PublishedDate.Format(time.RFC1123Z)
+	}
+	return params
+}
+
+// UpdateParams returns params setting every field of an existing post to the
+// values in the file.
+func (post BlogMarkdown) UpdateParams() UpdateBlogParams {
+	published := post.IsPublished
+	params := UpdateBlogParams{
+		Title:           post.Title,
+		URL:             post.URL,
+		Body:            markdown.ToHTML(post.Body),
+		Tags:            post.Tags,
+		IsPublished:     &published,
+		MetaDescription: post.MetaDescription,
+		MetaKeywords:    post.MetaKeywords,
+		Author:          post.Author,
+		ThumbnailPath:   post.ThumbnailPath,
+	}
+	if !post.PublishedDate.IsZero() {
+		params.PublishedDate = post.PublishedDate.Format(time.RFC1123Z)
+	}
+	return params
+}
+
+// BlogFieldDiff is a field that differs between a Markdown file and the post in
+// the store. Bodies are compared as Markdown, after the file's body has been
+// converted to the HTML an import would store, so a difference is reported
+// when the stored post would not read back as the file does.
+type BlogFieldDiff struct {
+	Field string
+	Store string
+	File  string
+}
+
+// Diff returns the fields of blog that importing post would change.
+func (post BlogMarkdown) Diff(blog Blog) []BlogFieldDiff {
+	current := NewBlogMarkdown(blog)
+	var diffs []BlogFieldDiff
+
+	compare := func(field, store, file string) {
+		if store != file {
+			diffs = append(diffs, BlogFieldDiff{Field: field, Store: store, File: file})
+		}
+	}
+
+	compare("title", current.Title, post.Title)
+	compare("url", current.URL, post.URL)
+	compare("tags", strings.Join(current.Tags, ", "), strings.Join(post.Tags, ", "))
+	compare("author", current.Author, post.Author)
+	if !post.PublishedDate.IsZero() && !post.PublishedDate.Equal(current.PublishedDate) {
+		compare("published_date", formatBlogDate(current.PublishedDate), formatBlogDate(post.PublishedDate))
+	}
+	compare("is_published", strconv.FormatBool(current.IsPublished), strconv.FormatBool(post.IsPublished))
+	compare("meta_description", current.MetaDescription, post.MetaDescription)
+	compare("meta_keywords", current.MetaKeywords, post.MetaKeywords)
+	compare("thumbnail_path", current.ThumbnailPath, post.ThumbnailPath)
+	if stored := markdown.FromHTML(markdown.ToHTML(post.Body)); strings.TrimSpace(current.Body) != strings.TrimSpace(stored) {
+		diffs = append(diffs, BlogFieldDiff{Field: "body", Store: strings.TrimSpace(current.Body), File: strings.TrimSpace(post.Body)})
+	}
+
+	return diffs
+}
+
+func formatBlogDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// blogMarkdownFileName names the file for a post after the last segment of its URL.
+func blogMarkdownFileName(blog Blog) string {
+	slug := path.Base(strings.Trim(blog.URL, "/"))
+	if slug == "." || slug == "" || slug == "/" {
+		slug = "post-" + strconv.Itoa(blog.ID)
+	}
+	return slug + ".md"
+}
+
+// ExportBlogPostsMarkdown writes every post matching params to dir as Markdown
+// files named after the post URL, and returns the paths written.
+func (client *V2Client) ExportBlogPostsMarkdown(dir string, params BlogQueryParams) ([]string, error) {
+	blogs, err := client.GetAllBlogPosts(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	var paths []string
+	used := map[string]bool{}
+	for _, blog := range blogs {
+		name := blogMarkdownFileName(blog)
+		if used[name] {
+			name = strings.TrimSuffix(name, ".md") + "-" + strconv.Itoa(blog.ID) + ".md"
+		}
+		used[name] = true
+
+		filePath := filepath.Join(dir, name)
+		if err := os.WriteFile(filePath, NewBlogMarkdown(blog).Marshal(), 0o644); err != nil {
+			return paths, fmt.Errorf("failed to write blog %d: %w", blog.ID, err)
+		}
+		paths = append(paths, filePath)
+	}
+
+	return paths, nil
+}
+
+type BlogImportOptions struct {
+	// DryRun reports what would change without creating or updating posts.
+	DryRun bool
+}
+
+type BlogImportAction string
+
+const (
+	BlogImportCreate    BlogImportAction = "create"
+	BlogImportUpdate    BlogImportAction = "update"
+	BlogImportUnchanged BlogImportAction = "unchanged"
+)
+
+type BlogImportChange struct {
+	File   string
+	URL    string
+	Action BlogImportAction
+	// BlogID is zero for posts not yet created.
+	BlogID int
+	Diffs  []BlogFieldDiff
+}
+
+type BlogImportResult struct {
+	Changes []BlogImportChange
+}
+
+// ImportBlogMarkdown creates or updates a post for every .md file in dir. Files
+// are matched to existing posts by URL, so a file without a url creates a new
+// post every time it is imported. With DryRun set nothing is written and the
+// result describes what an import would do.
+func (client *V2Client) ImportBlogMarkdown(dir string, opts BlogImportOptions) (BlogImportResult, error) {
+	var result BlogImportResult
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return result, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	sort.Strings(files)
+
+	blogs, err := client.GetAllBlogPosts(BlogQueryParams{})
+	if err != nil {
+		return result, err
+	}
+	byURL := map[string]Blog{}
+	for _, blog := range blogs {
+		byURL[normalizeBlogURL(blog.URL)] = blog
+	}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return result, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		post, err := ParseBlogMarkdown(data)
+		if err != nil {
+			return result, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+
+		change := BlogImportChange{File: file, URL: post.URL}
+
+		existing, ok := byURL[normalizeBlogURL(post.URL)]
+		if post.URL == "" || !ok {
+			change.Action = BlogImportCreate
+			if !opts.DryRun {
+				created, err := client.CreateBlog(post.CreateParams())
+				if err != nil {
+					return result, fmt.Errorf("failed to import %s: %w", file, err)
+				}
+				change.BlogID = created.ID
+				change.URL = created.URL
+			}
+			result.Changes = append(result.Changes, change)
+			continue
+		}
+
+		change.BlogID = existing.ID
+		change.Diffs = post.Diff(existing)
+		if len(change.Diffs) == 0 {
+			change.Action = BlogImportUnchanged
+			result.Changes = append(result.Changes, change)
+			continue
+		}
+
+		change.Action = BlogImportUpdate
+		if !opts.DryRun {
+			if _, err := client.UpdateBlog(existing.ID, post.UpdateParams()); err != nil {
+				return result, fmt.Errorf("failed to import %s: %w", file, err)
+			}
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	return result, nil
+}
+
+func normalizeBlogURL(u string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(u), "/"))
+}
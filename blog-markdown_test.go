@@ -0,0 +1,109 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestBlogMarkdown_RoundTrip(t *testing.T) {
+	post := BlogMarkdown{
+		Title:           `Spring "sale": 20% off`,
+		URL:             "/blog/spring-sale/",
+		Tags:            []string{"news", "sale, spring"},
+		Author:          "Jane Doe",
+		PublishedDate:   time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		IsPublished:     true,
+		MetaDescription: "Everything <20% off>",
+		ThumbnailPath:   "images/spring.png",
+		Body:            "Our **new** range.",
+	}
+
+	parsed, err := ParseBlogMarkdown(post.Marshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(post, parsed) {
+		t.Errorf("post did not round-trip:\n%+v\n%+v", post, parsed)
+	}
+}
+
+func TestParseBlogMarkdown_HandWritten(t *testing.T) {
+	post, err := ParseBlogMarkdown([]byte("---\ntitle: Hello world\ntags:\n  - one\n  - 'two'\npublished_date: 2024-03-01\nis_published: false\n---\n\n# Hi\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.Title != "Hello world" || !reflect.DeepEqual(post.Tags, []string{"one", "two"}) || post.PublishedDate.Day() != 1 || post.Body != "# Hi" {
+		t.Errorf("unexpected post %+v", post)
+	}
+
+	if _, err := ParseBlogMarkdown([]byte("# No front matter")); err == nil {
+		t.Error("expected an error without front matter")
+	}
+}
+
+func TestImportBlogMarkdown_DryRun(t *testing.T) {
+	existing := []Blog{
+		{ID: 1, Title: "Unchanged", URL: "/blog/unchanged/", Body: "<p>Same</p>", IsPublished: true},
+		{ID: 2, Title: "Old title", URL: "/blog/changed/", Body: "<p>Old</p>", IsPublished: true},
+	}
+	client := newTestServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("dry run sent %s %s", r.Method, r.URL)
+		}
+		if r.URL.Query().Get("page") != "1" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json.NewEncoder(w).Encode(existing)
+	}))
+
+	dir := t.TempDir()
+	files := map[string]BlogMarkdown{
+		"a-unchanged.md": NewBlogMarkdown(existing[0]),
+		"b-changed.md":   {Title: "New title", URL: "/blog/changed", Body: "New", IsPublished: true},
+		"c-new.md":       {Title: "Brand new", URL: "/blog/new/", Body: "Hello"},
+	}
+	for name, post := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), post.Marshal(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := client.V2.ImportBlogMarkdown(dir, BlogImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Changes) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", result.Changes)
+	}
+	if result.Changes[0].Action != BlogImportUnchanged {
+		t.Errorf("expected unchanged, got %+v", result.Changes[0])
+	}
+	if change := result.Changes[1]; change.Action != BlogImportUpdate || change.BlogID != 2 || len(change.Diffs) != 3 {
+		t.Errorf("expected title, url and body to change on post 2, got %+v", change)
+	}
+	if result.Changes[2].Action != BlogImportCreate {
+		t.Errorf("expected create, got %+v", result.Changes[2])
+	}
+}
+
+func TestBlogMarkdown_DiffBody(t *testing.T) {
+	blog := Blog{Title: "Plans", Body: "<p>2024. was a good year</p><p>- not a list</p>"}
+
+	post := NewBlogMarkdown(blog)
+	if diffs := post.Diff(blog); len(diffs) != 0 {
+		t.Fatalf("expected an exported post to match its store copy, got %+v", diffs)
+	}
+
+	post.Body = "2024. was a good year\n\n- not a list"
+	diffs := post.Diff(blog)
+	if len(diffs) != 1 || diffs[0].Field != "body" {
+		t.Fatalf("expected unescaped list markers to be reported, got %+v", diffs)
+	}
+}
@@ -3,6 +3,7 @@ package bigcommerce
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 type Blog struct {
@@ -23,11 +24,25 @@ type Blog struct {
 }
 
 type UpdateBlogParams struct {
-	Title           string   `json:"title,omitempty"`
+	Title string   `json:"title,omitempty"`
+	URL   string   `json:"url,omitempty"`
+	Body  string   `json:"body,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+	// IsPublished is a pointer so that a post can be unpublished.
+	IsPublished     *bool  `json:"is_published,omitempty"`
+	MetaDescription string `json:"meta_description,omitempty"`
+	MetaKeywords    string `json:"meta_keywords,omitempty"`
+	Author          string `json:"author,omitempty"`
+	ThumbnailPath   string `json:"thumbnail_path,omitempty"`
+	PublishedDate   string `json:"published_date,omitempty"`
+}
+
+type CreateBlogParams struct {
+	Title           string   `json:"title"`
 	URL             string   `json:"url,omitempty"`
-	Body            string   `json:"body,omitempty"`
+	Body            string   `json:"body"`
 	Tags            []string `json:"tags,omitempty"`
-	IsPublished     bool     `json:"is_published,omitempty"`
+	IsPublished     bool     `json:"is_published"`
 	MetaDescription string   `json:"meta_description,omitempty"`
 	MetaKeywords    string   `json:"meta_keywords,omitempty"`
 	Author          string   `json:"author,omitempty"`
@@ -35,6 +50,24 @@ type UpdateBlogParams struct {
 	PublishedDate   string   `json:"published_date,omitempty"`
 }
 
+type BlogQueryParams struct {
+	IsPublished *bool  `url:"is_published,omitempty"`
+	URL         string `url:"url,omitempty"`
+	Tag         string `url:"tag,omitempty"`
+	// PublishedDateMin and PublishedDateMax are RFC 2822 dates.
+	PublishedDateMin string `url:"published_date:min,omitempty"`
+	PublishedDateMax string `url:"published_date:max,omitempty"`
+	// Author is not supported by the API and is applied to the results instead.
+	Author string `url:"-"`
+	Page   int    `url:"page,omitempty"`
+	Limit  int    `url:"limit,omitempty"`
+}
+
+type BlogTag struct {
+	Tag     string `json:"tag"`
+	PostIDs []int  `json:"post_ids"`
+}
+
 type Date struct {
 	Date         string `json:"date"`
 	TimezoneType int    `json:"timezone_type"`
@@ -67,7 +100,7 @@ func (client *V2Client) GetBlog(id int) (Blog, error) {
 
 	path := client.constructURL("/blog/posts", strconv.Itoa(id))
 
-	if err := client.Get(path, &response); err != nil {
+	if err := client.Get(path, &response.Data); err != nil {
 		return response.Data, fmt.Errorf("failed to get blog with ID %d: %w", id, err)
 	}
 
@@ -110,3 +143,193 @@ func (client *V2Client) UpdateBlog(blogId int, params UpdateBlogParams) (Blog, e
 
 	return response.Data, nil
 }
+
+// GetBlogPosts retrieves one page of blog posts matching the given filters.
+//
+// Parameters:
+//   - params: BlogQueryParams with the filters and page to retrieve.
+//
+// Returns:
+//   - []Blog: The blog posts on the page.
+//   - error: An error if the request fails, or nil if successful.
+//
+// Example usage:
+//
+//	published := true
+//	posts, err := client.V2.GetBlogPosts(BlogQueryParams{IsPublished: &published, Tag: "news"})
+//	if err != nil {
+//	    log.Fatalf("Failed to get blog posts: %v", err)
+//	}
+func (client *V2Client) GetBlogPosts(params BlogQueryParams) ([]Blog, error) {
+	type ResponseObject struct {
+		Data []Blog   `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	path, err := urlWithQueryParams(client.constructURL("/blog/posts"), params)
+	if err != nil {
+		return response.Data, fmt.Errorf("failed to construct URL with query params: %w", err)
+	}
+
+	if err := client.Get(path, &response.Data); err != nil {
+		return response.Data, fmt.Errorf("failed to get blog posts: %w", err)
+	}
+
+	return filterBlogsByAuthor(response.Data, params.Author), nil
+}
+
+// GetAllBlogPosts retrieves every blog post matching the given filters.
+//
+// Parameters:
+//   - params: BlogQueryParams with the filters. Page and Limit are overwritten.
+//
+// Returns:
+//   - []Blog: All matching blog posts.
+//   - error: An error if any request fails, or nil if successful.
+//
+// Example usage:
+//
+//	posts, err := client.V2.GetAllBlogPosts(BlogQueryParams{Author: "Jane"})
+//	if err != nil {
+//	    log.Fatalf("Failed to get blog posts: %v", err)
+//	}
+func (client *V2Client) GetAllBlogPosts(params BlogQueryParams) ([]Blog, error) {
+	var blogs []Blog
+	author := params.Author
+	params.Author = ""
+	params.Page = 1
+	params.Limit = 250
+
+	for {
+		b, err := client.GetBlogPosts(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get all blog posts at page %d: %w", params.Page, err)
+		}
+		blogs = append(blogs, b...)
+
+		if len(b) < params.Limit {
+			break
+		}
+
+		params.Page++
+	}
+
+	return filterBlogsByAuthor(blogs, author), nil
+}
+
+func filterBlogsByAuthor(blogs []Blog, author string) []Blog {
+	if author == "" {
+		return blogs
+	}
+
+	filtered := []Blog{}
+	for _, blog := range blogs {
+		if strings.EqualFold(strings.TrimSpace(blog.Author), strings.TrimSpace(author)) {
+			filtered = append(filtered, blog)
+		}
+	}
+	return filtered
+}
+
+// CreateBlog creates a new blog post.
+//
+// Parameters:
+//   - params: CreateBlogParams describing the post. Title and Body are required.
+//
+// Returns:
+//   - Blog: The created blog post.
+//   - error: An error if the request fails, or nil if successful.
+//
+// Example usage:
+//
+//	blog, err := client.V2.CreateBlog(CreateBlogParams{
+//	    Title:       "Spring collection",
+//	    Body:        "<p>Our spring collection is here.</p>",
+//	    IsPublished: true,
+//	})
+//	if err != nil {
+//	    log.Fatalf("Failed to create blog: %v", err)
+//	}
+func (client *V2Client) CreateBlog(params CreateBlogParams) (Blog, error) {
+	type ResponseObject struct {
+		Data Blog     `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	if params.Title == "" || params.Body == "" {
+		return response.Data, fmt.Errorf("failed to create blog: Title and Body are required")
+	}
+
+	path := client.constructURL("/blog/posts")
+
+	if err := client.Post(path, params, &response.Data); err != nil {
+		return response.Data, fmt.Errorf("failed to create blog %q: %w", params.Title, err)
+	}
+
+	return response.Data, nil
+}
+
+// DeleteBlog deletes the blog post with the specified ID.
+//
+// Parameters:
+//   - id: The unique identifier of the blog post to delete.
+//
+// Returns:
+//   - error: An error if the request fails, or nil if successful.
+func (client *V2Client) DeleteBlog(id int) error {
+	path := client.constructURL("/blog/posts", strconv.Itoa(id))
+
+	if err := client.Delete(path, nil); err != nil {
+		return fmt.Errorf("failed to delete blog with ID %d: %w", id, err)
+	}
+
+	return nil
+}
+
+// DeleteBlogs deletes several blog posts in one request. Without IDs nothing is
+// deleted, as the API would otherwise delete every post.
+//
+// Parameters:
+//   - ids: The unique identifiers of the blog posts to delete.
+//
+// Returns:
+//   - error: An error if the request fails, or nil if successful.
+func (client *V2Client) DeleteBlogs(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	params := struct {
+		IDIn []int `url:"id:in,comma"`
+	}{IDIn: ids}
+
+	path, err := urlWithQueryParams(client.constructURL("/blog/posts"), params)
+	if err != nil {
+		return fmt.Errorf("failed to construct URL with query params: %w", err)
+	}
+
+	if err := client.Delete(path, nil); err != nil {
+		return fmt.Errorf("failed to delete blogs %v: %w", ids, err)
+	}
+
+	return nil
+}
+
+// GetBlogTags retrieves every blog tag with the IDs of the posts using it.
+//
+// Returns:
+//   - []BlogTag: The blog tags.
+//   - error: An error if the request fails, or nil if successful.
+func (client *V2Client) GetBlogTags() ([]BlogTag, error) {
+	var tags []BlogTag
+
+	path := client.constructURL("/blog/tags")
+
+	if err := client.Get(path, &tags); err != nil {
+		return tags, fmt.Errorf("failed to get blog tags: %w", err)
+	}
+
+	return tags, nil
+}
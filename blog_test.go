@@ -0,0 +1,76 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetAllBlogPosts(t *testing.T) {
+	client := newTestServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("is_published") != "true" || query.Get("tag") != "news" || query.Has("author") {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		if query.Get("page") != "1" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json.NewEncoder(w).Encode([]Blog{{ID: 1, Author: "Jane Doe"}, {ID: 2, Author: "John"}, {ID: 3, Author: "jane doe "}})
+	}))
+
+	published := true
+	blogs, err := client.V2.GetAllBlogPosts(BlogQueryParams{IsPublished: &published, Tag: "news", Author: "Jane Doe"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blogs) != 2 || blogs[0].ID != 1 || blogs[1].ID != 3 {
+		t.Errorf("expected posts 1 and 3, got %+v", blogs)
+	}
+}
+
+func TestGetBlog_DecodesBody(t *testing.T) {
+	client := newTestServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Blog{ID: 7, Title: "Hello"})
+	}))
+
+	blog, err := client.V2.GetBlog(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blog.ID != 7 || blog.Title != "Hello" {
+		t.Errorf("unexpected blog %+v", blog)
+	}
+}
+
+func TestDeleteBlogs(t *testing.T) {
+	client := newTestServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Query().Get("id:in") != "1,2" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	if err := client.V2.DeleteBlogs([]int{1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.V2.DeleteBlogs(nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateBlog_Unpublishes(t *testing.T) {
+	var body map[string]interface{}
+	client := newTestServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		json.NewEncoder(w).Encode(Blog{ID: 7})
+	}))
+
+	unpublished := false
+	if _, err := client.V2.UpdateBlog(7, UpdateBlogParams{IsPublished: &unpublished}); err != nil {
+		t.Fatal(err)
+	}
+	if published, ok := body["is_published"]; !ok || published != false {
+		t.Errorf("expected is_published false to be sent, got %v", body)
+	}
+}
@@ -17,8 +17,8 @@ type Brand struct {
 
 type BrandQueryParams struct {
 	ID            int    `url:"id,omitempty"`
-	IDIn          []int  `url:"id:in,omitempty"`
-	IDNotIn       []int  `url:"id:not_in,omitempty"`
+	IDIn          []int  `url:"id:in,omitempty,comma"`
+	IDNotIn       []int  `url:"id:not_in,omitempty,comma"`
 	IDMin         []int  `url:"id:min,omitempty"`
 	IDMax         []int  `url:"id:max,omitempty"`
 	IDGreater     []int  `url:"id:greater,omitempty"`
@@ -87,3 +87,47 @@ func (client *V3Client) GetAllBrands(params BrandQueryParams) ([]Brand, error) {
 	}
 	return brands, nil
 }
+
+type UpdateBrandParams struct {
+	Name            string     `json:"name,omitempty"`
+	PageTitle       string     `json:"page_title,omitempty"`
+	MetaKeywords    []string   `json:"meta_keywords,omitempty"`
+	MetaDescription string     `json:"meta_description,omitempty"`
+	ImageURL        string     `json:"image_url,omitempty"`
+	SearchKeywords  string     `json:"search_keywords,omitempty"`
+	CustomURL       *CustomURL `json:"custom_url,omitempty"`
+}
+
+// UpdateBrand updates a brand. When the redirect guard is enabled and params
+// changes the brand's custom URL, the old URL is redirected to the brand.
+func (client *V3Client) UpdateBrand(id int, params UpdateBrandParams) (Brand, error) {
+	type ResponseObject struct {
+		Data Brand    `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+
+	var response ResponseObject
+
+	oldPath := ""
+	if client.redirectGuard != nil && params.CustomURL != nil && params.CustomURL.URL != "" {
+		current, err := client.GetBrand(id)
+		if err != nil {
+			return Brand{}, fmt.Errorf("failed to get current URL of brand %d: %w", id, err)
+		}
+		oldPath = current.CustomURL.URL
+	}
+
+	brandURL := client.constructURL("/catalog/brands", strconv.Itoa(id))
+
+	if err := client.Put(brandURL, params, &response); err != nil {
+		return Brand{}, fmt.Errorf("failed to update brand with ID %d: %w", id, err)
+	}
+
+	if oldPath != "" {
+		if err := client.guardURLChange(RedirectToBrand, id, oldPath, response.Data.CustomURL.URL); err != nil {
+			return response.Data, err
+		}
+	}
+
+	return response.Data, nil
+}
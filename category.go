@@ -25,8 +25,8 @@ type Category struct {
 
 type CategoryQueryParams struct {
 	ID              int      `url:"id,omitempty"`
-	IDIn            []int    `url:"id:in,omitempty"`
-	IDNotIn         []int    `url:"id:not_in,omitempty"`
+	IDIn            []int    `url:"id:in,omitempty,comma"`
+	IDNotIn         []int    `url:"id:not_in,omitempty,comma"`
 	IDMin           []int    `url:"id:min,omitempty"`
 	IDMax           []int    `url:"id:max,omitempty"`
 	IDGreater       []int    `url:"id:greater,omitempty"`
@@ -34,7 +34,7 @@ type CategoryQueryParams struct {
 	Name            string   `url:"name,omitempty"`
 	NameLike        []string `url:"name:like,omitempty"`
 	ParentID        int      `url:"parent_id,omitempty"`
-	ParentIDIn      []int    `url:"parent_id:in,omitempty"`
+	ParentIDIn      []int    `url:"parent_id:in,omitempty,comma"`
 	ParentIDMin     []int    `url:"parent_id:min,omitempty"`
 	ParentIDMax     []int    `url:"parent_id:max,omitempty"`
 	ParentIDGreater []int    `url:"parent_id:greater,omitempty"`
@@ -108,6 +108,53 @@ func (client *V3Client) GetAllCategories(params CategoryQueryParams) ([]Category
 	return allCategories, nil
 }
 
+type UpdateCategoryParams struct {
+	ParentID           *int       `json:"parent_id,omitempty"`
+	Name               string     `json:"name,omitempty"`
+	Description        string     `json:"description,omitempty"`
+	SortOrder          *int       `json:"sort_order,omitempty"`
+	PageTitle          string     `json:"page_title,omitempty"`
+	SearchKeywords     string     `json:"search_keywords,omitempty"`
+	MetaKeywords       []string   `json:"meta_keywords,omitempty"`
+	MetaDescription    string     `json:"meta_description,omitempty"`
+	LayoutFile         string     `json:"layout_file,omitempty"`
+	IsVisible          *bool      `json:"is_visible,omitempty"`
+	DefaultProductSort string     `json:"default_product_sort,omitempty"`
+	ImageURL           string     `json:"image_url,omitempty"`
+	CustomURL          *CustomURL `json:"custom_url,omitempty"`
+}
+
+// UpdateCategory updates a category. When the redirect guard is enabled and
+// params changes the category's custom URL, the old URL is redirected to the
+// category.
+func (client *V3Client) UpdateCategory(id int, params UpdateCategoryParams) (Category, error) {
+	var response struct {
+		Data Category `json:"data"`
+	}
+
+	oldPath := ""
+	if client.redirectGuard != nil && params.CustomURL != nil && params.CustomURL.URL != "" {
+		current, err := client.GetCategory(id)
+		if err != nil {
+			return Category{}, fmt.Errorf("failed to get current URL of category %d: %w", id, err)
+		}
+		oldPath = current.CustomURL.URL
+	}
+
+	categoryURL := client.constructURL("/catalog/categories", strconv.Itoa(id))
+	if err := client.Put(categoryURL, params, &response); err != nil {
+		return Category{}, fmt.Errorf("failed to update category with ID %d: %w", id, err)
+	}
+
+	if oldPath != "" {
+		if err := client.guardURLChange(RedirectToCategory, id, oldPath, response.Data.CustomURL.URL); err != nil {
+			return response.Data, err
+		}
+	}
+
+	return response.Data, nil
+}
+
 func (client *V3Client) EmptyCategory(id int) error {
 	products, _, err := client.GetProducts(ProductQueryParams{CategoriesIn: []int{id}})
 	if err != nil {
@@ -51,6 +51,7 @@ type V2Client struct {
 
 type V3Client struct {
 	BaseVersionClient
+	redirectGuard *RedirectGuardConfig
 }
 
 type Client struct {
@@ -245,7 +246,8 @@ func (client *BaseVersionClient) requestAndDecode(httpMethod string, relativeUrl
 		client.logger.Printf("Response body: %s", string(body))
 	}
 
-	if dest != nil {
+	// V2 list endpoints respond with 204 No Content when there are no results
+	if dest != nil && len(body) > 0 {
 		if err := json.Unmarshal(body, dest); err != nil {
 			if client.logger != nil {
 				client.logger.Printf("Failed to decode response: %s", string(body))
@@ -294,3 +296,8 @@ func parseInt64(s string) int64 {
 	i, _ := strconv.ParseInt(s, 10, 64)
 	return i
 }
+
+func parseFloat64(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
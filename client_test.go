@@ -1,6 +1,9 @@
 package bigcommerce
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"testing"
 
@@ -25,3 +28,19 @@ func getTestClient() (*Client, error) {
 func TestNewClient(t *testing.T) {
 	NewClient("adsd", "adssda", nil, nil)
 }
+
+// newTestServerClient returns a client whose V2 and V3 base URLs point at a local
+// test server, so request handling can be exercised without a live store.
+func newTestServerClient(t *testing.T, handler http.Handler) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient("test", "token", &RateLimitConfig{}, nil)
+
+	v2URL, _ := url.Parse(server.URL + "/stores/test/v2")
+	v3URL, _ := url.Parse(server.URL + "/stores/test/v3")
+	client.V2.baseURL = v2URL
+	client.V3.baseURL = v3URL
+
+	return client
+}
@@ -0,0 +1,343 @@
+package bigcommerce
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultCouponCodeCharset leaves out characters that are easily confused when
+// codes are read aloud or printed, such as 0/O and 1/I.
+const DefaultCouponCodeCharset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+type CouponGeneratorOptions struct {
+	// Count is the total number of codes wanted, including codes generated by
+	// previous runs recorded in OutputPath.
+	Count int
+	// Prefix is prepended to every random code.
+	Prefix string
+	// Length is the number of random characters after the prefix. Defaults to 8.
+	Length int
+	// Charset is the set of characters codes are drawn from. Defaults to DefaultCouponCodeCharset.
+	Charset string
+	// Concurrency is the number of coupons created at once. Defaults to 4. The
+	// client's rate limit backoff still applies to each request.
+	Concurrency int
+	// MaxAttempts is the number of codes tried for each coupon when a code turns out
+	// to be taken. Defaults to 5.
+	MaxAttempts int
+	// OutputPath is the CSV file generated codes are appended to, with the columns
+	// code, coupon_id and name. When the file already exists its codes count towards
+	// Count, so an interrupted run can be resumed by running it again.
+	OutputPath string
+}
+
+type GeneratedCoupon struct {
+	ID   int
+	Code string
+	Name string
+}
+
+type CouponGenerationResult struct {
+	// Created holds the coupons created by this run.
+	Created []GeneratedCoupon
+	// Resumed is the number of codes found in OutputPath from previous runs.
+	Resumed int
+	// Collisions counts generated codes that were already taken.
+	Collisions int
+}
+
+// GenerateCoupons creates unique single-code coupons from a template. Each coupon
+// copies template with Code replaced by a random code and the code appended to Name.
+//
+// Codes are checked against every existing coupon before creation, and a code
+// rejected by BigCommerce as a duplicate is replaced and retried. Every created
+// coupon is written to OutputPath as soon as it exists.
+func (client *V2Client) GenerateCoupons(template CreateCouponParams, opts CouponGeneratorOptions) (CouponGenerationResult, error) {
+	var result CouponGenerationResult
+
+	if opts.Count < 1 {
+		return result, errors.New("coupon generator: Count must be positive")
+	}
+	if opts.OutputPath == "" {
+		return result, errors.New("coupon generator: OutputPath is required")
+	}
+	if opts.Length < 1 {
+		opts.Length = 8
+	}
+	if opts.Charset == "" {
+		opts.Charset = DefaultCouponCodeCharset
+	}
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 4
+	}
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 5
+	}
+	if !isValidCouponCode(opts.Charset) {
+		return result, errors.New("coupon generator: Charset may only contain letters, numbers, hyphens and underscores")
+	}
+
+	// Check the template once with a code of the final shape rather than failing on
+	// every coupon.
+	sample := template
+	sample.Code = opts.Prefix + strings.Repeat(opts.Charset[:1], opts.Length)
+	sample.Name = strings.TrimSpace(template.Name + " " + sample.Code)
+	if err := ValidateCreateCouponParams(sample); err != nil {
+		return result, fmt.Errorf("coupon generator: invalid template: %w", err)
+	}
+
+	used := map[string]bool{}
+
+	previous, err := readGeneratedCoupons(opts.OutputPath)
+	if err != nil {
+		return result, err
+	}
+	for _, coupon := range previous {
+		used[strings.ToUpper(coupon.Code)] = true
+	}
+	result.Resumed = len(previous)
+
+	remaining := opts.Count - len(previous)
+	if remaining <= 0 {
+		return result, nil
+	}
+
+	existing, err := client.GetAllCoupons(CouponQueryParams{})
+	if err != nil {
+		return result, fmt.Errorf("coupon generator: failed to load existing codes: %w", err)
+	}
+	for _, coupon := range existing {
+		used[strings.ToUpper(coupon.Code)] = true
+	}
+
+	output, err := openGeneratedCouponsCSV(opts.OutputPath, len(previous) == 0)
+	if err != nil {
+		return result, err
+	}
+	defer output.file.Close()
+
+	generator := &couponGenerator{
+		client:   client,
+		template: template,
+		opts:     opts,
+		used:     used,
+		output:   output,
+	}
+
+	jobs := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				generator.createOne()
+			}
+		}()
+	}
+
+	for i := 0; i < remaining; i++ {
+		if generator.failed() {
+			break
+		}
+		jobs <- struct{}{}
+	}
+	close(jobs)
+	wg.Wait()
+
+	result.Created = generator.created
+	result.Collisions = generator.collisions
+
+	return result, generator.err
+}
+
+type couponGenerator struct {
+	client   *V2Client
+	template CreateCouponParams
+	opts     CouponGeneratorOptions
+	output   *generatedCouponsCSV
+
+	mu         sync.Mutex
+	used       map[string]bool
+	created    []GeneratedCoupon
+	collisions int
+	err        error
+}
+
+func (generator *couponGenerator) failed() bool {
+	generator.mu.Lock()
+	defer generator.mu.Unlock()
+	return generator.err != nil
+}
+
+func (generator *couponGenerator) fail(err error) {
+	generator.mu.Lock()
+	defer generator.mu.Unlock()
+	if generator.err == nil {
+		generator.err = err
+	}
+}
+
+// reserveCode returns a random code that is not in use and marks it as used.
+func (generator *couponGenerator) reserveCode() (string, error) {
+	generator.mu.Lock()
+	defer generator.mu.Unlock()
+
+	for attempt := 0; attempt < generator.opts.MaxAttempts; attempt++ {
+		code, err := randomCouponCode(generator.opts.Prefix, generator.opts.Length, generator.opts.Charset)
+		if err != nil {
+			return "", err
+		}
+		if generator.used[strings.ToUpper(code)] {
+			generator.collisions++
+			continue
+		}
+		generator.used[strings.ToUpper(code)] = true
+		return code, nil
+	}
+
+	return "", fmt.Errorf("coupon generator: no free code after %d attempts, increase Length or Charset", generator.opts.MaxAttempts)
+}
+
+func (generator *couponGenerator) createOne() {
+	if generator.failed() {
+		return
+	}
+
+	for attempt := 0; attempt < generator.opts.MaxAttempts; attempt++ {
+		code, err := generator.reserveCode()
+		if err != nil {
+			generator.fail(err)
+			return
+		}
+
+		params := generator.template
+		params.Code = code
+		params.Name = strings.TrimSpace(generator.template.Name + " " + code)
+
+		coupon, err := generator.client.CreateCoupon(params)
+		if err != nil {
+			var bcErr *BigCommerceError
+			if errors.As(err, &bcErr) && bcErr.StatusCode == http.StatusConflict {
+				generator.mu.Lock()
+				generator.collisions++
+				generator.mu.Unlock()
+				continue
+			}
+			generator.fail(fmt.Errorf("coupon generator: %w", err))
+			return
+		}
+
+		generated := GeneratedCoupon{ID: coupon.ID, Code: code, Name: params.Name}
+
+		generator.mu.Lock()
+		generator.created = append(generator.created, generated)
+		generator.mu.Unlock()
+
+		if err := generator.output.write(generated); err != nil {
+			generator.fail(err)
+		}
+		return
+	}
+
+	generator.fail(fmt.Errorf("coupon generator: codes kept colliding after %d attempts", generator.opts.MaxAttempts))
+}
+
+func randomCouponCode(prefix string, length int, charset string) (string, error) {
+	var b strings.Builder
+	b.WriteString(prefix)
+
+	max := big.NewInt(int64(len(charset)))
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("coupon generator: failed to read random data: %w", err)
+		}
+		b.WriteByte(charset[n.Int64()])
+	}
+
+	return b.String(), nil
+}
+
+type generatedCouponsCSV struct {
+	mu     sync.Mutex
+	file   *os.File
+	writer *csv.Writer
+}
+
+func openGeneratedCouponsCSV(path string, writeHeader bool) (*generatedCouponsCSV, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("coupon generator: failed to open %s: %w", path, err)
+	}
+
+	output := &generatedCouponsCSV{file: file, writer: csv.NewWriter(file)}
+	if writeHeader {
+		if err := output.writeRecord([]string{"code", "coupon_id", "name"}); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return output, nil
+}
+
+func (output *generatedCouponsCSV) write(coupon GeneratedCoupon) error {
+	return output.writeRecord([]string{coupon.Code, strconv.Itoa(coupon.ID), coupon.Name})
+}
+
+func (output *generatedCouponsCSV) writeRecord(record []string) error {
+	output.mu.Lock()
+	defer output.mu.Unlock()
+
+	if err := output.writer.Write(record); err != nil {
+		return fmt.Errorf("coupon generator: failed to record code: %w", err)
+	}
+	output.writer.Flush()
+	if err := output.writer.Error(); err != nil {
+		return fmt.Errorf("coupon generator: failed to record code: %w", err)
+	}
+	return nil
+}
+
+func readGeneratedCoupons(path string) ([]GeneratedCoupon, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("coupon generator: failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = 3
+
+	coupons := []GeneratedCoupon{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return coupons, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("coupon generator: failed to read %s: %w", path, err)
+		}
+		if line == 1 && record[0] == "code" {
+			continue
+		}
+
+		id, err := strconv.Atoi(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("coupon generator: %s line %d has invalid coupon_id %q", path, line, record[1])
+		}
+		coupons = append(coupons, GeneratedCoupon{Code: record[0], ID: id, Name: record[2]})
+	}
+}
@@ -0,0 +1,116 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeCouponStore struct {
+	mu      sync.Mutex
+	coupons []Coupon
+	nextID  int
+}
+
+func (store *fakeCouponStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		if r.URL.Query().Get("page") != "1" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		json.NewEncoder(w).Encode(store.coupons)
+	case http.MethodPost:
+		var params CreateCouponParams
+		json.NewDecoder(r.Body).Decode(&params)
+		for _, coupon := range store.coupons {
+			if coupon.Code == params.Code {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+		}
+		store.nextID++
+		coupon := Coupon{ID: store.nextID, Code: params.Code, Name: params.Name, Type: params.Type}
+		store.coupons = append(store.coupons, coupon)
+		json.NewEncoder(w).Encode(coupon)
+	}
+}
+
+func TestGenerateCoupons(t *testing.T) {
+	store := &fakeCouponStore{nextID: 1, coupons: []Coupon{{ID: 1, Code: "EXISTING"}}}
+	client := newTestServerClient(t, store)
+	output := filepath.Join(t.TempDir(), "codes.csv")
+
+	template := CreateCouponParams{
+		Name:      "Spring",
+		Type:      CouponTypePercentageDiscount,
+		Amount:    "10",
+		Enabled:   true,
+		AppliesTo: &AppliesTo{Entity: "categories", IDs: []int{0}},
+		MaxUses:   1,
+	}
+
+	result, err := client.V2.GenerateCoupons(template, CouponGeneratorOptions{Count: 5, Prefix: "SPR-", OutputPath: output})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Created) != 5 {
+		t.Fatalf("expected 5 coupons, got %d", len(result.Created))
+	}
+
+	seen := map[string]bool{}
+	for _, coupon := range result.Created {
+		if !strings.HasPrefix(coupon.Code, "SPR-") || len(coupon.Code) != 12 {
+			t.Errorf("unexpected code %q", coupon.Code)
+		}
+		if seen[coupon.Code] {
+			t.Errorf("duplicate code %q", coupon.Code)
+		}
+		seen[coupon.Code] = true
+	}
+
+	// Running again with a higher count only creates the difference.
+	result, err = client.V2.GenerateCoupons(template, CouponGeneratorOptions{Count: 7, Prefix: "SPR-", OutputPath: output})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Resumed != 5 || len(result.Created) != 2 {
+		t.Errorf("expected 5 resumed and 2 created, got %d and %d", result.Resumed, len(result.Created))
+	}
+
+	b, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 8 || lines[0] != "code,coupon_id,name" {
+		t.Errorf("unexpected CSV:\n%s", b)
+	}
+}
+
+func TestGenerateCoupons_Collision(t *testing.T) {
+	// With a one character charset and length, only one code can ever be generated.
+	store := &fakeCouponStore{}
+	client := newTestServerClient(t, store)
+
+	_, err := client.V2.GenerateCoupons(CreateCouponParams{Name: "X", Type: CouponTypeFreeShipping, AppliesTo: &AppliesTo{Entity: "categories", IDs: []int{0}}}, CouponGeneratorOptions{
+		Count:       2,
+		Length:      1,
+		Charset:     "A",
+		Concurrency: 1,
+		OutputPath:  filepath.Join(t.TempDir(), "codes.csv"),
+	})
+	if err == nil {
+		t.Fatal("expected error once the code space is exhausted")
+	}
+	if len(store.coupons) != 1 {
+		t.Errorf("expected 1 coupon created, got %d", len(store.coupons))
+	}
+}
@@ -0,0 +1,226 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	couponNameMaxLength = 100
+	couponCodeMaxLength = 50
+)
+
+// ValidateCreateCouponParams checks params against the rules BigCommerce applies
+// when a coupon is created and returns every problem found as ValidationErrors.
+func ValidateCreateCouponParams(params CreateCouponParams) error {
+	var errors ValidationErrors
+
+	if params.Name == "" {
+		errors = append(errors, "Name is required")
+	}
+	if params.Code == "" {
+		errors = append(errors, "Code is required")
+	}
+	if params.Type == "" {
+		errors = append(errors, "Type is required")
+	}
+	if params.AppliesTo == nil {
+		errors = append(errors, "AppliesTo is required")
+	}
+	if params.Amount == "" && params.Type != "" && params.Type != CouponTypeFreeShipping {
+		errors = append(errors, fmt.Sprintf("Amount is required for %s coupons", params.Type))
+	}
+
+	errors = append(errors, validateCouponFields(couponFields{
+		Name:               params.Name,
+		Type:               params.Type,
+		Amount:             params.Amount,
+		MinPurchase:        params.MinPurchase,
+		Expires:            params.Expires,
+		Code:               params.Code,
+		AppliesTo:          params.AppliesTo,
+		MaxUses:            params.MaxUses,
+		MaxUsesPerCustomer: params.MaxUsesPerCustomer,
+		RestrictedTo:       params.RestrictedTo,
+	})...)
+
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return nil
+}
+
+// ValidateUpdateCouponParams checks the fields set in params against the rules
+// BigCommerce applies when a coupon is updated. Fields left empty are not changed
+// by an update and are not checked. The percentage bound on Amount can only be
+// checked when Type is set as well.
+func ValidateUpdateCouponParams(params UpdateCouponParams) error {
+	errors := validateCouponFields(couponFields{
+		Name:               params.Name,
+		Type:               params.Type,
+		Amount:             params.Amount,
+		MinPurchase:        params.MinPurchase,
+		Expires:            params.Expires,
+		Code:               params.Code,
+		AppliesTo:          params.AppliesTo,
+		MaxUses:            params.MaxUses,
+		MaxUsesPerCustomer: params.MaxUsesPerCustomer,
+		RestrictedTo:       params.RestrictedTo,
+	})
+
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return nil
+}
+
+// couponFields holds the fields shared by CreateCouponParams and UpdateCouponParams.
+type couponFields struct {
+	Name               string
+	Type               CouponType
+	Amount             string
+	MinPurchase        string
+	Expires            string
+	Code               string
+	AppliesTo          *AppliesTo
+	MaxUses            int
+	MaxUsesPerCustomer int
+	RestrictedTo       *RestrictedTo
+}
+
+func validateCouponFields(fields couponFields) ValidationErrors {
+	var errors ValidationErrors
+
+	if len([]rune(fields.Name)) > couponNameMaxLength {
+		errors = append(errors, fmt.Sprintf("Name must be at most %d characters", couponNameMaxLength))
+	}
+
+	if fields.Type != "" && !isAllowedCouponType(fields.Type) {
+		errors = append(errors, fmt.Sprintf("Type %q is not one of %v", fields.Type, AllowedCouponTypes))
+	}
+
+	if fields.Code != "" {
+		if len(fields.Code) > couponCodeMaxLength {
+			errors = append(errors, fmt.Sprintf("Code must be at most %d characters", couponCodeMaxLength))
+		}
+		if !isValidCouponCode(fields.Code) {
+			errors = append(errors, "Code may only contain letters, numbers, hyphens and underscores")
+		}
+	}
+
+	if fields.Amount != "" {
+		amount, err := strconv.ParseFloat(fields.Amount, 64)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("Amount %q is not a number", fields.Amount))
+		} else if amount < 0 {
+			errors = append(errors, "Amount must not be negative")
+		} else if fields.Type == CouponTypePercentageDiscount && amount > 100 {
+			errors = append(errors, "Amount must be between 0 and 100 for percentage_discount coupons")
+		}
+	}
+
+	if fields.MinPurchase != "" {
+		minPurchase, err := strconv.ParseFloat(fields.MinPurchase, 64)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("MinPurchase %q is not a number", fields.MinPurchase))
+		} else if minPurchase < 0 {
+			errors = append(errors, "MinPurchase must not be negative")
+		}
+	}
+
+	if fields.Expires != "" {
+		if _, err := time.Parse(time.RFC1123Z, fields.Expires); err != nil {
+			errors = append(errors, fmt.Sprintf("Expires %q must be an RFC 2822 date such as %q", fields.Expires, time.RFC1123Z))
+		}
+	}
+
+	if fields.AppliesTo != nil {
+		errors = append(errors, validateCouponAppliesTo(*fields.AppliesTo)...)
+	}
+
+	if fields.MaxUses < 0 {
+		errors = append(errors, "MaxUses must not be negative")
+	}
+	if fields.MaxUsesPerCustomer < 0 {
+		errors = append(errors, "MaxUsesPerCustomer must not be negative")
+	}
+
+	if fields.RestrictedTo != nil {
+		errors = append(errors, validateCouponRestrictedTo(*fields.RestrictedTo)...)
+	}
+
+	return errors
+}
+
+func validateCouponAppliesTo(appliesTo AppliesTo) ValidationErrors {
+	var errors ValidationErrors
+
+	switch appliesTo.Entity {
+	case "products", "categories":
+	case "":
+		errors = append(errors, "AppliesTo.Entity is required")
+	default:
+		errors = append(errors, fmt.Sprintf("AppliesTo.Entity %q must be 'products' or 'categories'", appliesTo.Entity))
+	}
+
+	if len(appliesTo.IDs) == 0 {
+		errors = append(errors, "AppliesTo.IDs must contain at least one ID (use category 0 for the whole catalog)")
+	}
+
+	for _, id := range appliesTo.IDs {
+		// Category 0 stands for every category, there is no equivalent for products.
+		if id < 0 || (id == 0 && appliesTo.Entity == "products") {
+			errors = append(errors, fmt.Sprintf("AppliesTo.IDs contains invalid %s ID %d", appliesTo.Entity, id))
+		}
+	}
+
+	return errors
+}
+
+func validateCouponRestrictedTo(restrictedTo RestrictedTo) ValidationErrors {
+	var errors ValidationErrors
+
+	for _, country := range restrictedTo.Countries {
+		if !isISO2CountryCode(country) {
+			errors = append(errors, fmt.Sprintf("RestrictedTo.Countries contains %q, expected an ISO 3166-1 alpha-2 code such as 'US'", country))
+		}
+	}
+
+	for _, method := range restrictedTo.ShippingMethods {
+		if !strings.HasPrefix(method, "shipping_") || len(method) == len("shipping_") {
+			errors = append(errors, fmt.Sprintf("RestrictedTo.ShippingMethods contains %q, expected a shipping method such as 'shipping_flatrate'", method))
+		}
+	}
+
+	return errors
+}
+
+func isAllowedCouponType(couponType CouponType) bool {
+	for _, allowed := range AllowedCouponTypes {
+		if couponType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidCouponCode(code string) bool {
+	for _, r := range code {
+		if !isCouponCodeRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isCouponCodeRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_'
+}
+
+func isISO2CountryCode(code string) bool {
+	return len(code) == 2 && code[0] >= 'A' && code[0] <= 'Z' && code[1] >= 'A' && code[1] <= 'Z'
+}
@@ -0,0 +1,53 @@
+package bigcommerce
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateCreateCouponParams(t *testing.T) {
+	valid := CreateCouponParams{
+		Name:         "Spring sale",
+		Type:         CouponTypePercentageDiscount,
+		Amount:       "15",
+		Code:         "SPRING-15",
+		Expires:      "Fri, 31 May 2024 23:59:59 +0000",
+		AppliesTo:    &AppliesTo{Entity: "categories", IDs: []int{0}},
+		RestrictedTo: &RestrictedTo{Countries: []string{"IE"}, ShippingMethods: []string{"shipping_flatrate"}},
+	}
+	if err := ValidateCreateCouponParams(valid); err != nil {
+		t.Fatalf("expected valid params, got %v", err)
+	}
+
+	invalid := CreateCouponParams{
+		Name:         "Spring sale",
+		Type:         CouponTypePercentageDiscount,
+		Amount:       "150",
+		Code:         "SPRING 15%",
+		Expires:      "2024-05-31",
+		AppliesTo:    &AppliesTo{Entity: "products", IDs: []int{0}},
+		RestrictedTo: &RestrictedTo{Countries: []string{"ie"}, ShippingMethods: []string{"flatrate"}},
+	}
+	err := ValidateCreateCouponParams(invalid)
+
+	var validationErrors ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	if len(validationErrors) != 6 {
+		t.Errorf("expected 6 problems, got %d: %v", len(validationErrors), validationErrors)
+	}
+
+	if err := ValidateCreateCouponParams(CreateCouponParams{}); err == nil {
+		t.Error("expected empty params to be invalid")
+	}
+}
+
+func TestValidateUpdateCouponParams(t *testing.T) {
+	if err := ValidateUpdateCouponParams(UpdateCouponParams{Enabled: true}); err != nil {
+		t.Errorf("expected a partial update to be valid, got %v", err)
+	}
+	if err := ValidateUpdateCouponParams(UpdateCouponParams{Type: CouponTypePromotion}); err == nil {
+		t.Error("expected the promotion type to be rejected")
+	}
+}
@@ -5,23 +5,46 @@ import (
 	"strconv"
 )
 
+// CouponType is the kind of discount a coupon gives
+type CouponType string
+
+const (
+	CouponTypePerItemDiscount    CouponType = "per_item_discount"
+	CouponTypePercentageDiscount CouponType = "percentage_discount"
+	CouponTypePerTotalDiscount   CouponType = "per_total_discount"
+	CouponTypeShippingDiscount   CouponType = "shipping_discount"
+	CouponTypeFreeShipping       CouponType = "free_shipping"
+	// CouponTypePromotion is reported for coupons created by the promotions engine.
+	// It cannot be used to create or update a coupon.
+	CouponTypePromotion CouponType = "promotion"
+)
+
+// AllowedCouponTypes is a slice containing the coupon types that can be created.
+var AllowedCouponTypes = []CouponType{
+	CouponTypePerItemDiscount,
+	CouponTypePercentageDiscount,
+	CouponTypePerTotalDiscount,
+	CouponTypeShippingDiscount,
+	CouponTypeFreeShipping,
+}
+
 type CouponQueryParams struct {
-	ID          string `url:"id,omitempty"`
-	Code        string `url:"code,omitempty"`
-	Name        string `url:"name,omitempty"`
-	Type        string `url:"type,omitempty"`
-	MinID       int    `url:"min_id,omitempty"`
-	MaxID       int    `url:"max_id,omitempty"`
-	Page        int    `url:"page,omitempty"`
-	Limit       int    `url:"limit,omitempty"`
-	ExcludeType string `url:"exclude_type,omitempty"`
+	ID          string     `url:"id,omitempty"`
+	Code        string     `url:"code,omitempty"`
+	Name        string     `url:"name,omitempty"`
+	Type        CouponType `url:"type,omitempty"`
+	MinID       int        `url:"min_id,omitempty"`
+	MaxID       int        `url:"max_id,omitempty"`
+	Page        int        `url:"page,omitempty"`
+	Limit       int        `url:"limit,omitempty"`
+	ExcludeType CouponType `url:"exclude_type,omitempty"`
 }
 type Coupon struct {
 	ID                 int                  `json:"id"`
 	DateCreated        string               `json:"date_created"`
 	NumUses            int                  `json:"num_uses"`
 	Name               string               `json:"name"`
-	Type               string               `json:"type"`
+	Type               CouponType           `json:"type"`
 	Amount             string               `json:"amount"`
 	MinPurchase        string               `json:"min_purchase"`
 	Expires            string               `json:"expires"`
@@ -55,7 +78,7 @@ type CouponsResponseObject struct {
 }
 type CreateCouponParams struct {
 	Name               string        `json:"name"`
-	Type               string        `json:"type"`
+	Type               CouponType    `json:"type"`
 	Amount             string        `json:"amount"`
 	MinPurchase        string        `json:"min_purchase,omitempty"`
 	Expires            string        `json:"expires,omitempty"`
@@ -69,7 +92,7 @@ type CreateCouponParams struct {
 
 type UpdateCouponParams struct {
 	Name               string        `json:"name,omitempty"`
-	Type               string        `json:"type,omitempty"`
+	Type               CouponType    `json:"type,omitempty"`
 	Amount             string        `json:"amount,omitempty"`
 	MinPurchase        string        `json:"min_purchase,omitempty"`
 	Expires            string        `json:"expires,omitempty"`
@@ -87,12 +110,18 @@ type AppliesTo struct {
 }
 
 type RestrictedTo struct {
+	// Countries are ISO 3166-1 alpha-2 codes.
+	Countries       []string `json:"countries,omitempty"`
 	ShippingMethods []string `json:"shipping_methods,omitempty"`
 }
 
 func (client *V2Client) CreateCoupon(params CreateCouponParams) (Coupon, error) {
 	var response CouponResponseObject
 
+	if err := ValidateCreateCouponParams(params); err != nil {
+		return response.Data, fmt.Errorf("failed to create coupon: invalid parameters: %w", err)
+	}
+
 	path := client.constructURL("coupons")
 	if err := client.Post(path, params, &response.Data); err != nil {
 		return response.Data, fmt.Errorf("failed to create coupon: %w", err)
@@ -104,6 +133,10 @@ func (client *V2Client) CreateCoupon(params CreateCouponParams) (Coupon, error)
 func (client *V2Client) UpdateCoupon(couponID int, params UpdateCouponParams) (Coupon, error) {
 	var response CouponResponseObject
 
+	if err := ValidateUpdateCouponParams(params); err != nil {
+		return response.Data, fmt.Errorf("failed to update coupon with ID %d: invalid parameters: %w", couponID, err)
+	}
+
 	path := client.constructURL("coupons", strconv.Itoa(couponID))
 	if err := client.Put(path, params, &response.Data); err != nil {
 		return response.Data, fmt.Errorf("failed to update coupon with ID %d: %w", couponID, err)
@@ -148,3 +181,41 @@ func (client *V2Client) DeleteCoupon(couponID int) error {
 
 	return nil
 }
+
+// GetAllCoupons retrieves every coupon matching params. The Page and Limit fields of
+// params are overwritten.
+func (client *V2Client) GetAllCoupons(params CouponQueryParams) ([]Coupon, error) {
+	var coupons []Coupon
+	params.Page = 1
+	params.Limit = 250
+
+	for {
+		c, err := client.GetCoupons(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get all coupons at page %d: %w", params.Page, err)
+		}
+		coupons = append(coupons, c...)
+
+		if len(c) < params.Limit {
+			break
+		}
+
+		params.Page++
+	}
+
+	return coupons, nil
+}
+
+// GetCouponsCount returns the number of coupons in the store.
+func (client *V2Client) GetCouponsCount() (int, error) {
+	var response struct {
+		Count int `json:"count"`
+	}
+
+	path := client.constructURL("coupons", "count")
+	if err := client.Get(path, &response); err != nil {
+		return 0, fmt.Errorf("failed to get coupons count: %w", err)
+	}
+
+	return response.Count, nil
+}
@@ -0,0 +1,159 @@
+// Package frontmatter reads and writes the subset of YAML used in the front
+// matter of Markdown files: keys with plain, single or double quoted scalars and
+// flow or block lists.
+package frontmatter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Value is the value of a front matter key. List is nil for scalars.
+type Value struct {
+	Scalar string
+	List   []string
+}
+
+// Split separates text into its front matter, without the --- delimiters, and
+// the body that follows.
+func Split(text string) (frontMatter, body string, err error) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return "", "", fmt.Errorf("missing front matter: file must start with ---")
+	}
+	end := strings.Index(text[4:], "\n---")
+	if end < 0 {
+		return "", "", fmt.Errorf("front matter is not closed with ---")
+	}
+	return text[4 : 4+end+1], text[4+end+4:], nil
+}
+
+// Quote writes s as a double-quoted YAML string. JSON string escapes are a
+// subset of YAML's.
+func Quote(s string) string {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Parse reads the keys of frontMatter. Comments and blank lines are skipped.
+func Parse(frontMatter string) (map[string]Value, error) {
+	fields := map[string]Value{}
+	var listKey string
+
+	scanner := bufio.NewScanner(strings.NewReader(frontMatter))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			if listKey == "" {
+				return nil, fmt.Errorf("front matter line %d: list item without a key", lineNumber)
+			}
+			item, err := parseScalar(strings.TrimSpace(strings.TrimPrefix(trimmed, "-")))
+			if err != nil {
+				return nil, fmt.Errorf("front matter line %d: %w", lineNumber, err)
+			}
+			value := fields[listKey]
+			value.List = append(value.List, item)
+			fields[listKey] = value
+			continue
+		}
+
+		colon := strings.IndexByte(line, ':')
+		if colon < 0 {
+			return nil, fmt.Errorf("front matter line %d: expected key: value", lineNumber)
+		}
+		key := strings.TrimSpace(line[:colon])
+		raw := strings.TrimSpace(line[colon+1:])
+		listKey = ""
+
+		switch {
+		case raw == "":
+			fields[key] = Value{List: []string{}}
+			listKey = key
+		case strings.HasPrefix(raw, "["):
+			list, err := parseFlowList(raw)
+			if err != nil {
+				return nil, fmt.Errorf("front matter line %d: %w", lineNumber, err)
+			}
+			fields[key] = Value{List: list}
+		default:
+			scalar, err := parseScalar(raw)
+			if err != nil {
+				return nil, fmt.Errorf("front matter line %d: %w", lineNumber, err)
+			}
+			fields[key] = Value{Scalar: scalar}
+		}
+	}
+
+	return fields, scanner.Err()
+}
+
+func parseScalar(raw string) (string, error) {
+	switch {
+	case strings.HasPrefix(raw, `"`):
+		var s string
+		if err := json.Unmarshal([]byte(raw), &s); err != nil {
+			return "", fmt.Errorf("invalid double quoted string %s", raw)
+		}
+		return s, nil
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return "", fmt.Errorf("invalid single quoted string %s", raw)
+		}
+		return strings.ReplaceAll(raw[1:len(raw)-1], "''", "'"), nil
+	default:
+		if comment := strings.Index(raw, " #"); comment >= 0 {
+			raw = strings.TrimSpace(raw[:comment])
+		}
+		return raw, nil
+	}
+}
+
+func parseFlowList(raw string) ([]string, error) {
+	if !strings.HasSuffix(raw, "]") {
+		return nil, fmt.Errorf("list %s is not closed", raw)
+	}
+	inner := strings.TrimSpace(raw[1 : len(raw)-1])
+
+	list := []string{}
+	for inner != "" {
+		var item string
+		switch inner[0] {
+		case '"', '\'':
+			end := 1
+			for end < len(inner) && (inner[end] != inner[0] || (inner[0] == '"' && inner[end-1] == '\\' && inner[end-2] != '\\')) {
+				end++
+			}
+			if end >= len(inner) {
+				return nil, fmt.Errorf("unterminated string in list %s", raw)
+			}
+			var err error
+			if item, err = parseScalar(inner[:end+1]); err != nil {
+				return nil, err
+			}
+			inner = strings.TrimSpace(inner[end+1:])
+		default:
+			end := strings.IndexByte(inner, ',')
+			if end < 0 {
+				end = len(inner)
+			}
+			item = strings.TrimSpace(inner[:end])
+			inner = inner[end:]
+		}
+
+		list = append(list, item)
+		inner = strings.TrimSpace(strings.TrimPrefix(inner, ","))
+	}
+
+	return list, nil
+}
@@ -0,0 +1,40 @@
+package frontmatter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	frontMatter, body, err := Split("---\r\ntitle: " + Quote(`Say "hi": #1`) + "\r\nauthor: 'Jane''s'\nsummary: plain # note\ntags: [one, \"two, three\", 'four']\nlinks:\n  - a\n  - \"b\"\n---\n\nBody\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != "\n\nBody\n" {
+		t.Errorf("unexpected body %q", body)
+	}
+
+	fields, err := Parse(frontMatter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]Value{
+		"title":   {Scalar: `Say "hi": #1`},
+		"author":  {Scalar: "Jane's"},
+		"summary": {Scalar: "plain"},
+		"tags":    {List: []string{"one", "two, three", "four"}},
+		"links":   {List: []string{"a", "b"}},
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("unexpected fields\n%+v\n%+v", fields, expected)
+	}
+
+	for _, invalid := range []string{"- orphan\n", "no colon\n", "tags: [one\n", `title: "open` + "\n"} {
+		if _, err := Parse(invalid); err == nil {
+			t.Errorf("expected an error for %q", invalid)
+		}
+	}
+	if _, _, err := Split("title: x\n"); err == nil {
+		t.Error("expected an error without front matter")
+	}
+}
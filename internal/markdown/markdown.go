@@ -0,0 +1,909 @@
+// Package markdown converts between the HTML of BigCommerce blog posts and
+// pages and Markdown.
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FromHTML converts the HTML of a blog post or page body to Markdown. It
+// handles the markup produced by the BigCommerce editor: paragraphs, headings,
+// emphasis, links, images, lists, block quotes, code and rules. Any other
+// element, such as a table or an embedded video, is kept as raw HTML, which
+// Markdown allows and ToHTML passes through unchanged.
+func FromHTML(source string) string {
+	root := parseHTMLFragment(source)
+
+	var converter markdownWriter
+	markdown := converter.children(root, false)
+
+	return strings.TrimSpace(collapseBlankLines(markdown))
+}
+
+// ToHTML converts Markdown written by FromHTML, or by hand using the same
+// subset, back to HTML.
+func ToHTML(markdown string) string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	return strings.Join(markdownBlocks(lines), "\n")
+}
+
+type htmlNode struct {
+	// tag is empty for text nodes.
+	tag      string
+	attrs    map[string]string
+	raw      string
+	text     string
+	children []*htmlNode
+	parent   *htmlNode
+}
+
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// parseHTMLFragment builds a tree from HTML. It is forgiving in the way browsers
+// are: unmatched end tags are ignored and unclosed elements end with their parent.
+func parseHTMLFragment(source string) *htmlNode {
+	root := &htmlNode{tag: "#root"}
+	current := root
+
+	for i := 0; i < len(source); {
+		if source[i] != '<' {
+			end := strings.IndexByte(source[i:], '<')
+			if end < 0 {
+				end = len(source) - i
+			}
+			current.children = append(current.children, &htmlNode{text: html.UnescapeString(source[i : i+end]), parent: current})
+			i += end
+			continue
+		}
+
+		if strings.HasPrefix(source[i:], "<!--") {
+			end := strings.Index(source[i:], "-->")
+			if end < 0 {
+				end = len(source) - i - 3
+			}
+			current.children = append(current.children, &htmlNode{tag: "#comment", raw: source[i : i+end+3], parent: current})
+			i += end + 3
+			continue
+		}
+
+		if !htmlTagStart(source[i+1:]) {
+			// A < not directly followed by a tag name, as in "a < b", is text.
+			end := strings.IndexByte(source[i+1:], '<')
+			if end < 0 {
+				end = len(source) - i - 1
+			}
+			text := html.UnescapeString(source[i : i+1+end])
+			if last := len(current.children) - 1; last >= 0 && current.children[last].tag == "" {
+				current.children[last].text += text
+			} else {
+				current.children = append(current.children, &htmlNode{text: text, parent: current})
+			}
+			i += 1 + end
+			continue
+		}
+
+		end := htmlTagEnd(source, i)
+		if end < 0 {
+			current.children = append(current.children, &htmlNode{text: source[i:], parent: current})
+			break
+		}
+		raw := source[i : end+1]
+		i = end + 1
+
+		name, attrs, closing, selfClosing := parseHTMLTag(raw)
+		if name == "" {
+			current.children = append(current.children, &htmlNode{text: raw, parent: current})
+			continue
+		}
+
+		if closing {
+			for n := current; n != root; n = n.parent {
+				if n.tag == name {
+					current = n.parent
+					break
+				}
+			}
+			continue
+		}
+
+		node := &htmlNode{tag: name, attrs: attrs, raw: raw, parent: current}
+		current.children = append(current.children, node)
+
+		if name == "pre" || name == "script" || name == "style" {
+			// Keep the contents of preformatted elements exactly as written.
+			closeTag := "</" + name
+			closeAt := strings.Index(strings.ToLower(source[i:]), closeTag)
+			if closeAt < 0 {
+				closeAt = len(source) - i
+			}
+			node.children = append(node.children, &htmlNode{tag: "#raw", raw: source[i : i+closeAt], parent: node})
+			i += closeAt
+			if gt := strings.IndexByte(source[i:], '>'); gt >= 0 {
+				i += gt + 1
+			}
+			continue
+		}
+
+		if !selfClosing && !voidElements[name] {
+			current = node
+		}
+	}
+
+	return root
+}
+
+// htmlTagStart reports whether s, which follows a '<', starts a tag: a name, an
+// end tag's / and name, or a declaration such as !DOCTYPE.
+func htmlTagStart(s string) bool {
+	if strings.HasPrefix(s, "/") {
+		s = s[1:]
+	}
+	return s != "" && (s[0] >= 'a' && s[0] <= 'z' || s[0] >= 'A' && s[0] <= 'Z' || s[0] == '!')
+}
+
+// htmlTagEnd returns the index of the '>' ending the tag starting at start,
+// skipping over quoted attribute values.
+func htmlTagEnd(source string, start int) int {
+	var quote byte
+	for i := start + 1; i < len(source); i++ {
+		c := source[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i
+		}
+	}
+	return -1
+}
+
+var htmlAttrPattern = regexp.MustCompile(`([^\s=/"']+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+)))?`)
+
+func parseHTMLTag(raw string) (name string, attrs map[string]string, closing, selfClosing bool) {
+	inner := strings.TrimSpace(raw[1 : len(raw)-1])
+	if strings.HasPrefix(inner, "/") {
+		closing = true
+		inner = strings.TrimSpace(inner[1:])
+	}
+	if strings.HasSuffix(inner, "/") {
+		selfClosing = true
+		inner = strings.TrimSpace(inner[:len(inner)-1])
+	}
+
+	nameEnd := strings.IndexAny(inner, " \t\n\r")
+	if nameEnd < 0 {
+		nameEnd = len(inner)
+	}
+	name = strings.ToLower(inner[:nameEnd])
+	if name == "" || !isHTMLTagName(name) {
+		return "", nil, false, false
+	}
+
+	attrs = map[string]string{}
+	for _, match := range htmlAttrPattern.FindAllStringSubmatch(inner[nameEnd:], -1) {
+		attrs[strings.ToLower(match[1])] = html.UnescapeString(match[2] + match[3] + match[4])
+	}
+
+	return name, attrs, closing, selfClosing
+}
+
+func isHTMLTagName(name string) bool {
+	for i, r := range name {
+		if !(r >= 'a' && r <= 'z') && !(i > 0 && (r >= '0' && r <= '9' || r == '-')) && r != '!' {
+			return false
+		}
+	}
+	return true
+}
+
+func (node *htmlNode) previousSibling() *htmlNode {
+	if node.parent == nil {
+		return nil
+	}
+	for i, sibling := range node.parent.children {
+		if sibling == node {
+			if i == 0 {
+				return nil
+			}
+			return node.parent.children[i-1]
+		}
+	}
+	return nil
+}
+
+// outerHTML writes a node back out as HTML.
+func (node *htmlNode) outerHTML() string {
+	switch node.tag {
+	case "":
+		return html.EscapeString(node.text)
+	case "#comment", "#raw":
+		return node.raw
+	}
+
+	var b strings.Builder
+	b.WriteString(node.raw)
+	for _, child := range node.children {
+		b.WriteString(child.outerHTML())
+	}
+	if !voidElements[node.tag] && !strings.HasSuffix(node.raw, "/>") {
+		b.WriteString("</" + node.tag + ">")
+	}
+	return b.String()
+}
+
+func (node *htmlNode) textContent() string {
+	if node.tag == "" {
+		return node.text
+	}
+	if node.tag == "#raw" {
+		return html.UnescapeString(node.raw)
+	}
+	var b strings.Builder
+	for _, child := range node.children {
+		b.WriteString(child.textContent())
+	}
+	return b.String()
+}
+
+// Elements written as raw HTML blocks because Markdown has no equivalent.
+var rawBlockElements = map[string]bool{
+	"table": true, "iframe": true, "figure": true, "video": true, "audio": true, "form": true,
+	"script": true, "style": true, "dl": true, "section": true, "article": true, "aside": true,
+	"header": true, "footer": true, "nav": true, "center": true, "object": true, "details": true,
+}
+
+var preCodePattern = regexp.MustCompile(`(?s)^\s*<code([^>]*)>(.*)</code>\s*$`)
+
+var whitespacePattern = regexp.MustCompile(`\s+`)
+
+type markdownWriter struct{}
+
+func (w markdownWriter) children(node *htmlNode, inline bool) string {
+	var b strings.Builder
+	for _, child := range node.children {
+		b.WriteString(w.node(child, inline))
+	}
+	return b.String()
+}
+
+func (w markdownWriter) inline(node *htmlNode) string {
+	return strings.TrimSpace(collapseInlineSpace(w.children(node, true)))
+}
+
+func (w markdownWriter) node(node *htmlNode, inline bool) string {
+	switch node.tag {
+	case "":
+		text := escapeMarkdown(whitespacePattern.ReplaceAllString(node.text, " "))
+		if !inline {
+			// Text directly inside a block element starts a line.
+			if node.previousSibling() != nil && node.previousSibling().tag == "br" {
+				text = strings.TrimLeft(text, " ")
+			}
+			text = escapeBlockStart(text)
+		}
+		return text
+	case "#comment":
+		return node.raw
+	case "#raw":
+		return html.UnescapeString(node.raw)
+	case "p":
+		return "\n\n" + escapeBlockStarts(w.inline(node)) + "\n\n"
+	case "div":
+		if len(node.attrs) > 0 {
+			return "\n\n" + node.outerHTML() + "\n\n"
+		}
+		return "\n\n" + w.children(node, false) + "\n\n"
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level := int(node.tag[1] - '0')
+		content := w.inline(node)
+		if strings.HasSuffix(content, "#") {
+			// Keep a trailing # from being read as a closing sequence.
+			content = content[:len(content)-1] + `\#`
+		}
+		return "\n\n" + strings.Repeat("#", level) + " " + content + "\n\n"
+	case "br":
+		return "  \n"
+	case "hr":
+		return "\n\n---\n\n"
+	case "strong", "b":
+		return wrapMarkdown("**", w.children(node, true))
+	case "em", "i":
+		return wrapMarkdown("*", w.children(node, true))
+	case "code":
+		return markdownCodeSpan(node.textContent())
+	case "a":
+		href, ok := node.attrs["href"]
+		if !ok || len(node.attrs) > 1 {
+			return node.outerHTML()
+		}
+		return "[" + w.inline(node) + "](" + markdownLinkTarget(href) + ")"
+	case "img":
+		if len(node.attrs) > 2 || node.attrs["src"] == "" {
+			return node.outerHTML()
+		}
+		return "![" + escapeMarkdown(node.attrs["alt"]) + "](" + markdownLinkTarget(node.attrs["src"]) + ")"
+	case "pre":
+		code := node.textContent()
+		language := ""
+		if match := preCodePattern.FindStringSubmatch(node.children[0].raw); match != nil {
+			_, attrs, _, _ := parseHTMLTag("<code" + match[1] + ">")
+			language = strings.TrimPrefix(attrs["class"], "language-")
+			code = html.UnescapeString(match[2])
+		}
+		code = strings.Trim(code, "\n")
+		fence := "```"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+		return "\n\n" + fence + language + "\n" + code + "\n" + fence + "\n\n"
+	case "blockquote":
+		content := strings.TrimSpace(collapseBlankLines(w.children(node, false)))
+		lines := strings.Split(content, "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight("> "+line, " ")
+		}
+		return "\n\n" + strings.Join(lines, "\n") + "\n\n"
+	case "ul", "ol":
+		return "\n\n" + w.list(node) + "\n\n"
+	}
+
+	if rawBlockElements[node.tag] {
+		return "\n\n" + node.outerHTML() + "\n\n"
+	}
+	return node.outerHTML()
+}
+
+func (w markdownWriter) list(node *htmlNode) string {
+	var items []string
+	number := 1
+	if start, err := strconv.Atoi(node.attrs["start"]); err == nil {
+		number = start
+	}
+
+	for _, child := range node.children {
+		if child.tag != "li" {
+			continue
+		}
+
+		marker := "- "
+		if node.tag == "ol" {
+			marker = strconv.Itoa(number) + ". "
+			number++
+		}
+
+		content := strings.TrimSpace(collapseBlankLines(w.children(child, false)))
+		content = strings.ReplaceAll(content, "\n\n", "\n")
+		lines := strings.Split(content, "\n")
+		for i := range lines {
+			if i > 0 {
+				lines[i] = strings.Repeat(" ", len(marker)) + lines[i]
+			} else {
+				hardBreak := strings.HasSuffix(lines[i], "  ") && len(lines) > 1
+				lines[i] = marker + strings.TrimSpace(collapseInlineSpace(lines[i]))
+				if isRuleLine(lines[i]) {
+					// An item such as "- --" would be read as a rule.
+					lines[i] = marker + `\` + strings.TrimPrefix(lines[i], marker)
+				}
+				if hardBreak {
+					lines[i] += "  "
+				}
+			}
+		}
+		items = append(items, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(items, "\n")
+}
+
+// markdownCodeSpan fences code with more backticks than any run inside it, padding
+// it with spaces when it starts or ends with a backtick.
+func markdownCodeSpan(code string) string {
+	fence := "`"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") ||
+		(strings.HasPrefix(code, " ") && strings.HasSuffix(code, " ") && strings.Trim(code, " ") != "") {
+		code = " " + code + " "
+	}
+	return fence + code + fence
+}
+
+func wrapMarkdown(marker, content string) string {
+	trimmed := strings.TrimSpace(content)
+	if trimmed == "" {
+		return content
+	}
+	// Keep the surrounding spaces outside the markers so the emphasis still parses.
+	leading := content[:len(content)-len(strings.TrimLeft(content, " "))]
+	trailing := content[len(strings.TrimRight(content, " ")):]
+	return leading + marker + trimmed + marker + trailing
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`)
+
+// Text that ToHTML would otherwise pass through as a tag or an entity.
+var (
+	textTagPattern    = regexp.MustCompile(`<([a-zA-Z/!])`)
+	textEntityPattern = regexp.MustCompile(`&(#?[a-zA-Z0-9]+;)`)
+)
+
+func escapeMarkdown(s string) string {
+	s = markdownEscaper.Replace(s)
+	s = textTagPattern.ReplaceAllString(s, `\<$1`)
+	return textEntityPattern.ReplaceAllString(s, `\&$1`)
+}
+
+// blockStartPattern matches the start of a line that ToHTML would read
+// as a heading, list item or quote. The first group is the marker.
+var blockStartPattern = regexp.MustCompile(`^\s*(#{1,6}|[-+*]|\d+[.)])(?:\s|$)|^\s*(>)`)
+
+// escapeBlockStart escapes text at the start of a line that would otherwise be
+// read back as block syntax, such as "2024. A great year" becoming a list.
+func escapeBlockStart(line string) string {
+	if isRuleLine(line) {
+		i := len(line) - len(strings.TrimLeft(line, " \t"))
+		return line[:i] + `\` + line[i:]
+	}
+
+	match := blockStartPattern.FindStringSubmatchIndex(line)
+	if match == nil {
+		return line
+	}
+	end := match[3]
+	if end < 0 {
+		end = match[5]
+	}
+	return line[:end-1] + `\` + line[end-1:]
+}
+
+func escapeBlockStarts(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = escapeBlockStart(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+var blankLinesPattern = regexp.MustCompile(`\n[ \t]*\n(?:[ \t]*\n)+`)
+
+func collapseBlankLines(s string) string {
+	return blankLinesPattern.ReplaceAllString(s, "\n\n")
+}
+
+var inlineSpacePattern = regexp.MustCompile(`[ \t]+`)
+
+func collapseInlineSpace(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		hardBreak := strings.HasSuffix(line, "  ") && i < len(lines)-1
+		line = strings.TrimSpace(inlineSpacePattern.ReplaceAllString(line, " "))
+		if hardBreak {
+			line += "  "
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})(?:\s+(.*?))?(?:\s+#+)?\s*$`)
+	listItemPattern    = regexp.MustCompile(`^(\s*)([-*+]|\d+\.)\s+(.*)$`)
+	ruleLinePattern    = regexp.MustCompile(`^\s*([-*_])(\s*([-*_]))*\s*$`)
+	orderedPattern     = regexp.MustCompile(`^\d+\.$`)
+	htmlBlockStartLine = regexp.MustCompile(`^\s*<(/?[a-zA-Z][a-zA-Z0-9-]*|!--)`)
+)
+
+func isRuleLine(line string) bool {
+	trimmed := strings.ReplaceAll(strings.TrimSpace(line), " ", "")
+	return len(trimmed) >= 3 && ruleLinePattern.MatchString(line) && strings.Count(trimmed, trimmed[:1]) == len(trimmed)
+}
+
+func markdownBlocks(lines []string) []string {
+	var blocks []string
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case strings.HasPrefix(trimmed, "```"):
+			fence := trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, "`"))]
+			language := strings.TrimSpace(strings.TrimPrefix(trimmed, fence))
+			var code []string
+			i++
+			for i < len(lines) && !isClosingFence(lines[i], fence) {
+				code = append(code, lines[i])
+				i++
+			}
+			i++
+			open := "<pre><code>"
+			if language != "" {
+				open = fmt.Sprintf(`<pre><code class="language-%s">`, html.EscapeString(language))
+			}
+			blocks = append(blocks, open+html.EscapeString(strings.Join(code, "\n"))+"</code></pre>")
+
+		case headingPattern.MatchString(trimmed):
+			match := headingPattern.FindStringSubmatch(trimmed)
+			tag := "h" + strconv.Itoa(len(match[1]))
+			blocks = append(blocks, "<"+tag+">"+markdownInline(match[2])+"</"+tag+">")
+			i++
+
+		case isRuleLine(trimmed):
+			blocks = append(blocks, "<hr>")
+			i++
+
+		case strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">") {
+				content := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(content, " "))
+				i++
+			}
+			blocks = append(blocks, "<blockquote>\n"+strings.Join(markdownBlocks(quoted), "\n")+"\n</blockquote>")
+
+		case listItemPattern.MatchString(line):
+			var block string
+			block, i = markdownList(lines, i)
+			blocks = append(blocks, block)
+
+		case htmlBlockStartLine.MatchString(line):
+			var raw []string
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" {
+				raw = append(raw, lines[i])
+				i++
+			}
+			blocks = append(blocks, strings.Join(raw, "\n"))
+
+		default:
+			var paragraph []string
+			for i < len(lines) {
+				l := lines[i]
+				t := strings.TrimSpace(l)
+				if t == "" || (len(paragraph) > 0 && startsMarkdownBlock(l)) {
+					break
+				}
+				paragraph = append(paragraph, l)
+				i++
+			}
+			blocks = append(blocks, "<p>"+markdownParagraph(paragraph)+"</p>")
+		}
+	}
+
+	return blocks
+}
+
+// isClosingFence reports whether line closes a code block opened with fence: a
+// line of nothing but at least as many backticks.
+func isClosingFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, "`") == ""
+}
+
+func startsMarkdownBlock(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || headingPattern.MatchString(trimmed) || strings.HasPrefix(trimmed, ">") ||
+		listItemPattern.MatchString(line) || isRuleLine(trimmed)
+}
+
+// markdownList converts the list starting at lines[start] and returns the index
+// of the first line after it.
+func markdownList(lines []string, start int) (string, int) {
+	first := listItemPattern.FindStringSubmatch(lines[start])
+	indent := len(first[1])
+	ordered := orderedPattern.MatchString(first[2])
+
+	tag := "ul"
+	open := "<ul>"
+	if ordered {
+		tag = "ol"
+		open = "<ol>"
+		if n, _ := strconv.Atoi(strings.TrimSuffix(first[2], ".")); n > 1 {
+			open = fmt.Sprintf(`<ol start="%d">`, n)
+		}
+	}
+
+	var items []string
+	i := start
+	for i < len(lines) {
+		match := listItemPattern.FindStringSubmatch(lines[i])
+		if match == nil || len(match[1]) != indent || orderedPattern.MatchString(match[2]) != ordered {
+			break
+		}
+
+		text := []string{match[3]}
+		var nested []string
+		i++
+		for i < len(lines) {
+			l := lines[i]
+			if strings.TrimSpace(l) == "" {
+				break
+			}
+			lineIndent := len(l) - len(strings.TrimLeft(l, " "))
+			if lineIndent <= indent {
+				break
+			}
+			if nested != nil || listItemPattern.MatchString(l) {
+				nested = append(nested, l)
+			} else {
+				text = append(text, l)
+			}
+			i++
+		}
+
+		item := "<li>" + markdownParagraph(text)
+		if nested != nil {
+			n := minIndent(nested)
+			for j := range nested {
+				nested[j] = trimIndent(nested[j], n)
+			}
+			item += "\n" + strings.Join(markdownBlocks(nested), "\n") + "\n"
+		}
+		items = append(items, item+"</li>")
+	}
+
+	return open + "\n" + strings.Join(items, "\n") + "\n</" + tag + ">", i
+}
+
+func minIndent(lines []string) int {
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " "))
+		if indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	if indent < 0 {
+		return 0
+	}
+	return indent
+}
+
+func trimIndent(line string, n int) string {
+	if len(line) >= n {
+		return line[n:]
+	}
+	return strings.TrimLeft(line, " ")
+}
+
+// markdownParagraph converts the lines of a paragraph, turning lines that end in
+// two spaces into line breaks.
+func markdownParagraph(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		hardBreak := strings.HasSuffix(line, "  ")
+		b.WriteString(markdownInline(strings.TrimSpace(line)))
+		if i < len(lines)-1 {
+			if hardBreak {
+				b.WriteString("<br>")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func markdownInline(text string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		rest := text[i:]
+
+		switch {
+		case c == '\\' && i+1 < len(text) && strings.IndexByte("\\`*_[]{}()#+-.!<>&", text[i+1]) >= 0:
+			b.WriteString(html.EscapeString(text[i+1 : i+2]))
+			i += 2
+			continue
+
+		case c == '`':
+			code, n, ok := codeSpan(rest)
+			if ok {
+				b.WriteString("<code>" + html.EscapeString(code) + "</code>")
+			} else {
+				// An unmatched run of backticks is literal text.
+				b.WriteString(rest[:n])
+			}
+			i += n
+			continue
+
+		case strings.HasPrefix(rest, "!["):
+			if alt, target, n, ok := markdownLink(rest[1:]); ok {
+				fmt.Fprintf(&b, `<img src="%s" alt="%s">`, html.EscapeString(target), html.EscapeString(unescapeMarkdown(alt)))
+				i += n + 1
+				continue
+			}
+
+		case c == '[':
+			if label, target, n, ok := markdownLink(rest); ok {
+				fmt.Fprintf(&b, `<a href="%s">%s</a>`, html.EscapeString(target), markdownInline(label))
+				i += n
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**"):
+			if end := closingMarker(rest[2:], "**"); end > 0 {
+				b.WriteString("<strong>" + markdownInline(rest[2:2+end]) + "</strong>")
+				i += end + 4
+				continue
+			}
+
+		case c == '*':
+			if end := closingMarker(rest[1:], "*"); end > 0 {
+				b.WriteString("<em>" + markdownInline(rest[1:1+end]) + "</em>")
+				i += end + 2
+				continue
+			}
+
+		case c == '<':
+			if end := htmlTagEnd(text, i); end > 0 && htmlBlockStartLine.MatchString(rest) {
+				b.WriteString(text[i : end+1])
+				i = end + 1
+				continue
+			}
+
+		case c == '&':
+			if semi := strings.IndexByte(rest, ';'); semi > 1 && semi < 10 && html.UnescapeString(rest[:semi+1]) != rest[:semi+1] {
+				b.WriteString(rest[:semi+1])
+				i += semi + 1
+				continue
+			}
+		}
+
+		b.WriteString(html.EscapeString(text[i : i+1]))
+		i++
+	}
+
+	return b.String()
+}
+
+// closingMarker finds the marker closing an emphasis, skipping escaped characters
+// and code spans. It returns -1 when there is none.
+func closingMarker(text, marker string) int {
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\\':
+			i++
+		case text[i] == '`':
+			_, n, _ := codeSpan(text[i:])
+			i += n - 1
+		case strings.HasPrefix(text[i:], marker):
+			if marker == "*" && strings.HasPrefix(text[i:], "**") {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+// codeSpan parses a code span at the start of text, which opens and closes with
+// backtick runs of the same length. It returns the code and the length of the
+// span, or of the opening run when the span isn't closed.
+func codeSpan(text string) (code string, n int, ok bool) {
+	fence := len(text) - len(strings.TrimLeft(text, "`"))
+	for i := fence; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+		run := len(text[i:]) - len(strings.TrimLeft(text[i:], "`"))
+		if run == fence {
+			code = text[fence:i]
+			if len(code) >= 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+				code = code[1 : len(code)-1]
+			}
+			return code, i + run, true
+		}
+		i += run
+	}
+	return "", fence, false
+}
+
+// markdownLink parses "[label](target)" at the start of text.
+func markdownLink(text string) (label, target string, n int, ok bool) {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				if i+1 >= len(text) || text[i+1] != '(' {
+					return "", "", 0, false
+				}
+				target, end, ok := linkTarget(text[i+2:])
+				if !ok {
+					return "", "", 0, false
+				}
+				return text[1:i], target, i + 3 + end, true
+			}
+		}
+	}
+	return "", "", 0, false
+}
+
+// markdownLinkTarget writes a link target, using the <target> form for targets
+// with parentheses or spaces so the target doesn't end early when read back.
+func markdownLinkTarget(target string) string {
+	if !strings.ContainsAny(target, "()<> \t\n") {
+		return target
+	}
+	return "<" + linkTargetEscaper.Replace(target) + ">"
+}
+
+var linkTargetEscaper = strings.NewReplacer(`\`, `\\`, "<", `\<`, ">", `\>`)
+
+// linkTarget reads a link target from text, which follows the "(", and returns
+// the index of the closing ")". The target is either written as <target> or
+// runs to the ")" that balances the parentheses within it.
+func linkTarget(text string) (target string, end int, ok bool) {
+	if strings.HasPrefix(text, "<") {
+		var b strings.Builder
+		for i := 1; i < len(text); i++ {
+			switch c := text[i]; c {
+			case '\\':
+				if i+1 < len(text) {
+					i++
+					b.WriteByte(text[i])
+				}
+			case '>':
+				if i+1 < len(text) && text[i+1] == ')' {
+					return b.String(), i + 1, true
+				}
+				return "", 0, false
+			case '<', '\n':
+				return "", 0, false
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", 0, false
+	}
+
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return text[:i], i, true
+			}
+			depth--
+		}
+	}
+	return "", 0, false
+}
+
+func unescapeMarkdown(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
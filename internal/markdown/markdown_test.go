@@ -0,0 +1,150 @@
+package markdown
+
+import (
+	"html"
+	"strings"
+	"testing"
+)
+
+const markdownTestHTML = `<h2>Spring</h2>
+<p>Our <strong>new</strong> range is <a href="https://example.com/spring">here</a>.<br>
+See <em>below</em> for 2*3 offers &amp; more.</p>
+<ul>
+<li>One</li>
+<li>Two <code>x_y</code><ul><li>Nested</li></ul></li>
+</ul>
+<ol><li>First</li><li>Second</li></ol>
+<blockquote><p>Quote</p></blockquote>
+<pre><code class="language-go">if a &lt; b {
+}</code></pre>
+<p><img src="/images/spring.png" alt="Spring range"></p>
+<table><tr><td>kept as HTML</td></tr></table>
+<hr>`
+
+func TestFromHTML(t *testing.T) {
+	markdown := FromHTML(markdownTestHTML)
+
+	for _, want := range []string{
+		"## Spring",
+		"Our **new** range is [here](https://example.com/spring).  \nSee *below* for 2\\*3 offers & more.",
+		"- One\n- Two `x_y`\n  - Nested",
+		"1. First\n2. Second",
+		"> Quote",
+		"```go\nif a < b {\n}\n```",
+		"![Spring range](/images/spring.png)",
+		"<table><tr><td>kept as HTML</td></tr></table>",
+		"---",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("expected markdown to contain %q, got:\n%s", want, markdown)
+		}
+	}
+}
+
+func TestToHTML_RoundTrip(t *testing.T) {
+	markdown := FromHTML(markdownTestHTML)
+	html := ToHTML(markdown)
+
+	for _, want := range []string{
+		`<a href="https://example.com/spring">here</a>.<br>`,
+		"for 2*3 offers &amp; more.",
+		"<li>Two <code>x_y</code>\n<ul>\n<li>Nested</li>\n</ul>\n</li>",
+		`<pre><code class="language-go">if a &lt; b {`,
+		`<img src="/images/spring.png" alt="Spring range">`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("expected HTML to contain %q, got:\n%s", want, html)
+		}
+	}
+
+	if again := FromHTML(html); again != markdown {
+		t.Errorf("markdown changed after a round trip:\n%s\n\nbecame:\n%s", markdown, again)
+	}
+}
+
+func TestBlockSyntaxInTextRoundTrips(t *testing.T) {
+	for _, source := range []string{
+		`<p>2024. A great year</p>`,
+		`<p>1) one</p>`,
+		`<p>- not a list</p>`,
+		`<p>+ plus</p>`,
+		`<p>* star</p>`,
+		`<p>&gt; not a quote</p>`,
+		`<p>&gt;quoted</p>`,
+		`<p># not a heading</p>`,
+		`<p>---</p>`,
+		`<p>line<br>- second</p>`,
+		`<p>&lt;b&gt; is not bold</p>`,
+		`<p>write &amp;copy; for ©</p>`,
+		`<h2>C#</h2>`,
+		`<ul><li>- dash</li><li>2024. year</li></ul>`,
+		`<blockquote><p>1. quoted</p></blockquote>`,
+	} {
+		markdown := FromHTML(source)
+		html := ToHTML(markdown)
+		if FromHTML(html) != markdown || textOf(html) != textOf(source) || tagsOf(html) != tagsOf(source) {
+			t.Errorf("%s did not round-trip:\nmarkdown: %q\nhtml:     %s", source, markdown, html)
+		}
+	}
+}
+
+func TestLessThanInTextRoundTrips(t *testing.T) {
+	for _, source := range []string{
+		`<p>a < b and 5 > 3</p>`,
+		`<p>x <3 y < /p z</p>`,
+		`<p>if a < b then <strong>stop</strong> < now</p>`,
+	} {
+		markdown := FromHTML(source)
+		html := ToHTML(markdown)
+		if textOf(html) != textOf(source) || tagsOf(html) != tagsOf(source) || FromHTML(html) != markdown {
+			t.Errorf("%s did not round-trip:\nmarkdown: %q\nhtml:     %s", source, markdown, html)
+		}
+	}
+}
+
+func TestLinkTargetsRoundTrip(t *testing.T) {
+	for _, target := range []string{
+		"https://en.wikipedia.org/wiki/Foo_(bar)",
+		"https://example.com/a)b",
+		"/files/spring sale.pdf",
+		`/odd<name>\path`,
+	} {
+		for _, source := range []string{
+			`<p><a href="` + html.EscapeString(target) + `">wiki</a> after</p>`,
+			`<p><img src="` + html.EscapeString(target) + `" alt="pic"> after</p>`,
+		} {
+			markdown := FromHTML(source)
+			node := parseHTMLFragment(ToHTML(markdown)).children[0].children[0]
+			if got := node.attrs["href"] + node.attrs["src"]; got != target || textOf(ToHTML(markdown)) != textOf(source) {
+				t.Errorf("%s did not round-trip: markdown %q, target %q", source, markdown, got)
+			}
+		}
+	}
+
+	// Hand-written targets may use balanced parentheses without the <> form.
+	if got := ToHTML("[wiki](https://en.wikipedia.org/wiki/Foo_(bar)) after"); got != `<p><a href="https://en.wikipedia.org/wiki/Foo_(bar)">wiki</a> after</p>` {
+		t.Errorf("unexpected html %s", got)
+	}
+}
+
+// tagsOf and textOf compare HTML while ignoring the whitespace between tags that
+// ToHTML adds.
+func tagsOf(html string) string {
+	root := parseHTMLFragment(html)
+	var tags []string
+	var walk func(node *htmlNode)
+	walk = func(node *htmlNode) {
+		for _, child := range node.children {
+			if child.tag != "" {
+				tags = append(tags, child.tag)
+			}
+			walk(child)
+		}
+	}
+	walk(root)
+	return strings.Join(tags, " ")
+}
+
+func textOf(html string) string {
+	return strings.Join(strings.Fields(parseHTMLFragment(html).textContent()), "")
+}
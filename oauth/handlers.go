@@ -0,0 +1,117 @@
+package oauth
+
+import (
+	"errors"
+	"net/http"
+)
+
+// InstallHandler handles the auth callback BigCommerce redirects to when a store
+// installs the app, or when the app's scopes change.
+func (app *App) InstallHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		code, scope, context := query.Get("code"), query.Get("scope"), query.Get("context")
+		if code == "" || context == "" {
+			http.Error(w, "missing code or context", http.StatusBadRequest)
+			return
+		}
+
+		installation, err := app.Install(r.Context(), code, scope, context)
+		if err != nil {
+			app.logf("oauth: install failed: %v", err)
+			http.Error(w, "installation failed", http.StatusBadGateway)
+			return
+		}
+
+		if app.config.OnInstall != nil {
+			app.config.OnInstall(w, r, installation)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Write([]byte("App installed"))
+	})
+}
+
+// LoadHandler handles the load callback, called when a user opens the app.
+func (app *App) LoadHandler() http.Handler {
+	return app.signedPayloadHandler(true, func(w http.ResponseWriter, r *http.Request, payload SignedPayload) {
+		if app.config.OnLoad != nil {
+			app.config.OnLoad(w, r, payload)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// UninstallHandler handles the uninstall callback and deletes the store's
+// installation and cached client.
+func (app *App) UninstallHandler() http.Handler {
+	return app.signedPayloadHandler(false, func(w http.ResponseWriter, r *http.Request, payload SignedPayload) {
+		if app.config.OnUninstall != nil {
+			app.config.OnUninstall(r, payload)
+		}
+		if err := app.config.Store.Delete(r.Context(), payload.StoreHash()); err != nil {
+			app.logf("oauth: failed to delete installation for store %s: %v", payload.StoreHash(), err)
+			http.Error(w, "uninstall failed", http.StatusInternalServerError)
+			return
+		}
+		app.forgetClient(payload.StoreHash())
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// RemoveUserHandler handles the remove user callback.
+func (app *App) RemoveUserHandler() http.Handler {
+	return app.signedPayloadHandler(true, func(w http.ResponseWriter, r *http.Request, payload SignedPayload) {
+		if app.config.OnRemoveUser != nil {
+			app.config.OnRemoveUser(r, payload)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// signedPayloadHandler verifies the signed_payload_jwt query parameter before
+// calling next. With requireInstallation, requests for stores without an
+// installation are refused. Uninstalls are let through so a store whose
+// installation is already gone can still be cleaned up.
+func (app *App) signedPayloadHandler(requireInstallation bool, next func(w http.ResponseWriter, r *http.Request, payload SignedPayload)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.URL.Query().Get("signed_payload_jwt")
+		if token == "" {
+			http.Error(w, "missing signed_payload_jwt", http.StatusBadRequest)
+			return
+		}
+
+		payload, err := app.VerifySignedPayload(token)
+		if err != nil {
+			app.logf("oauth: %v", err)
+			http.Error(w, "invalid signed payload", http.StatusUnauthorized)
+			return
+		}
+
+		if !requireInstallation {
+			next(w, r, payload)
+			return
+		}
+
+		_, err = app.config.Store.Get(r.Context(), payload.StoreHash())
+		if errors.Is(err, ErrNotInstalled) {
+			http.Error(w, "app is not installed", http.StatusUnauthorized)
+			return
+		}
+		if err != nil {
+			app.logf("oauth: failed to get installation for store %s: %v", payload.StoreHash(), err)
+			http.Error(w, "failed to get installation", http.StatusInternalServerError)
+			return
+		}
+
+		next(w, r, payload)
+	})
+}
+
+func (app *App) logf(format string, v ...interface{}) {
+	if app.config.Logger != nil {
+		app.config.Logger.Printf(format, v...)
+	}
+}
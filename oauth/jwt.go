@@ -0,0 +1,108 @@
+package oauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSignedPayload is returned, wrapped, for signed payloads that are
+// malformed, signed with another secret, meant for another app or expired.
+var ErrInvalidSignedPayload = errors.New("invalid signed payload")
+
+// signedPayloadLeeway allows for clock differences between BigCommerce and the app.
+const signedPayloadLeeway = time.Minute
+
+// SignedPayload holds the claims of the signed payload JWT BigCommerce sends to
+// the load, uninstall and remove user callbacks.
+type SignedPayload struct {
+	// Audience is the app's client ID.
+	Audience  string `json:"aud"`
+	Issuer    string `json:"iss"`
+	IssuedAt  int64  `json:"iat"`
+	NotBefore int64  `json:"nbf"`
+	ExpiresAt int64  `json:"exp"`
+	ID        string `json:"jti"`
+	// Subject is stores/{store_hash}.
+	Subject string `json:"sub"`
+	// User is the user opening the app, or the user being removed.
+	User  User `json:"user"`
+	Owner User `json:"owner"`
+	// URL is the path the app was opened at, for deep links.
+	URL       string `json:"url"`
+	ChannelID *int   `json:"channel_id"`
+}
+
+// StoreHash returns the hash of the store the payload is from.
+func (payload SignedPayload) StoreHash() string {
+	return storeHashFromContext(payload.Subject)
+}
+
+// IsOwner reports whether the payload's user is the store owner.
+func (payload SignedPayload) IsOwner() bool {
+	return payload.User.ID == payload.Owner.ID
+}
+
+// VerifySignedPayload checks that token is an HS256 JWT signed with clientSecret,
+// addressed to clientID and valid at now, and returns its claims.
+func VerifySignedPayload(token, clientID, clientSecret string, now time.Time) (SignedPayload, error) {
+	var payload SignedPayload
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return payload, fmt.Errorf("%w: not a JWT", ErrInvalidSignedPayload)
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return payload, fmt.Errorf("%w: failed to decode header: %v", ErrInvalidSignedPayload, err)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return payload, fmt.Errorf("%w: failed to decode header: %v", ErrInvalidSignedPayload, err)
+	}
+	// Only HS256 is accepted, so a token can't choose a weaker algorithm or none.
+	if header.Alg != "HS256" {
+		return payload, fmt.Errorf("%w: unexpected algorithm %q", ErrInvalidSignedPayload, header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return payload, fmt.Errorf("%w: failed to decode signature: %v", ErrInvalidSignedPayload, err)
+	}
+	mac := hmac.New(sha256.New, []byte(clientSecret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return payload, fmt.Errorf("%w: signature mismatch", ErrInvalidSignedPayload)
+	}
+
+	claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return payload, fmt.Errorf("%w: failed to decode claims: %v", ErrInvalidSignedPayload, err)
+	}
+	if err := json.Unmarshal(claims, &payload); err != nil {
+		return payload, fmt.Errorf("%w: failed to decode claims: %v", ErrInvalidSignedPayload, err)
+	}
+
+	if payload.Audience != clientID {
+		return payload, fmt.Errorf("%w: issued for client %q", ErrInvalidSignedPayload, payload.Audience)
+	}
+	if payload.ExpiresAt == 0 || now.After(time.Unix(payload.ExpiresAt, 0).Add(signedPayloadLeeway)) {
+		return payload, fmt.Errorf("%w: expired", ErrInvalidSignedPayload)
+	}
+	if payload.NotBefore != 0 && now.Add(signedPayloadLeeway).Before(time.Unix(payload.NotBefore, 0)) {
+		return payload, fmt.Errorf("%w: not valid yet", ErrInvalidSignedPayload)
+	}
+	if payload.StoreHash() == "" {
+		return payload, fmt.Errorf("%w: no store in subject", ErrInvalidSignedPayload)
+	}
+
+	return payload, nil
+}
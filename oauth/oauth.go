@@ -0,0 +1,236 @@
+// Package oauth implements the install, load, uninstall and remove user callbacks
+// of a BigCommerce single-click app.
+//
+// The install callback exchanges the temporary code for a store's access token and
+// saves it to an InstallationStore. The other callbacks verify the signed payload
+// JWT BigCommerce sends with them using the app's client secret. Client returns a
+// bigcommerce.Client for any store the app is installed on.
+//
+// Example usage:
+//
+//	app := oauth.NewApp(oauth.Config{
+//	    ClientID:     os.Getenv("BC_CLIENT_ID"),
+//	    ClientSecret: os.Getenv("BC_CLIENT_SECRET"),
+//	    RedirectURI:  "https://app.example.com/auth",
+//	})
+//	http.Handle("/auth", app.InstallHandler())
+//	http.Handle("/load", app.LoadHandler())
+//	http.Handle("/uninstall", app.UninstallHandler())
+//	http.Handle("/remove-user", app.RemoveUserHandler())
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	bigcommerce "github.com/seanomeara96/go-bigcommerce"
+)
+
+const DefaultTokenURL = "https://login.bigcommerce.com/oauth2/token"
+
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	// RedirectURI is the app's auth callback URL, as registered in the developer
+	// portal.
+	RedirectURI string
+	// TokenURL defaults to DefaultTokenURL.
+	TokenURL string
+	// HTTPClient is used for the code exchange. Defaults to a client with a 30
+	// second timeout.
+	HTTPClient *http.Client
+	// Store saves installations. Defaults to NewMemoryInstallationStore().
+	Store InstallationStore
+	// RateLimitConfig and Logger are passed to bigcommerce.NewClient.
+	RateLimitConfig *bigcommerce.RateLimitConfig
+	Logger          bigcommerce.Logger
+
+	// OnInstall is called once an installation is saved. It should respond with the
+	// app's UI. Without it the install callback responds with a short message.
+	OnInstall func(w http.ResponseWriter, r *http.Request, installation Installation)
+	// OnLoad is called when a user opens the app in the control panel. It should
+	// respond with the app's UI. Without it the load callback responds 200 with no
+	// body.
+	OnLoad func(w http.ResponseWriter, r *http.Request, payload SignedPayload)
+	// OnUninstall is called before the store's installation is deleted.
+	OnUninstall func(r *http.Request, payload SignedPayload)
+	// OnRemoveUser is called when the store owner revokes a user's access to the app.
+	OnRemoveUser func(r *http.Request, payload SignedPayload)
+}
+
+// User is a control panel user.
+type User struct {
+	ID       int    `json:"id"`
+	Username string `json:"username,omitempty"`
+	Email    string `json:"email"`
+	Locale   string `json:"locale,omitempty"`
+}
+
+// Token is the response to the code exchange.
+type Token struct {
+	AccessToken string `json:"access_token"`
+	Scope       string `json:"scope"`
+	User        User   `json:"user"`
+	// Context is stores/{store_hash}.
+	Context     string `json:"context"`
+	AccountUUID string `json:"account_uuid"`
+}
+
+// StoreHash returns the hash of the store the token is for.
+func (token Token) StoreHash() string {
+	return storeHashFromContext(token.Context)
+}
+
+func storeHashFromContext(context string) string {
+	return strings.TrimPrefix(context, "stores/")
+}
+
+// ErrNotInstalled is returned for stores without a saved installation.
+var ErrNotInstalled = errors.New("app is not installed on store")
+
+// App handles the callbacks of one app.
+type App struct {
+	config Config
+
+	mu      sync.Mutex
+	clients map[string]storeClient
+}
+
+// storeClient is a cached client and the access token it was made with.
+type storeClient struct {
+	accessToken string
+	client      *bigcommerce.Client
+}
+
+// storeHashPattern matches store hashes, which are used in API URLs.
+var storeHashPattern = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+
+func NewApp(config Config) *App {
+	if config.TokenURL == "" {
+		config.TokenURL = DefaultTokenURL
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryInstallationStore()
+	}
+	return &App{config: config, clients: map[string]storeClient{}}
+}
+
+// ExchangeCode trades the temporary code sent to the install callback for the
+// store's access token. scope and context are the values sent with the code.
+func (app *App) ExchangeCode(ctx context.Context, code, scope, context string) (Token, error) {
+	var token Token
+
+	form := url.Values{
+		"client_id":     {app.config.ClientID},
+		"client_secret": {app.config.ClientSecret},
+		"code":          {code},
+		"scope":         {scope},
+		"grant_type":    {"authorization_code"},
+		"redirect_uri":  {app.config.RedirectURI},
+		"context":       {context},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, app.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return token, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := app.config.HTTPClient.Do(req)
+	if err != nil {
+		return token, fmt.Errorf("failed to exchange code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return token, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return token, fmt.Errorf("failed to exchange code: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, &token); err != nil {
+		return token, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if token.AccessToken == "" || token.StoreHash() == "" {
+		return token, fmt.Errorf("failed to exchange code: response has no access token or context")
+	}
+
+	return token, nil
+}
+
+// Install exchanges the code and saves the resulting installation.
+func (app *App) Install(ctx context.Context, code, scope, context string) (Installation, error) {
+	token, err := app.ExchangeCode(ctx, code, scope, context)
+	if err != nil {
+		return Installation{}, err
+	}
+
+	installation := Installation{
+		StoreHash:   token.StoreHash(),
+		AccessToken: token.AccessToken,
+		Scope:       token.Scope,
+		Owner:       token.User,
+		AccountUUID: token.AccountUUID,
+		InstalledAt: time.Now(),
+	}
+
+	if err := app.config.Store.Save(ctx, installation); err != nil {
+		return installation, fmt.Errorf("failed to save installation for store %s: %w", installation.StoreHash, err)
+	}
+
+	return installation, nil
+}
+
+// VerifySignedPayload verifies and decodes a signed payload JWT sent to the load,
+// uninstall or remove user callback.
+func (app *App) VerifySignedPayload(token string) (SignedPayload, error) {
+	return VerifySignedPayload(token, app.config.ClientID, app.config.ClientSecret, time.Now())
+}
+
+// Client returns a client for a store the app is installed on. Each store's client
+// is reused while its access token stays the same, so its rate limit state is
+// kept between calls.
+func (app *App) Client(ctx context.Context, storeHash string) (*bigcommerce.Client, error) {
+	installation, err := app.config.Store.Get(ctx, storeHash)
+	if err != nil {
+		return nil, err
+	}
+	if !storeHashPattern.MatchString(installation.StoreHash) {
+		return nil, fmt.Errorf("invalid store hash %q", installation.StoreHash)
+	}
+
+	app.mu.Lock()
+	defer app.mu.Unlock()
+
+	cached, ok := app.clients[installation.StoreHash]
+	if ok && cached.accessToken == installation.AccessToken {
+		return cached.client, nil
+	}
+
+	client := bigcommerce.NewClient(installation.StoreHash, installation.AccessToken, app.config.RateLimitConfig, app.config.Logger)
+	app.clients[installation.StoreHash] = storeClient{accessToken: installation.AccessToken, client: client}
+	return client, nil
+}
+
+// forgetClient drops the cached client for storeHash.
+func (app *App) forgetClient(storeHash string) {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+	delete(app.clients, storeHash)
+}
@@ -0,0 +1,191 @@
+package oauth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+const (
+	testClientID     = "client-id"
+	testClientSecret = "client-secret"
+)
+
+func signTestPayload(t *testing.T, alg string, claims map[string]interface{}, secret string) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	body, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func testClaims(storeHash string, userID int) map[string]interface{} {
+	now := time.Now().Unix()
+	return map[string]interface{}{
+		"aud":   testClientID,
+		"iss":   "bc",
+		"iat":   now,
+		"nbf":   now - 5,
+		"exp":   now + 3600,
+		"jti":   "abc",
+		"sub":   "stores/" + storeHash,
+		"user":  map[string]interface{}{"id": userID, "email": "user@example.com", "locale": "en"},
+		"owner": map[string]interface{}{"id": 1, "email": "owner@example.com"},
+		"url":   "/orders",
+	}
+}
+
+func TestVerifySignedPayload(t *testing.T) {
+	now := time.Now()
+
+	token := signTestPayload(t, "HS256", testClaims("abc123", 7), testClientSecret)
+	payload, err := VerifySignedPayload(token, testClientID, testClientSecret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payload.StoreHash() != "abc123" || payload.User.ID != 7 || payload.Owner.Email != "owner@example.com" || payload.URL != "/orders" {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+	if payload.IsOwner() {
+		t.Fatal("expected user 7 not to be the owner")
+	}
+
+	expired := testClaims("abc123", 7)
+	expired["exp"] = now.Add(-time.Hour).Unix()
+	otherApp := testClaims("abc123", 7)
+	otherApp["aud"] = "someone-else"
+
+	invalid := map[string]string{
+		"wrong secret": signTestPayload(t, "HS256", testClaims("abc123", 7), "guess"),
+		"none alg":     signTestPayload(t, "none", testClaims("abc123", 7), testClientSecret),
+		"expired":      signTestPayload(t, "HS256", expired, testClientSecret),
+		"other app":    signTestPayload(t, "HS256", otherApp, testClientSecret),
+		"malformed":    "not.a-jwt",
+	}
+	for name, token := range invalid {
+		if _, err := VerifySignedPayload(token, testClientID, testClientSecret, now); !errors.Is(err, ErrInvalidSignedPayload) {
+			t.Errorf("%s: expected ErrInvalidSignedPayload, got %v", name, err)
+		}
+	}
+}
+
+func TestAppFlow(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if r.Form.Get("code") != "good-code" || r.Form.Get("client_secret") != testClientSecret || r.Form.Get("grant_type") != "authorization_code" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid code"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(Token{
+			AccessToken: "access-token",
+			Scope:       r.Form.Get("scope"),
+			User:        User{ID: 1, Email: "owner@example.com"},
+			Context:     r.Form.Get("context"),
+		})
+	}))
+	defer tokenServer.Close()
+
+	var loaded []SignedPayload
+	var uninstalled []string
+	app := NewApp(Config{
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURI:  "https://app.example.com/auth",
+		TokenURL:     tokenServer.URL,
+		OnLoad: func(w http.ResponseWriter, r *http.Request, payload SignedPayload) {
+			loaded = append(loaded, payload)
+			w.Write([]byte("app UI"))
+		},
+		OnUninstall: func(r *http.Request, payload SignedPayload) {
+			uninstalled = append(uninstalled, payload.StoreHash())
+		},
+	})
+
+	get := func(handler http.Handler, query url.Values) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?"+query.Encode(), nil))
+		return w
+	}
+
+	install := url.Values{"code": {"bad-code"}, "scope": {"store_v2_orders"}, "context": {"stores/abc123"}}
+	if w := get(app.InstallHandler(), install); w.Code != http.StatusBadGateway {
+		t.Fatalf("expected 502 for a rejected code, got %d", w.Code)
+	}
+	install.Set("code", "good-code")
+	if w := get(app.InstallHandler(), install); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+
+	client, err := app.Client(context.Background(), "abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.V3.BaseURL().String() != "https://api.bigcommerce.com/stores/abc123/v3" {
+		t.Fatalf("unexpected base URL %s", client.V3.BaseURL())
+	}
+	if again, err := app.Client(context.Background(), "abc123"); err != nil || again != client {
+		t.Fatalf("expected the store's client to be reused, got %v", err)
+	}
+	if _, err := app.Client(context.Background(), "other"); !errors.Is(err, ErrNotInstalled) {
+		t.Fatalf("expected ErrNotInstalled, got %v", err)
+	}
+
+	load := url.Values{"signed_payload_jwt": {signTestPayload(t, "HS256", testClaims("abc123", 1), testClientSecret)}}
+	if w := get(app.LoadHandler(), load); w.Code != http.StatusOK || w.Body.String() != "app UI" {
+		t.Fatalf("unexpected load response %d %q", w.Code, w.Body)
+	}
+	if len(loaded) != 1 || !loaded[0].IsOwner() {
+		t.Fatalf("unexpected loads %+v", loaded)
+	}
+
+	forged := url.Values{"signed_payload_jwt": {signTestPayload(t, "HS256", testClaims("abc123", 1), "guess")}}
+	if w := get(app.LoadHandler(), forged); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a forged payload, got %d", w.Code)
+	}
+	otherStore := url.Values{"signed_payload_jwt": {signTestPayload(t, "HS256", testClaims("other", 1), testClientSecret)}}
+	if w := get(app.LoadHandler(), otherStore); w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a store without an installation, got %d", w.Code)
+	}
+
+	removeUser := url.Values{"signed_payload_jwt": {signTestPayload(t, "HS256", testClaims("abc123", 7), testClientSecret)}}
+	if w := get(app.RemoveUserHandler(), removeUser); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	if w := get(app.UninstallHandler(), load); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if len(uninstalled) != 1 || uninstalled[0] != "abc123" {
+		t.Fatalf("unexpected uninstalls %v", uninstalled)
+	}
+	if _, err := app.Client(context.Background(), "abc123"); !errors.Is(err, ErrNotInstalled) {
+		t.Fatalf("expected the installation to be deleted, got %v", err)
+	}
+	if len(app.clients) != 0 {
+		t.Fatalf("expected the cached client to be dropped, got %v", app.clients)
+	}
+
+	// A malformed store hash is an error rather than a crash in NewClient.
+	app.config.Store.Save(context.Background(), Installation{StoreHash: "bad%zz", AccessToken: "x"})
+	if _, err := app.Client(context.Background(), "bad%zz"); err == nil {
+		t.Fatal("expected an error for an invalid store hash")
+	}
+}
@@ -0,0 +1,66 @@
+package oauth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Installation is the app's access to one store.
+type Installation struct {
+	StoreHash   string    `json:"store_hash"`
+	AccessToken string    `json:"access_token"`
+	Scope       string    `json:"scope"`
+	Owner       User      `json:"owner"`
+	AccountUUID string    `json:"account_uuid"`
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// InstallationStore saves installations, usually in the app's database.
+// Implementations must be safe for concurrent use.
+type InstallationStore interface {
+	// Save adds an installation or replaces the one for the same store, which
+	// happens when the app is reinstalled or its scopes change.
+	Save(ctx context.Context, installation Installation) error
+	// Get returns ErrNotInstalled when the store has no installation.
+	Get(ctx context.Context, storeHash string) (Installation, error)
+	Delete(ctx context.Context, storeHash string) error
+}
+
+// MemoryInstallationStore keeps installations in memory. It is meant for tests
+// and development, as installations are lost when the process exits.
+type MemoryInstallationStore struct {
+	mu            sync.RWMutex
+	installations map[string]Installation
+}
+
+func NewMemoryInstallationStore() *MemoryInstallationStore {
+	return &MemoryInstallationStore{installations: map[string]Installation{}}
+}
+
+func (store *MemoryInstallationStore) Save(ctx context.Context, installation Installation) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.installations[installation.StoreHash] = installation
+	return nil
+}
+
+func (store *MemoryInstallationStore) Get(ctx context.Context, storeHash string) (Installation, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+
+	installation, ok := store.installations[storeHash]
+	if !ok {
+		return installation, ErrNotInstalled
+	}
+	return installation, nil
+}
+
+func (store *MemoryInstallationStore) Delete(ctx context.Context, storeHash string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	delete(store.installations, storeHash)
+	return nil
+}
@@ -0,0 +1,310 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+// OrderSubResource names a V2 order sub-resource that can be loaded alongside an order
+type OrderSubResource string
+
+const (
+	OrderSubResourceProducts          OrderSubResource = "products"
+	OrderSubResourceShippingAddresses OrderSubResource = "shipping_addresses"
+	OrderSubResourceShipments         OrderSubResource = "shipments"
+	OrderSubResourceCoupons           OrderSubResource = "coupons"
+)
+
+// AllOrderSubResources is a slice containing every order sub-resource.
+var AllOrderSubResources = []OrderSubResource{
+	OrderSubResourceProducts,
+	OrderSubResourceShippingAddresses,
+	OrderSubResourceShipments,
+	OrderSubResourceCoupons,
+}
+
+type OrderBundleOptions struct {
+	// Include lists the sub-resources to load. All sub-resources are loaded when empty.
+	Include []OrderSubResource
+	// Concurrency caps the number of requests in flight across every order being
+	// loaded. Defaults to 4. The client's rate limit backoff still applies to each request.
+	Concurrency int
+}
+
+func (opts OrderBundleOptions) includes(resource OrderSubResource) bool {
+	if len(opts.Include) == 0 {
+		return true
+	}
+	for _, r := range opts.Include {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderBundleTotals are the order's monetary totals parsed into numbers, in the
+// order's currency, plus item counts taken from its products.
+type OrderBundleTotals struct {
+	SubtotalExTax   float64
+	SubtotalIncTax  float64
+	ShippingExTax   float64
+	ShippingIncTax  float64
+	HandlingExTax   float64
+	HandlingIncTax  float64
+	WrappingExTax   float64
+	WrappingIncTax  float64
+	Tax             float64
+	Discount        float64
+	CouponDiscount  float64
+	GiftCertificate float64
+	StoreCredit     float64
+	TotalExTax      float64
+	TotalIncTax     float64
+	Refunded        float64
+	ItemsQuantity   int
+	ItemsShipped    int
+	ItemsRefunded   int
+}
+
+// OrderBundle is an order together with its sub-resources
+type OrderBundle struct {
+	Order             Order
+	Products          []OrderProduct
+	ShippingAddresses []ShippingAddress
+	Shipments         []OrderShipment
+	Coupons           []OrderCoupon
+	Totals            OrderBundleTotals
+}
+
+// GetOrderBundle fetches an order and loads its sub-resources concurrently.
+func (client *V2Client) GetOrderBundle(orderID int, opts OrderBundleOptions) (OrderBundle, error) {
+	order, err := client.GetOrder(orderID)
+	if err != nil {
+		return OrderBundle{}, err
+	}
+	return client.LoadOrderBundle(order, opts)
+}
+
+// LoadOrderBundle loads the sub-resources of an order that has already been fetched,
+// following the order's products, shipping_addresses and coupons resource links.
+func (client *V2Client) LoadOrderBundle(order Order, opts OrderBundleOptions) (OrderBundle, error) {
+	bundles, err := client.LoadOrderBundles([]Order{order}, opts)
+	if err != nil {
+		return OrderBundle{}, err
+	}
+	return bundles[0], nil
+}
+
+// GetOrderBundles fetches the given orders and their sub-resources. Bundles are
+// returned in the same order as orderIDs.
+func (client *V2Client) GetOrderBundles(orderIDs []int, opts OrderBundleOptions) ([]OrderBundle, error) {
+	orders := make([]Order, len(orderIDs))
+	loader := newOrderBundleLoader(client, opts)
+
+	err := loader.run(len(orderIDs), func(i int) error {
+		order, err := client.GetOrder(orderIDs[i])
+		if err != nil {
+			return err
+		}
+		orders[i] = order
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return client.LoadOrderBundles(orders, opts)
+}
+
+// LoadOrderBundles loads the sub-resources of many orders concurrently. Bundles are
+// returned in the same order as orders.
+func (client *V2Client) LoadOrderBundles(orders []Order, opts OrderBundleOptions) ([]OrderBundle, error) {
+	bundles := make([]OrderBundle, len(orders))
+	for i := range orders {
+		bundles[i].Order = orders[i]
+	}
+
+	loader := newOrderBundleLoader(client, opts)
+
+	type task struct {
+		bundle   *OrderBundle
+		resource OrderSubResource
+	}
+	tasks := []task{}
+	for i := range bundles {
+		for _, resource := range AllOrderSubResources {
+			if opts.includes(resource) {
+				tasks = append(tasks, task{bundle: &bundles[i], resource: resource})
+			}
+		}
+	}
+
+	err := loader.run(len(tasks), func(i int) error {
+		return loader.load(tasks[i].bundle, tasks[i].resource)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range bundles {
+		bundles[i].Totals = bundles[i].computeTotals()
+	}
+
+	return bundles, nil
+}
+
+type orderBundleLoader struct {
+	client *V2Client
+	sem    chan struct{}
+}
+
+func newOrderBundleLoader(client *V2Client, opts OrderBundleOptions) *orderBundleLoader {
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 4
+	}
+	return &orderBundleLoader{client: client, sem: make(chan struct{}, concurrency)}
+}
+
+// run calls fn for every index in [0, n) with at most cap(sem) calls in flight and
+// returns the first error encountered. Remaining calls are skipped after an error.
+func (loader *orderBundleLoader) run(n int, fn func(i int) error) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	for i := 0; i < n; i++ {
+		loader.sem <- struct{}{}
+
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			<-loader.sem
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-loader.sem }()
+
+			if err := fn(i); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+func (loader *orderBundleLoader) load(bundle *OrderBundle, resource OrderSubResource) error {
+	orderID := bundle.Order.ID
+	client := loader.client
+
+	var err error
+	switch resource {
+	case OrderSubResourceProducts:
+		u := client.resourceURL(bundle.Order.Products, "orders", strconv.Itoa(orderID), "products")
+		bundle.Products, err = getAllV2Pages[OrderProduct](client, u)
+	case OrderSubResourceShippingAddresses:
+		u := client.resourceURL(bundle.Order.ShippingAddresses, "orders", strconv.Itoa(orderID), "shipping_addresses")
+		bundle.ShippingAddresses, err = getAllV2Pages[ShippingAddress](client, u)
+	case OrderSubResourceShipments:
+		u := client.constructURL("orders", strconv.Itoa(orderID), "shipments")
+		bundle.Shipments, err = getAllV2Pages[OrderShipment](client, u)
+	case OrderSubResourceCoupons:
+		u := client.resourceURL(bundle.Order.Coupons, "orders", strconv.Itoa(orderID), "coupons")
+		bundle.Coupons, err = getAllV2Pages[OrderCoupon](client, u)
+	default:
+		return fmt.Errorf("unknown order sub-resource %q", resource)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to load %s for order %d: %w", resource, orderID, err)
+	}
+	return nil
+}
+
+// resourceURL returns the URL of a V2 resource link, falling back to the path built
+// from pathComponents when the link is missing, malformed or points at another
+// host. The store's access token is sent with the request, so only links to the
+// API the client already uses are followed.
+func (client *V2Client) resourceURL(resource URLResource, pathComponents ...string) *url.URL {
+	if resource.URL != "" {
+		base := client.BaseURL()
+		if u, err := url.Parse(resource.URL); err == nil && u.IsAbs() && u.Scheme == base.Scheme && u.Host == base.Host {
+			return u
+		}
+	}
+	return client.constructURL(pathComponents...)
+}
+
+// getAllV2Pages fetches every page of a V2 list endpoint.
+func getAllV2Pages[T any](client *V2Client, u *url.URL) ([]T, error) {
+	all := []T{}
+	limit := 250
+
+	for page := 1; ; page++ {
+		pageURL := *u
+		query := pageURL.Query()
+		query.Set("page", strconv.Itoa(page))
+		query.Set("limit", strconv.Itoa(limit))
+		pageURL.RawQuery = query.Encode()
+
+		var data []T
+		if err := client.Get(&pageURL, &data); err != nil {
+			return nil, err
+		}
+
+		all = append(all, data...)
+
+		if len(data) < limit {
+			return all, nil
+		}
+	}
+}
+
+func (bundle *OrderBundle) computeTotals() OrderBundleTotals {
+	order := bundle.Order
+	totals := OrderBundleTotals{
+		SubtotalExTax:   parseFloat64(order.SubtotalExTax),
+		SubtotalIncTax:  parseFloat64(order.SubtotalIncTax),
+		ShippingExTax:   parseFloat64(order.ShippingCostExTax),
+		ShippingIncTax:  parseFloat64(order.ShippingCostIncTax),
+		HandlingExTax:   parseFloat64(order.HandlingCostExTax),
+		HandlingIncTax:  parseFloat64(order.HandlingCostIncTax),
+		WrappingExTax:   parseFloat64(order.WrappingCostExTax),
+		WrappingIncTax:  parseFloat64(order.WrappingCostIncTax),
+		Tax:             parseFloat64(order.TotalTax),
+		Discount:        parseFloat64(order.DiscountAmount),
+		CouponDiscount:  parseFloat64(order.CouponDiscount),
+		GiftCertificate: parseFloat64(order.GiftCertificateAmount),
+		StoreCredit:     parseFloat64(order.StoreCreditAmount),
+		TotalExTax:      parseFloat64(order.TotalExTax),
+		TotalIncTax:     parseFloat64(order.TotalIncTax),
+		Refunded:        parseFloat64(order.RefundedAmount),
+		ItemsQuantity:   order.ItemsTotal,
+		ItemsShipped:    order.ItemsShipped,
+	}
+
+	if len(bundle.Products) > 0 {
+		totals.ItemsQuantity = 0
+		totals.ItemsShipped = 0
+		for _, product := range bundle.Products {
+			totals.ItemsQuantity += product.Quantity
+			totals.ItemsShipped += product.QuantityShipped
+			totals.ItemsRefunded += product.QuantityRefunded
+		}
+	}
+
+	return totals
+}
@@ -0,0 +1,106 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLoadOrderBundle(t *testing.T) {
+	var requests int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stores/test/v2/orders/100/products", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Query().Get("limit") != "250" {
+			t.Errorf("expected limit=250, got %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `[{"id":1,"order_id":100,"sku":"A","quantity":2,"quantity_shipped":1},{"id":2,"order_id":100,"sku":"B","quantity":1,"quantity_refunded":1}]`)
+	})
+	mux.HandleFunc("/stores/test/v2/orders/100/shipping_addresses", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `[{"id":7,"order_id":100,"country_iso2":"IE"}]`)
+	})
+	mux.HandleFunc("/stores/test/v2/orders/100/shipments", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/stores/test/v2/orders/100/coupons", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		fmt.Fprint(w, `[{"id":3,"code":"SAVE","type":1}]`)
+	})
+	client := newTestServerClient(t, mux)
+
+	order := Order{
+		ID:          100,
+		TotalIncTax: "25.5000",
+		TotalTax:    "4.2500",
+		Products: URLResource{
+			URL: strings.TrimSuffix(client.V2.BaseURL().String(), "/") + "/orders/100/products",
+		},
+	}
+
+	bundle, err := client.V2.LoadOrderBundle(order, OrderBundleOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if requests != 4 {
+		t.Errorf("expected 4 requests, got %d", requests)
+	}
+	if len(bundle.Products) != 2 || len(bundle.ShippingAddresses) != 1 || len(bundle.Shipments) != 0 || len(bundle.Coupons) != 1 {
+		t.Errorf("unexpected bundle contents: %+v", bundle)
+	}
+	if bundle.Totals.TotalIncTax != 25.5 || bundle.Totals.Tax != 4.25 {
+		t.Errorf("unexpected totals: %+v", bundle.Totals)
+	}
+	if bundle.Totals.ItemsQuantity != 3 || bundle.Totals.ItemsShipped != 1 || bundle.Totals.ItemsRefunded != 1 {
+		t.Errorf("unexpected item counts: %+v", bundle.Totals)
+	}
+}
+
+func TestLoadOrderBundle_ForeignResourceLink(t *testing.T) {
+	var leaked int32
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&leaked, 1)
+		fmt.Fprint(w, `[]`)
+	}))
+	defer foreign.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stores/test/v2/orders/9/products", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id":1,"order_id":9,"sku":"A","quantity":1}]`)
+	})
+	client := newTestServerClient(t, mux)
+
+	order := Order{ID: 9, Products: URLResource{URL: foreign.URL + "/stores/test/v2/orders/9/products"}}
+	bundle, err := client.V2.LoadOrderBundle(order, OrderBundleOptions{Include: []OrderSubResource{OrderSubResourceProducts}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaked != 0 {
+		t.Error("expected the link to another host not to be followed")
+	}
+	if len(bundle.Products) != 1 {
+		t.Errorf("expected products from the store's API, got %+v", bundle.Products)
+	}
+}
+
+func TestLoadOrderBundle_Include(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stores/test/v2/orders/5/coupons", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	client := newTestServerClient(t, mux)
+
+	_, err := client.V2.LoadOrderBundle(Order{ID: 5}, OrderBundleOptions{Include: []OrderSubResource{OrderSubResourceCoupons}})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,515 @@
+package bigcommerce
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type OrderExportFormat string
+
+const (
+	// OrderExportCSV writes one flat CSV row per order or line item using the export columns.
+	OrderExportCSV OrderExportFormat = "csv"
+	// OrderExportNDJSON writes each order bundle as one JSON object per line, with the
+	// loaded sub-resources nested under "products", "shipping_addresses", "shipments"
+	// and "coupons".
+	OrderExportNDJSON OrderExportFormat = "ndjson"
+	// OrderExportColumnar writes one flat JSON object per order or line item using the
+	// export columns, with numbers and booleans typed according to the column schema
+	// and empty values written as null, ready to load into Parquet or a warehouse table.
+	OrderExportColumnar OrderExportFormat = "columnar"
+)
+
+type OrderExportGranularity string
+
+const (
+	OrderExportPerOrder    OrderExportGranularity = "order"
+	OrderExportPerLineItem OrderExportGranularity = "line_item"
+)
+
+type ExportColumnType string
+
+const (
+	ExportColumnString  ExportColumnType = "string"
+	ExportColumnInteger ExportColumnType = "integer"
+	ExportColumnFloat   ExportColumnType = "float"
+	ExportColumnBoolean ExportColumnType = "boolean"
+)
+
+// OrderExportRow is the data an export column reads from. Product is only set when
+// exporting per line item. ShippingAddress is the line item's address, or the
+// order's first shipping address when exporting per order.
+type OrderExportRow struct {
+	Bundle          *OrderBundle
+	Product         *OrderProduct
+	ShippingAddress *ShippingAddress
+}
+
+type ExportColumn struct {
+	Name  string
+	Type  ExportColumnType
+	Value func(row OrderExportRow) string
+}
+
+// DefaultOrderExportColumns are used when exporting one row per order without columns.
+var DefaultOrderExportColumns = []string{
+	"order.id",
+	"order.date_created",
+	"order.status",
+	"order.customer_id",
+	"billing_address.email",
+	"billing_address.first_name",
+	"billing_address.last_name",
+	"order.currency_code",
+	"order.subtotal_inc_tax",
+	"order.shipping_cost_inc_tax",
+	"order.total_tax",
+	"order.discount_amount",
+	"order.total_inc_tax",
+	"order.items_total",
+	"order.payment_method",
+	"shipping_address.country_iso2",
+	"coupons.codes",
+	"shipments.tracking_numbers",
+}
+
+// DefaultLineItemExportColumns are used when exporting one row per line item without columns.
+var DefaultLineItemExportColumns = []string{
+	"order.id",
+	"order.date_created",
+	"order.status",
+	"order.currency_code",
+	"product.id",
+	"product.product_id",
+	"product.sku",
+	"product.name",
+	"product.quantity",
+	"product.price_inc_tax",
+	"product.total_inc_tax",
+	"product.quantity_refunded",
+	"shipping_address.city",
+	"shipping_address.country_iso2",
+}
+
+// ParseExportColumns builds export columns from a column mapping. Each entry is a
+// source field, optionally preceded by a header and "=", for example
+// "Order ID=order.id" or "product.sku".
+//
+// Sources are the JSON field names of Order ("order."), Order.BillingAddress
+// ("billing_address."), OrderProduct ("product.") and ShippingAddress
+// ("shipping_address."), plus "coupons.codes" and "shipments.tracking_numbers",
+// which join the values of every coupon or shipment with "|".
+func ParseExportColumns(mapping []string) ([]ExportColumn, error) {
+	columns := []ExportColumn{}
+	for _, entry := range mapping {
+		header, source := entry, entry
+		if i := strings.Index(entry, "="); i >= 0 {
+			header, source = strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		}
+
+		column, err := exportColumnFor(source)
+		if err != nil {
+			return nil, err
+		}
+		column.Name = header
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func exportColumnFor(source string) (ExportColumn, error) {
+	switch source {
+	case "coupons.codes":
+		return ExportColumn{Type: ExportColumnString, Value: func(row OrderExportRow) string {
+			codes := []string{}
+			for _, coupon := range row.Bundle.Coupons {
+				codes = append(codes, coupon.Code)
+			}
+			return strings.Join(codes, "|")
+		}}, nil
+	case "shipments.tracking_numbers":
+		return ExportColumn{Type: ExportColumnString, Value: func(row OrderExportRow) string {
+			numbers := []string{}
+			for _, shipment := range row.Bundle.Shipments {
+				if shipment.TrackingNumber != "" {
+					numbers = append(numbers, shipment.TrackingNumber)
+				}
+			}
+			return strings.Join(numbers, "|")
+		}}, nil
+	}
+
+	prefix, field, ok := strings.Cut(source, ".")
+	if !ok {
+		return ExportColumn{}, fmt.Errorf("export column %q must have the form source.field", source)
+	}
+
+	var structType reflect.Type
+	var target func(row OrderExportRow) interface{}
+	switch prefix {
+	case "order":
+		structType = reflect.TypeOf(Order{})
+		target = func(row OrderExportRow) interface{} { return &row.Bundle.Order }
+	case "billing_address":
+		structType = reflect.TypeOf(BillingAddress{})
+		target = func(row OrderExportRow) interface{} { return &row.Bundle.Order.BillingAddress }
+	case "product":
+		structType = reflect.TypeOf(OrderProduct{})
+		target = func(row OrderExportRow) interface{} { return row.Product }
+	case "shipping_address":
+		structType = reflect.TypeOf(ShippingAddress{})
+		target = func(row OrderExportRow) interface{} { return row.ShippingAddress }
+	default:
+		return ExportColumn{}, fmt.Errorf("export column %q has unknown source %q", source, prefix)
+	}
+
+	index, fieldType, ok := fieldByJSONName(structType, field)
+	if !ok {
+		return ExportColumn{}, fmt.Errorf("export column %q: %s has no field %q", source, structType.Name(), field)
+	}
+
+	return ExportColumn{
+		Type: exportColumnType(field, fieldType),
+		Value: func(row OrderExportRow) string {
+			v := reflect.ValueOf(target(row))
+			if v.IsNil() {
+				return ""
+			}
+			return formatExportValue(v.Elem().FieldByIndex(index))
+		},
+	}, nil
+}
+
+func fieldByJSONName(structType reflect.Type, name string) ([]int, reflect.Type, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tag == name {
+			return field.Index, field.Type, true
+		}
+	}
+	return nil, nil, false
+}
+
+// decimalFieldSuffixes identify string fields the V2 API uses for decimal amounts.
+var decimalFieldSuffixes = []string{"_ex_tax", "_inc_tax", "_tax", "_cost", "_amount", "_price", "_discount", "_rate", "weight"}
+
+func exportColumnType(name string, fieldType reflect.Type) ExportColumnType {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return ExportColumnInteger
+	case reflect.Float32, reflect.Float64:
+		return ExportColumnFloat
+	case reflect.Bool:
+		return ExportColumnBoolean
+	case reflect.String:
+		for _, suffix := range decimalFieldSuffixes {
+			if strings.HasSuffix(name, suffix) {
+				return ExportColumnFloat
+			}
+		}
+	}
+	return ExportColumnString
+}
+
+func formatExportValue(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	default:
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return ""
+		}
+		return string(b)
+	}
+}
+
+type OrderExportOptions struct {
+	Format      OrderExportFormat
+	Granularity OrderExportGranularity
+	// Columns selects and names the columns of CSV and columnar exports. Defaults to
+	// DefaultOrderExportColumns or DefaultLineItemExportColumns. Ignored for NDJSON.
+	Columns []ExportColumn
+	// Include lists the sub-resources loaded for each order. Defaults to every sub-resource.
+	Include []OrderSubResource
+	// Concurrency is passed to the order bundle loader. Defaults to 4.
+	Concurrency int
+}
+
+// OrderExporter writes order bundles in one of the export formats.
+type OrderExporter struct {
+	w             io.Writer
+	opts          OrderExportOptions
+	csvWriter     *csv.Writer
+	headerWritten bool
+}
+
+func NewOrderExporter(w io.Writer, opts OrderExportOptions) (*OrderExporter, error) {
+	if opts.Format == "" {
+		opts.Format = OrderExportCSV
+	}
+	if opts.Granularity == "" {
+		opts.Granularity = OrderExportPerOrder
+	}
+
+	switch opts.Format {
+	case OrderExportCSV, OrderExportNDJSON, OrderExportColumnar:
+	default:
+		return nil, fmt.Errorf("unknown order export format %q", opts.Format)
+	}
+	switch opts.Granularity {
+	case OrderExportPerOrder, OrderExportPerLineItem:
+	default:
+		return nil, fmt.Errorf("unknown order export granularity %q", opts.Granularity)
+	}
+
+	if len(opts.Columns) == 0 {
+		defaults := DefaultOrderExportColumns
+		if opts.Granularity == OrderExportPerLineItem {
+			defaults = DefaultLineItemExportColumns
+		}
+		columns, err := ParseExportColumns(defaults)
+		if err != nil {
+			return nil, err
+		}
+		opts.Columns = columns
+	}
+
+	exporter := &OrderExporter{w: w, opts: opts}
+	if opts.Format == OrderExportCSV {
+		exporter.csvWriter = csv.NewWriter(w)
+	}
+	return exporter, nil
+}
+
+// Schema returns the name and type of each column written by CSV and columnar exports.
+func (exporter *OrderExporter) Schema() []ExportColumnSchema {
+	schema := []ExportColumnSchema{}
+	for _, column := range exporter.opts.Columns {
+		schema = append(schema, ExportColumnSchema{Name: column.Name, Type: column.Type})
+	}
+	return schema
+}
+
+type ExportColumnSchema struct {
+	Name string           `json:"name"`
+	Type ExportColumnType `json:"type"`
+}
+
+// Write exports one order bundle, as one row or one row per line item.
+func (exporter *OrderExporter) Write(bundle OrderBundle) error {
+	if exporter.opts.Format == OrderExportNDJSON {
+		return exporter.writeNDJSON(bundle)
+	}
+
+	for _, row := range exporter.rows(&bundle) {
+		var err error
+		if exporter.opts.Format == OrderExportCSV {
+			err = exporter.writeCSVRow(row)
+		} else {
+			err = exporter.writeColumnarRow(row)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export order %d: %w", bundle.Order.ID, err)
+		}
+	}
+	return nil
+}
+
+// Flush writes any buffered CSV data. It must be called once all bundles are written.
+func (exporter *OrderExporter) Flush() error {
+	if exporter.csvWriter == nil {
+		return nil
+	}
+	if !exporter.headerWritten {
+		if err := exporter.writeCSVHeader(); err != nil {
+			return err
+		}
+	}
+	exporter.csvWriter.Flush()
+	return exporter.csvWriter.Error()
+}
+
+func (exporter *OrderExporter) rows(bundle *OrderBundle) []OrderExportRow {
+	if exporter.opts.Granularity == OrderExportPerOrder {
+		row := OrderExportRow{Bundle: bundle}
+		if len(bundle.ShippingAddresses) > 0 {
+			row.ShippingAddress = &bundle.ShippingAddresses[0]
+		}
+		return []OrderExportRow{row}
+	}
+
+	rows := []OrderExportRow{}
+	for i := range bundle.Products {
+		row := OrderExportRow{Bundle: bundle, Product: &bundle.Products[i]}
+		for j := range bundle.ShippingAddresses {
+			if bundle.ShippingAddresses[j].ID == bundle.Products[i].OrderAddressID {
+				row.ShippingAddress = &bundle.ShippingAddresses[j]
+				break
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (exporter *OrderExporter) writeCSVHeader() error {
+	header := []string{}
+	for _, column := range exporter.opts.Columns {
+		header = append(header, column.Name)
+	}
+	exporter.headerWritten = true
+	return exporter.csvWriter.Write(header)
+}
+
+func (exporter *OrderExporter) writeCSVRow(row OrderExportRow) error {
+	if !exporter.headerWritten {
+		if err := exporter.writeCSVHeader(); err != nil {
+			return err
+		}
+	}
+
+	record := []string{}
+	for _, column := range exporter.opts.Columns {
+		record = append(record, column.Value(row))
+	}
+	return exporter.csvWriter.Write(record)
+}
+
+func (exporter *OrderExporter) writeColumnarRow(row OrderExportRow) error {
+	// Keys are written in column order, which encoding/json does not do for maps.
+	var b strings.Builder
+	b.WriteString("{")
+	for i, column := range exporter.opts.Columns {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		key, _ := json.Marshal(column.Name)
+		b.Write(key)
+		b.WriteString(":")
+
+		value, err := json.Marshal(typedExportValue(column.Type, column.Value(row)))
+		if err != nil {
+			return err
+		}
+		b.Write(value)
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(exporter.w, b.String())
+	return err
+}
+
+func typedExportValue(columnType ExportColumnType, value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	switch columnType {
+	case ExportColumnInteger:
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i
+		}
+		return nil
+	case ExportColumnFloat:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+		return nil
+	case ExportColumnBoolean:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+		return nil
+	}
+	return value
+}
+
+func (exporter *OrderExporter) writeNDJSON(bundle OrderBundle) error {
+	type exportedOrder struct {
+		Order
+		ProductList         []OrderProduct    `json:"products,omitempty"`
+		ShippingAddressList []ShippingAddress `json:"shipping_addresses,omitempty"`
+		Shipments           []OrderShipment   `json:"shipments,omitempty"`
+		CouponList          []OrderCoupon     `json:"coupons,omitempty"`
+	}
+
+	b, err := json.Marshal(exportedOrder{
+		Order:               bundle.Order,
+		ProductList:         bundle.Products,
+		ShippingAddressList: bundle.ShippingAddresses,
+		Shipments:           bundle.Shipments,
+		CouponList:          bundle.Coupons,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export order %d: %w", bundle.Order.ID, err)
+	}
+
+	b = append(b, '\n')
+	_, err = exporter.w.Write(b)
+	return err
+}
+
+// ExportOrders streams every order matching params to w, loading each page of
+// orders' sub-resources concurrently. It returns the number of orders exported.
+func (client *V2Client) ExportOrders(w io.Writer, params OrderQueryParams, opts OrderExportOptions) (int, error) {
+	exporter, err := NewOrderExporter(w, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	include := opts.Include
+	if len(include) == 0 {
+		include = AllOrderSubResources
+	}
+	if opts.Granularity == OrderExportPerLineItem && !(OrderBundleOptions{Include: include}).includes(OrderSubResourceProducts) {
+		include = append(append([]OrderSubResource{}, include...), OrderSubResourceProducts)
+	}
+	bundleOpts := OrderBundleOptions{Include: include, Concurrency: opts.Concurrency}
+
+	exported := 0
+	err = client.ForEachOrderPage(params, func(orders []Order) error {
+		bundles, err := client.LoadOrderBundles(orders, bundleOpts)
+		if err != nil {
+			return err
+		}
+		for _, bundle := range bundles {
+			if err := exporter.Write(bundle); err != nil {
+				return err
+			}
+			exported++
+		}
+		return nil
+	})
+	if err != nil {
+		return exported, fmt.Errorf("failed to export orders: %w", err)
+	}
+
+	if err := exporter.Flush(); err != nil {
+		return exported, fmt.Errorf("failed to export orders: %w", err)
+	}
+
+	return exported, nil
+}
@@ -0,0 +1,111 @@
+package bigcommerce
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testExportBundle() OrderBundle {
+	return OrderBundle{
+		Order: Order{
+			ID:             100,
+			Status:         "Shipped",
+			TotalIncTax:    "42.5000",
+			ItemsTotal:     3,
+			BillingAddress: BillingAddress{Email: "jane@example.com"},
+		},
+		Products: []OrderProduct{
+			{ID: 1, SKU: "A", Quantity: 2, OrderAddressID: 8},
+			{ID: 2, SKU: "B", Quantity: 1, OrderAddressID: 9},
+		},
+		ShippingAddresses: []ShippingAddress{{ID: 8, City: "Cork"}, {ID: 9, City: "Galway"}},
+		Shipments:         []OrderShipment{{TrackingNumber: "T1"}, {TrackingNumber: "T2"}},
+		Coupons:           []OrderCoupon{{Code: "SPRING"}},
+	}
+}
+
+func TestOrderExporter_CSVPerLineItem(t *testing.T) {
+	columns, err := ParseExportColumns([]string{"Order=order.id", "billing_address.email", "product.sku", "product.quantity", "City=shipping_address.city"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	exporter, err := NewOrderExporter(&buf, OrderExportOptions{Granularity: OrderExportPerLineItem, Columns: columns})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.Write(testExportBundle()); err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "Order,billing_address.email,product.sku,product.quantity,City\n" +
+		"100,jane@example.com,A,2,Cork\n" +
+		"100,jane@example.com,B,1,Galway\n"
+	if buf.String() != expected {
+		t.Errorf("expected:\n%s\nreceived:\n%s", expected, buf.String())
+	}
+}
+
+func TestOrderExporter_Columnar(t *testing.T) {
+	columns, err := ParseExportColumns([]string{"order.id", "order.total_inc_tax", "order.is_deleted", "order.date_shipped", "coupons.codes", "shipments.tracking_numbers"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	exporter, err := NewOrderExporter(&buf, OrderExportOptions{Format: OrderExportColumnar, Columns: columns})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.Write(testExportBundle()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"order.id":100,"order.total_inc_tax":42.5,"order.is_deleted":false,"order.date_shipped":null,"coupons.codes":"SPRING","shipments.tracking_numbers":"T1|T2"}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("expected %s but received %s instead", expected, buf.String())
+	}
+
+	schema := exporter.Schema()
+	if schema[1].Type != ExportColumnFloat || schema[0].Type != ExportColumnInteger || schema[2].Type != ExportColumnBoolean {
+		t.Errorf("unexpected schema: %+v", schema)
+	}
+}
+
+func TestOrderExporter_NDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	exporter, err := NewOrderExporter(&buf, OrderExportOptions{Format: OrderExportNDJSON})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.Write(testExportBundle()); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		ID       int            `json:"id"`
+		Products []OrderProduct `json:"products"`
+		Coupons  []OrderCoupon  `json:"coupons"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(buf.String())), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ID != 100 || len(decoded.Products) != 2 || len(decoded.Coupons) != 1 {
+		t.Errorf("unexpected NDJSON record: %s", buf.String())
+	}
+}
+
+func TestParseExportColumns_UnknownField(t *testing.T) {
+	if _, err := ParseExportColumns([]string{"order.nope"}); err == nil {
+		t.Error("expected error for unknown field")
+	}
+	if _, err := ParseExportColumns([]string{"customer.id"}); err == nil {
+		t.Error("expected error for unknown source")
+	}
+}
@@ -0,0 +1,65 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type OrderMessageCustomer struct {
+	ID          int    `json:"id"`
+	Email       string `json:"email"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	Company     string `json:"company"`
+	Phone       string `json:"phone"`
+	DateCreated string `json:"date_created"`
+}
+
+// OrderMessage is a message exchanged between the customer and staff about an order
+type OrderMessage struct {
+	ID          int                  `json:"id"`
+	OrderID     int                  `json:"order_id"`
+	StaffID     int                  `json:"staff_id"`
+	CustomerID  int                  `json:"customer_id"`
+	Type        string               `json:"type"`
+	Subject     string               `json:"subject"`
+	Message     string               `json:"message"`
+	Status      string               `json:"status"`
+	IsFlagged   bool                 `json:"is_flagged"`
+	DateCreated string               `json:"date_created"`
+	Customer    OrderMessageCustomer `json:"customer"`
+}
+
+// IsFromCustomer reports whether the message was written by the customer rather than staff.
+func (orderMessage *OrderMessage) IsFromCustomer() bool {
+	return orderMessage.Type == "customer"
+}
+
+type OrderMessageQueryParams struct {
+	MinID          int    `url:"min_id,omitempty"`
+	MaxID          int    `url:"max_id,omitempty"`
+	CustomerID     int    `url:"customer_id,omitempty"`
+	MinDateCreated string `url:"min_date_created,omitempty"`
+	MaxDateCreated string `url:"max_date_created,omitempty"`
+	// IsFlagged is a pointer so that unflagged messages can be asked for.
+	IsFlagged *bool  `url:"is_flagged,omitempty"`
+	Status    string `url:"status,omitempty"`
+	Page      int    `url:"page,omitempty"`
+	Limit     int    `url:"limit,omitempty"`
+}
+
+func (client *V2Client) GetOrderMessages(orderID int, params OrderMessageQueryParams) ([]OrderMessage, error) {
+	var messages []OrderMessage
+
+	path, err := urlWithQueryParams(client.constructURL("orders", strconv.Itoa(orderID), "messages"), params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct URL for GetOrderMessages (order ID: %d): %w", orderID, err)
+	}
+
+	// V2 responds with a bare array, or with no content when there are no messages.
+	if err := client.Get(path, &messages); err != nil {
+		return nil, fmt.Errorf("failed to get messages for order %d: %w", orderID, err)
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,43 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetOrderMessages(t *testing.T) {
+	client := newTestServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/stores/test/v2/orders/100/messages":
+			if r.URL.Query().Get("status") != "unread" || r.URL.Query().Get("is_flagged") != "false" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			json.NewEncoder(w).Encode([]OrderMessage{
+				{ID: 1, OrderID: 100, Type: "customer", Message: "Where is my order?"},
+				{ID: 2, OrderID: 100, StaffID: 3, Type: "staff", Message: "It shipped today."},
+			})
+		case "/stores/test/v2/orders/101/messages":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	unflagged := false
+	messages, err := client.V2.GetOrderMessages(100, OrderMessageQueryParams{Status: "unread", IsFlagged: &unflagged})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 2 || !messages[0].IsFromCustomer() || messages[1].IsFromCustomer() {
+		t.Fatalf("unexpected messages %+v", messages)
+	}
+
+	messages, err = client.V2.GetOrderMessages(101, OrderMessageQueryParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 0 {
+		t.Fatalf("expected no messages, got %+v", messages)
+	}
+}
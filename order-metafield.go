@@ -0,0 +1,253 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// MetafieldPermissionSet controls which API clients and storefronts can read or write a metafield
+type MetafieldPermissionSet string
+
+const (
+	MetafieldPermissionAppOnly          MetafieldPermissionSet = "app_only"
+	MetafieldPermissionRead             MetafieldPermissionSet = "read"
+	MetafieldPermissionWrite            MetafieldPermissionSet = "write"
+	MetafieldPermissionReadAndSFAccess  MetafieldPermissionSet = "read_and_sf_access"
+	MetafieldPermissionWriteAndSFAccess MetafieldPermissionSet = "write_and_sf_access"
+)
+
+type Metafield struct {
+	ID            int                    `json:"id"`
+	Key           string                 `json:"key"`
+	Value         string                 `json:"value"`
+	Namespace     string                 `json:"namespace"`
+	PermissionSet MetafieldPermissionSet `json:"permission_set"`
+	ResourceType  string                 `json:"resource_type"`
+	ResourceID    int                    `json:"resource_id"`
+	Description   string                 `json:"description"`
+	DateCreated   string                 `json:"date_created"`
+	DateModified  string                 `json:"date_modified"`
+	OwnerClientID string                 `json:"owner_client_id"`
+}
+
+type MetafieldQueryParams struct {
+	Page      int      `url:"page,omitempty"`
+	Limit     int      `url:"limit,omitempty"`
+	Key       string   `url:"key,omitempty"`
+	KeyIn     []string `url:"key:in,omitempty,comma"`
+	Namespace string   `url:"namespace,omitempty"`
+	Direction string   `url:"direction,omitempty"`
+}
+
+type CreateMetafieldParams struct {
+	Key           string                 `json:"key"`
+	Value         string                 `json:"value"`
+	Namespace     string                 `json:"namespace"`
+	PermissionSet MetafieldPermissionSet `json:"permission_set"`
+	Description   string                 `json:"description,omitempty"`
+	// ResourceID is only sent by the batch endpoints, where it identifies the order.
+	ResourceID int `json:"resource_id,omitempty"`
+}
+
+type UpdateMetafieldParams struct {
+	Key string `json:"key,omitempty"`
+	// Value is a pointer so a metafield can be set to an empty value.
+	Value         *string                `json:"value,omitempty"`
+	Namespace     string                 `json:"namespace,omitempty"`
+	PermissionSet MetafieldPermissionSet `json:"permission_set,omitempty"`
+	Description   string                 `json:"description,omitempty"`
+	// ID is only sent by the batch endpoint, where it identifies the metafield.
+	ID int `json:"id,omitempty"`
+}
+
+// MetafieldBatchError describes a metafield that could not be written by a batch request
+type MetafieldBatchError struct {
+	Status int               `json:"status"`
+	Title  string            `json:"title"`
+	Type   string            `json:"type"`
+	Errors map[string]string `json:"errors"`
+}
+
+type MetafieldBatchMeta struct {
+	Total   int `json:"total"`
+	Success int `json:"success"`
+	Failed  int `json:"failed"`
+}
+
+// MetafieldBatchResult is the outcome of a batch metafield request. Batch requests
+// can partially succeed, so Errors should be checked even when no error is returned.
+type MetafieldBatchResult struct {
+	Data   []Metafield           `json:"data"`
+	Errors []MetafieldBatchError `json:"errors"`
+	Meta   MetafieldBatchMeta    `json:"meta"`
+}
+
+func (client *V3Client) GetOrderMetafields(orderID int, params MetafieldQueryParams) ([]Metafield, MetaData, error) {
+	type ResponseObject struct {
+		Data []Metafield `json:"data"`
+		Meta MetaData    `json:"meta"`
+	}
+	var response ResponseObject
+
+	path, err := urlWithQueryParams(client.constructURL("orders", strconv.Itoa(orderID), "metafields"), params)
+	if err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to construct URL for GetOrderMetafields (order ID: %d): %w", orderID, err)
+	}
+
+	if err := client.Get(path, &response); err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to get metafields for order %d: %w", orderID, err)
+	}
+
+	return response.Data, response.Meta, nil
+}
+
+func (client *V3Client) GetOrderMetafield(orderID, metafieldID int) (Metafield, error) {
+	type ResponseObject struct {
+		Data Metafield `json:"data"`
+		Meta MetaData  `json:"meta"`
+	}
+	var response ResponseObject
+
+	path := client.constructURL("orders", strconv.Itoa(orderID), "metafields", strconv.Itoa(metafieldID))
+
+	if err := client.Get(path, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to get metafield %d for order %d: %w", metafieldID, orderID, err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) CreateOrderMetafield(orderID int, params CreateMetafieldParams) (Metafield, error) {
+	type ResponseObject struct {
+		Data Metafield `json:"data"`
+		Meta MetaData  `json:"meta"`
+	}
+	var response ResponseObject
+
+	params.ResourceID = 0
+	path := client.constructURL("orders", strconv.Itoa(orderID), "metafields")
+
+	if err := client.Post(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to create metafield for order %d: %w", orderID, err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) UpdateOrderMetafield(orderID, metafieldID int, params UpdateMetafieldParams) (Metafield, error) {
+	type ResponseObject struct {
+		Data Metafield `json:"data"`
+		Meta MetaData  `json:"meta"`
+	}
+	var response ResponseObject
+
+	params.ID = 0
+	path := client.constructURL("orders", strconv.Itoa(orderID), "metafields", strconv.Itoa(metafieldID))
+
+	if err := client.Put(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to update metafield %d for order %d: %w", metafieldID, orderID, err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) DeleteOrderMetafield(orderID, metafieldID int) error {
+	path := client.constructURL("orders", strconv.Itoa(orderID), "metafields", strconv.Itoa(metafieldID))
+
+	if err := client.Delete(path, nil); err != nil {
+		return fmt.Errorf("failed to delete metafield %d for order %d: %w", metafieldID, orderID, err)
+	}
+
+	return nil
+}
+
+// GetAllOrderMetafields lists metafields across all orders. The ResourceID of each
+// metafield is the order it belongs to.
+func (client *V3Client) GetAllOrderMetafields(params MetafieldQueryParams) ([]Metafield, error) {
+	type ResponseObject struct {
+		Data []Metafield `json:"data"`
+		Meta MetaData    `json:"meta"`
+	}
+
+	var metafields []Metafield
+	params.Page = 1
+	if params.Limit < 1 {
+		params.Limit = 250
+	}
+
+	for {
+		var response ResponseObject
+
+		path, err := urlWithQueryParams(client.constructURL("orders", "metafields"), params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to construct URL for GetAllOrderMetafields: %w", err)
+		}
+
+		if err := client.Get(path, &response); err != nil {
+			return nil, fmt.Errorf("failed to get order metafields at page %d: %w", params.Page, err)
+		}
+
+		metafields = append(metafields, response.Data...)
+
+		if response.Meta.Pagination.CurrentPage >= response.Meta.Pagination.TotalPages {
+			break
+		}
+
+		params.Page++
+	}
+
+	return metafields, nil
+}
+
+// CreateOrderMetafields creates metafields on multiple orders in one request.
+// ResourceID must be set to the order ID on every item.
+func (client *V3Client) CreateOrderMetafields(params []CreateMetafieldParams) (MetafieldBatchResult, error) {
+	var response MetafieldBatchResult
+
+	for i := range params {
+		if params[i].ResourceID <= 0 {
+			return response, fmt.Errorf("metafield %d (%s) is missing the order ID in ResourceID", i, params[i].Key)
+		}
+	}
+
+	path := client.constructURL("orders", "metafields")
+
+	if err := client.Post(path, params, &response); err != nil {
+		return response, fmt.Errorf("failed to batch create order metafields: %w", err)
+	}
+
+	return response, nil
+}
+
+// UpdateOrderMetafields updates multiple order metafields in one request.
+// ID must be set to the metafield ID on every item.
+func (client *V3Client) UpdateOrderMetafields(params []UpdateMetafieldParams) (MetafieldBatchResult, error) {
+	var response MetafieldBatchResult
+
+	for i := range params {
+		if params[i].ID <= 0 {
+			return response, fmt.Errorf("metafield update %d is missing the metafield ID", i)
+		}
+	}
+
+	path := client.constructURL("orders", "metafields")
+
+	if err := client.Put(path, params, &response); err != nil {
+		return response, fmt.Errorf("failed to batch update order metafields: %w", err)
+	}
+
+	return response, nil
+}
+
+// DeleteOrderMetafields deletes multiple order metafields by ID in one request.
+func (client *V3Client) DeleteOrderMetafields(metafieldIDs []int) (MetafieldBatchResult, error) {
+	var response MetafieldBatchResult
+
+	path := client.constructURL("orders", "metafields")
+
+	if err := client.marshalJSONandRequestAndDecode("DELETE", path.String(), metafieldIDs, &response); err != nil {
+		return response, fmt.Errorf("failed to batch delete order metafields: %w", err)
+	}
+
+	return response, nil
+}
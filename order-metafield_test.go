@@ -0,0 +1,81 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestOrderMetafields(t *testing.T) {
+	var bodies []string
+	client := newTestServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, r.Method+" "+r.URL.Path+" "+string(b))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/stores/test/v3/orders/metafields":
+			page := r.URL.Query().Get("page")
+			if r.URL.Query().Get("key:in") != "erp_id,erp_status" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			id := 1
+			if page == "2" {
+				id = 2
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []Metafield{{ID: id, Key: "erp_id", ResourceID: 100 + id}},
+				"meta": map[string]interface{}{"pagination": map[string]interface{}{"current_page": id, "total_pages": 2}},
+			})
+		case r.Method == http.MethodPut && r.URL.Path == "/stores/test/v3/orders/100/metafields/7":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": Metafield{ID: 7, Key: "erp_id"}})
+		case r.URL.Path == "/stores/test/v3/orders/metafields":
+			json.NewEncoder(w).Encode(MetafieldBatchResult{
+				Data:   []Metafield{{ID: 8}},
+				Errors: []MetafieldBatchError{{Status: 422, Title: "duplicate key"}},
+				Meta:   MetafieldBatchMeta{Total: 2, Success: 1, Failed: 1},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	all, err := client.V3.GetAllOrderMetafields(MetafieldQueryParams{KeyIn: []string{"erp_id", "erp_status"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all[1].ResourceID != 102 {
+		t.Fatalf("expected metafields from both pages, got %+v", all)
+	}
+
+	empty := ""
+	if _, err := client.V3.UpdateOrderMetafield(100, 7, UpdateMetafieldParams{Value: &empty, ID: 7}); err != nil {
+		t.Fatal(err)
+	}
+	if got := bodies[len(bodies)-1]; !strings.HasSuffix(got, `{"value":""}`) {
+		t.Errorf("expected an empty value to be sent and the ID left out, got %s", got)
+	}
+
+	result, err := client.V3.CreateOrderMetafields([]CreateMetafieldParams{
+		{Key: "erp_id", Value: "A1", Namespace: "erp", PermissionSet: MetafieldPermissionAppOnly, ResourceID: 100},
+		{Key: "erp_id", Value: "A2", Namespace: "erp", PermissionSet: MetafieldPermissionAppOnly, ResourceID: 101},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Meta.Failed != 1 || len(result.Errors) != 1 {
+		t.Errorf("expected the partial failure to be reported, got %+v", result)
+	}
+
+	requests := len(bodies)
+	if _, err := client.V3.CreateOrderMetafields([]CreateMetafieldParams{{Key: "erp_id"}}); err == nil {
+		t.Error("expected an error for a batch item without an order ID")
+	}
+	if _, err := client.V3.UpdateOrderMetafields([]UpdateMetafieldParams{{Key: "erp_id"}}); err == nil {
+		t.Error("expected an error for a batch item without a metafield ID")
+	}
+	if len(bodies) != requests {
+		t.Error("expected invalid batches not to be sent")
+	}
+}
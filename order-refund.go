@@ -0,0 +1,264 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// RefundItemType identifies what part of an order a refund item applies to
+type RefundItemType string
+
+const (
+	RefundItemTypeProduct      RefundItemType = "PRODUCT"
+	RefundItemTypeGiftWrapping RefundItemType = "GIFT_WRAPPING"
+	RefundItemTypeShipping     RefundItemType = "SHIPPING"
+	RefundItemTypeHandling     RefundItemType = "HANDLING"
+	RefundItemTypeOrder        RefundItemType = "ORDER"
+)
+
+// RefundItem is a single line of a refund quote or refund request.
+//
+// PRODUCT items are refunded by quantity and reference the order product ID.
+// SHIPPING and HANDLING items are refunded by amount and reference the order
+// address ID. ORDER items are refunded by amount and reference the order ID.
+type RefundItem struct {
+	ItemType RefundItemType `json:"item_type"`
+	ItemID   int            `json:"item_id"`
+	Quantity int            `json:"quantity,omitempty"`
+	Amount   float64        `json:"amount,omitempty"`
+	Reason   string         `json:"reason,omitempty"`
+}
+
+// RefundItem builds a PRODUCT refund item for quantity units of this order line.
+// It returns an error if quantity exceeds the units that have not already been refunded.
+func (orderProduct *OrderProduct) RefundItem(quantity int, reason string) (RefundItem, error) {
+	refundable := orderProduct.Quantity - orderProduct.QuantityRefunded
+	if quantity <= 0 {
+		return RefundItem{}, fmt.Errorf("refund quantity for order product %d must be positive", orderProduct.ID)
+	}
+	if quantity > refundable {
+		return RefundItem{}, fmt.Errorf("cannot refund %d units of order product %d: only %d refundable", quantity, orderProduct.ID, refundable)
+	}
+
+	return RefundItem{
+		ItemType: RefundItemTypeProduct,
+		ItemID:   orderProduct.ID,
+		Quantity: quantity,
+		Reason:   reason,
+	}, nil
+}
+
+// RefundItems builds PRODUCT refund items covering every unit of each line that has
+// not already been refunded. Fully refunded lines are skipped.
+func RefundItems(orderProducts []OrderProduct, reason string) []RefundItem {
+	items := []RefundItem{}
+	for i := range orderProducts {
+		refundable := orderProducts[i].Quantity - orderProducts[i].QuantityRefunded
+		if refundable <= 0 {
+			continue
+		}
+		items = append(items, RefundItem{
+			ItemType: RefundItemTypeProduct,
+			ItemID:   orderProducts[i].ID,
+			Quantity: refundable,
+			Reason:   reason,
+		})
+	}
+	return items
+}
+
+// ShippingRefundItem builds a SHIPPING refund item for the given order address.
+func ShippingRefundItem(orderAddressID int, amount float64, reason string) RefundItem {
+	return RefundItem{
+		ItemType: RefundItemTypeShipping,
+		ItemID:   orderAddressID,
+		Amount:   amount,
+		Reason:   reason,
+	}
+}
+
+// OrderAmountRefundItem builds an ORDER refund item for an arbitrary amount.
+func OrderAmountRefundItem(orderID int, amount float64, reason string) RefundItem {
+	return RefundItem{
+		ItemType: RefundItemTypeOrder,
+		ItemID:   orderID,
+		Amount:   amount,
+		Reason:   reason,
+	}
+}
+
+type RefundQuoteParams struct {
+	Items []RefundItem `json:"items"`
+}
+
+// RefundMethod is one payment provider's share of a refund option
+type RefundMethod struct {
+	ProviderID          string  `json:"provider_id"`
+	ProviderDescription string  `json:"provider_description"`
+	Amount              float64 `json:"amount"`
+	Offline             bool    `json:"offline"`
+	OfflineProvider     bool    `json:"offline_provider"`
+	OfflineReason       string  `json:"offline_reason"`
+}
+
+type RefundQuote struct {
+	OrderID              int              `json:"order_id"`
+	TotalRefundAmount    float64          `json:"total_refund_amount"`
+	TotalRefundTaxAmount float64          `json:"total_refund_tax_amount"`
+	Rounding             float64          `json:"rounding"`
+	Adjustment           float64          `json:"adjustment"`
+	TaxInclusive         bool             `json:"tax_inclusive"`
+	RefundMethods        [][]RefundMethod `json:"refund_methods"`
+}
+
+// Payments converts the refund option at index into the payments of a refund request.
+// Each option in RefundMethods is a complete way of paying out the quoted total.
+func (quote *RefundQuote) Payments(index int) ([]RefundPayment, error) {
+	if index < 0 || index >= len(quote.RefundMethods) {
+		return nil, fmt.Errorf("refund quote for order %d has no refund method at index %d", quote.OrderID, index)
+	}
+
+	payments := []RefundPayment{}
+	for _, method := range quote.RefundMethods[index] {
+		payments = append(payments, RefundPayment{
+			ProviderID: method.ProviderID,
+			Amount:     method.Amount,
+			Offline:    method.Offline,
+		})
+	}
+	return payments, nil
+}
+
+type RefundPayment struct {
+	ID              int     `json:"id,omitempty"`
+	ProviderID      string  `json:"provider_id"`
+	Amount          float64 `json:"amount"`
+	Offline         bool    `json:"offline"`
+	IsDeclined      bool    `json:"is_declined,omitempty"`
+	DeclinedMessage string  `json:"declined_message,omitempty"`
+}
+
+type RefundMerchantOverride struct {
+	TotalAmount float64 `json:"total_amount"`
+	TotalTax    float64 `json:"total_tax"`
+}
+
+type CreateRefundParams struct {
+	Items                      []RefundItem            `json:"items"`
+	Payments                   []RefundPayment         `json:"payments"`
+	MerchantCalculatedOverride *RefundMerchantOverride `json:"merchant_calculated_override,omitempty"`
+}
+
+type RefundedItem struct {
+	ItemType        RefundItemType `json:"item_type"`
+	ItemID          int            `json:"item_id"`
+	Quantity        int            `json:"quantity"`
+	RequestedAmount *float64       `json:"requested_amount"`
+	Reason          string         `json:"reason"`
+}
+
+type Refund struct {
+	ID                         int             `json:"id"`
+	OrderID                    int             `json:"order_id"`
+	UserID                     int             `json:"user_id"`
+	Created                    string          `json:"created"`
+	Reason                     string          `json:"reason"`
+	TotalAmount                float64         `json:"total_amount"`
+	TotalTax                   float64         `json:"total_tax"`
+	UsesMerchantOverrideValues bool            `json:"uses_merchant_override_values"`
+	Payments                   []RefundPayment `json:"payments"`
+	Items                      []RefundedItem  `json:"items"`
+}
+
+type RefundQueryParams struct {
+	OrderIDIn  []int  `url:"order_id:in,omitempty,comma"`
+	IDIn       []int  `url:"id:in,omitempty,comma"`
+	CreatedMin string `url:"created:min,omitempty"`
+	CreatedMax string `url:"created:max,omitempty"`
+	Page       int    `url:"page,omitempty"`
+	Limit      int    `url:"limit,omitempty"`
+}
+
+// CreateRefundQuote asks BigCommerce to calculate the refund due for the given items,
+// including tax, and the payment providers the amount can be refunded to.
+func (client *V3Client) CreateRefundQuote(orderID int, params RefundQuoteParams) (RefundQuote, error) {
+	type ResponseObject struct {
+		Data RefundQuote `json:"data"`
+		Meta MetaData    `json:"meta"`
+	}
+	var response ResponseObject
+
+	if len(params.Items) < 1 {
+		return response.Data, fmt.Errorf("refund quote for order %d requires at least one item", orderID)
+	}
+
+	path := client.constructURL("orders", strconv.Itoa(orderID), "payment_actions", "refund_quotes")
+
+	if err := client.Post(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to create refund quote for order %d: %w", orderID, err)
+	}
+
+	return response.Data, nil
+}
+
+// CreateRefund executes a refund against an order. The payments should normally be
+// taken from a refund quote for the same items, see RefundQuote.Payments.
+func (client *V3Client) CreateRefund(orderID int, params CreateRefundParams) (Refund, error) {
+	type ResponseObject struct {
+		Data Refund   `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	if len(params.Items) < 1 {
+		return response.Data, fmt.Errorf("refund for order %d requires at least one item", orderID)
+	}
+	if len(params.Payments) < 1 {
+		return response.Data, fmt.Errorf("refund for order %d requires at least one payment", orderID)
+	}
+
+	path := client.constructURL("orders", strconv.Itoa(orderID), "payment_actions", "refunds")
+
+	if err := client.Post(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to create refund for order %d: %w", orderID, err)
+	}
+
+	return response.Data, nil
+}
+
+// GetOrderRefunds lists the refunds that have been made against an order.
+func (client *V3Client) GetOrderRefunds(orderID int) ([]Refund, error) {
+	type ResponseObject struct {
+		Data []Refund `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	path := client.constructURL("orders", strconv.Itoa(orderID), "payment_actions", "refunds")
+
+	if err := client.Get(path, &response); err != nil {
+		return nil, fmt.Errorf("failed to get refunds for order %d: %w", orderID, err)
+	}
+
+	return response.Data, nil
+}
+
+// GetRefunds lists refunds across all orders.
+func (client *V3Client) GetRefunds(params RefundQueryParams) ([]Refund, MetaData, error) {
+	type ResponseObject struct {
+		Data []Refund `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	path, err := urlWithQueryParams(client.constructURL("orders", "payment_actions", "refunds"), params)
+	if err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to construct URL for GetRefunds: %w", err)
+	}
+
+	if err := client.Get(path, &response); err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to get refunds: %w", err)
+	}
+
+	return response.Data, response.Meta, nil
+}
@@ -0,0 +1,66 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOrderProduct_RefundItem(t *testing.T) {
+	orderProduct := OrderProduct{ID: 15, Quantity: 3, QuantityRefunded: 1}
+
+	item, err := orderProduct.RefundItem(2, "damaged")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if item.ItemType != RefundItemTypeProduct || item.ItemID != 15 || item.Quantity != 2 {
+		t.Errorf("unexpected refund item: %+v", item)
+	}
+
+	if _, err := orderProduct.RefundItem(3, "damaged"); err == nil {
+		t.Error("expected error when refunding more units than remain")
+	}
+}
+
+func TestRefundItems(t *testing.T) {
+	orderProducts := []OrderProduct{
+		{ID: 1, Quantity: 2},
+		{ID: 2, Quantity: 1, QuantityRefunded: 1},
+	}
+
+	items := RefundItems(orderProducts, "")
+	if len(items) != 1 {
+		t.Fatalf("expected 1 refund item, got %d", len(items))
+	}
+
+	b, err := json.Marshal(RefundQuoteParams{Items: items})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"items":[{"item_type":"PRODUCT","item_id":1,"quantity":2}]}`
+	if string(b) != expected {
+		t.Errorf("expected %s but received %s instead", expected, string(b))
+	}
+}
+
+func TestRefundQuote_Payments(t *testing.T) {
+	quote := RefundQuote{
+		OrderID: 100,
+		RefundMethods: [][]RefundMethod{
+			{{ProviderID: "storecredit", Amount: 5}, {ProviderID: "braintree", Amount: 10.5}},
+		},
+	}
+
+	payments, err := quote.Payments(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(payments) != 2 || payments[1].ProviderID != "braintree" || payments[1].Amount != 10.5 {
+		t.Errorf("unexpected payments: %+v", payments)
+	}
+
+	if _, err := quote.Payments(1); err == nil {
+		t.Error("expected error for missing refund method")
+	}
+}
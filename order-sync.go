@@ -0,0 +1,386 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// OrderSyncEventType describes why an order was emitted by an OrderSyncer
+type OrderSyncEventType string
+
+const (
+	OrderSyncEventCreated OrderSyncEventType = "created"
+	OrderSyncEventUpdated OrderSyncEventType = "updated"
+	OrderSyncEventDeleted OrderSyncEventType = "deleted"
+)
+
+type OrderSyncEvent struct {
+	Type  OrderSyncEventType
+	Order Order
+}
+
+// OrderSyncWatermark is the position of a sync pass. BigCommerce reports
+// date_modified to the second, so the IDs of orders already emitted within the
+// watermark second are kept to break ties without re-emitting or skipping orders.
+type OrderSyncWatermark struct {
+	DateModified time.Time `json:"date_modified"`
+	LastID       int       `json:"last_id"`
+	IDs          []int     `json:"ids"`
+}
+
+func (watermark *OrderSyncWatermark) seen(orderID int, modified time.Time) bool {
+	if modified.Before(watermark.DateModified) {
+		return true
+	}
+	if modified.After(watermark.DateModified) {
+		return false
+	}
+	for _, id := range watermark.IDs {
+		if id == orderID {
+			return true
+		}
+	}
+	return false
+}
+
+func (watermark *OrderSyncWatermark) advance(orderID int, modified time.Time) {
+	if modified.After(watermark.DateModified) {
+		watermark.DateModified = modified
+		watermark.IDs = nil
+	}
+	watermark.IDs = append(watermark.IDs, orderID)
+	watermark.LastID = orderID
+}
+
+// OrderSyncCursor holds separate watermarks for live and deleted orders, because
+// BigCommerce only returns deleted orders when they are requested explicitly.
+type OrderSyncCursor struct {
+	Orders  OrderSyncWatermark `json:"orders"`
+	Deleted OrderSyncWatermark `json:"deleted"`
+}
+
+// CheckpointStore persists an OrderSyncer's cursor between runs. Load must return a
+// zero cursor, not an error, when nothing has been saved yet.
+type CheckpointStore interface {
+	Load() (OrderSyncCursor, error)
+	Save(cursor OrderSyncCursor) error
+}
+
+// FileCheckpointStore stores the cursor as JSON in a file. Writes go to a temporary
+// file that is renamed into place so a crash never leaves a truncated checkpoint.
+type FileCheckpointStore struct {
+	Path string
+}
+
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{Path: path}
+}
+
+func (store *FileCheckpointStore) Load() (OrderSyncCursor, error) {
+	var cursor OrderSyncCursor
+
+	b, err := os.ReadFile(store.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cursor, nil
+	}
+	if err != nil {
+		return cursor, fmt.Errorf("failed to read checkpoint %s: %w", store.Path, err)
+	}
+
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return cursor, fmt.Errorf("failed to decode checkpoint %s: %w", store.Path, err)
+	}
+
+	return cursor, nil
+}
+
+func (store *FileCheckpointStore) Save(cursor OrderSyncCursor) error {
+	b, err := json.MarshalIndent(cursor, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoint: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(store.Path), filepath.Base(store.Path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), store.Path); err != nil {
+		return fmt.Errorf("failed to replace checkpoint %s: %w", store.Path, err)
+	}
+
+	return nil
+}
+
+type OrderSyncOptions struct {
+	// OnCreated, OnUpdated and OnDeleted receive the changed orders in date_modified
+	// order. Returning an error stops the sync; progress up to the previous order is
+	// saved and the failed order is emitted again on the next run.
+	OnCreated func(order Order) error
+	OnUpdated func(order Order) error
+	OnDeleted func(order Order) error
+	// Since is the starting point of the first sync, when the store holds no cursor.
+	// A zero value syncs every order.
+	Since time.Time
+	// PageSize is the number of orders requested per page, at most 250. Defaults to 250.
+	PageSize int
+	// Now returns the current time. Defaults to time.Now.
+	Now func() time.Time
+}
+
+type OrderSyncResult struct {
+	Created int
+	Updated int
+	Deleted int
+	Cursor  OrderSyncCursor
+}
+
+// OrderSyncer emits orders that changed since the last run, using GetOrders sorted by
+// date_modified and a cursor persisted in a CheckpointStore.
+type OrderSyncer struct {
+	client *V2Client
+	store  CheckpointStore
+	opts   OrderSyncOptions
+}
+
+func NewOrderSyncer(client *V2Client, store CheckpointStore, opts OrderSyncOptions) *OrderSyncer {
+	if opts.PageSize < 1 || opts.PageSize > 250 {
+		opts.PageSize = 250
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &OrderSyncer{client: client, store: store, opts: opts}
+}
+
+// Sync emits every order modified since the stored cursor and saves the new cursor
+// after each page.
+//
+// Orders modified during the current second are left for the next run: BigCommerce
+// timestamps have second granularity, so the second is not complete until it has passed.
+func (syncer *OrderSyncer) Sync() (OrderSyncResult, error) {
+	var result OrderSyncResult
+
+	cursor, err := syncer.store.Load()
+	if err != nil {
+		return result, err
+	}
+	if cursor.Orders.DateModified.IsZero() && !syncer.opts.Since.IsZero() {
+		cursor.Orders.DateModified = syncer.opts.Since.UTC().Truncate(time.Second)
+	}
+	if cursor.Deleted.DateModified.IsZero() && !syncer.opts.Since.IsZero() {
+		cursor.Deleted.DateModified = syncer.opts.Since.UTC().Truncate(time.Second)
+	}
+	result.Cursor = cursor
+
+	until := syncer.opts.Now().UTC().Truncate(time.Second).Add(-time.Second)
+
+	err = syncer.pass(&result, &result.Cursor.Orders, false, until)
+	if err != nil {
+		return result, err
+	}
+
+	err = syncer.pass(&result, &result.Cursor.Deleted, true, until)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (syncer *OrderSyncer) pass(result *OrderSyncResult, watermark *OrderSyncWatermark, deleted bool, until time.Time) error {
+	// createdAfter separates orders first seen by this pass from ones emitted before
+	createdAfter := watermark.DateModified
+	from := watermark.DateModified
+
+	for {
+		pageStart := watermark.DateModified
+		params := OrderQueryParams{
+			MaxDateModified: until.Format(time.RFC1123Z),
+			Sort:            OrderSortQuery{Field: OrderSortFieldDateModified, Direction: OrderSortDirectionAsc}.String(),
+			IsDeleted:       deleted,
+			Page:            1,
+			Limit:           syncer.opts.PageSize,
+		}
+		if !from.IsZero() {
+			params.MinDateModified = from.Format(time.RFC1123Z)
+		}
+
+		orders, _, err := syncer.client.GetOrders(params)
+		if err != nil {
+			return fmt.Errorf("order sync failed at %s: %w", params.MinDateModified, err)
+		}
+
+		if err := syncer.apply(result, watermark, orders, deleted, createdAfter); err != nil {
+			return err
+		}
+
+		if len(orders) < syncer.opts.PageSize {
+			return nil
+		}
+
+		// Keyset pagination: restart from the watermark once it moves to a later
+		// second.
+		if watermark.DateModified.After(pageStart) {
+			from = watermark.DateModified
+			continue
+		}
+
+		// A full page within one second means more than a page of orders share that
+		// timestamp. The API doesn't order ties consistently between requests, so
+		// the second is read again by ID before moving past it.
+		if err := syncer.drainSecond(result, watermark, deleted, createdAfter, pageStart); err != nil {
+			return err
+		}
+		from = pageStart.Add(time.Second)
+	}
+}
+
+// drainSecond emits the orders modified within second that have not been emitted
+// yet, paging through them by ID.
+func (syncer *OrderSyncer) drainSecond(result *OrderSyncResult, watermark *OrderSyncWatermark, deleted bool, createdAfter, second time.Time) error {
+	minID := 0
+
+	for {
+		params := OrderQueryParams{
+			MinDateModified: second.Format(time.RFC1123Z),
+			MaxDateModified: second.Format(time.RFC1123Z),
+			MinID:           minID,
+			Sort:            OrderSortQuery{Field: OrderSortFieldID, Direction: OrderSortDirectionAsc}.String(),
+			IsDeleted:       deleted,
+			Limit:           syncer.opts.PageSize,
+		}
+
+		orders, _, err := syncer.client.GetOrders(params)
+		if err != nil {
+			return fmt.Errorf("order sync failed at %s (from order %d): %w", params.MinDateModified, minID, err)
+		}
+
+		if err := syncer.apply(result, watermark, orders, deleted, createdAfter); err != nil {
+			return err
+		}
+
+		if len(orders) < syncer.opts.PageSize {
+			return nil
+		}
+
+		for _, order := range orders {
+			if order.ID >= minID {
+				minID = order.ID + 1
+			}
+		}
+	}
+}
+
+// apply emits the orders not yet covered by watermark, advancing it after each one,
+// and saves the cursor.
+func (syncer *OrderSyncer) apply(result *OrderSyncResult, watermark *OrderSyncWatermark, orders []Order, deleted bool, createdAfter time.Time) error {
+	changes, err := sortOrdersByModified(orders)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		if watermark.seen(change.order.ID, change.modified) {
+			continue
+		}
+
+		eventType := OrderSyncEventUpdated
+		if deleted {
+			eventType = OrderSyncEventDeleted
+		} else if createdAfter.IsZero() || !change.created.Before(createdAfter) {
+			eventType = OrderSyncEventCreated
+		}
+
+		if err := syncer.emit(result, OrderSyncEvent{Type: eventType, Order: change.order}); err != nil {
+			if saveErr := syncer.store.Save(result.Cursor); saveErr != nil {
+				return fmt.Errorf("%v (and failed to save checkpoint: %w)", err, saveErr)
+			}
+			return err
+		}
+
+		watermark.advance(change.order.ID, change.modified)
+	}
+
+	return syncer.store.Save(result.Cursor)
+}
+
+func (syncer *OrderSyncer) emit(result *OrderSyncResult, event OrderSyncEvent) error {
+	var handler func(order Order) error
+	var counter *int
+	switch event.Type {
+	case OrderSyncEventCreated:
+		handler, counter = syncer.opts.OnCreated, &result.Created
+	case OrderSyncEventUpdated:
+		handler, counter = syncer.opts.OnUpdated, &result.Updated
+	case OrderSyncEventDeleted:
+		handler, counter = syncer.opts.OnDeleted, &result.Deleted
+	default:
+		return fmt.Errorf("unknown order sync event type %q", event.Type)
+	}
+
+	if handler != nil {
+		if err := handler(event.Order); err != nil {
+			return fmt.Errorf("order sync %s handler failed for order %d: %w", event.Type, event.Order.ID, err)
+		}
+	}
+
+	*counter++
+	return nil
+}
+
+type orderChange struct {
+	order    Order
+	modified time.Time
+	created  time.Time
+}
+
+// sortOrdersByModified parses the order timestamps and sorts by date_modified then ID,
+// so orders sharing a second are always visited in the same order.
+func sortOrdersByModified(orders []Order) ([]orderChange, error) {
+	changes := make([]orderChange, 0, len(orders))
+	for _, order := range orders {
+		modified, err := ParseOrderDate(order.DateModified)
+		if err != nil {
+			return nil, fmt.Errorf("order %d has invalid date_modified: %w", order.ID, err)
+		}
+		created, err := ParseOrderDate(order.DateCreated)
+		if err != nil {
+			created = modified
+		}
+		changes = append(changes, orderChange{order: order, modified: modified, created: created})
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if !changes[i].modified.Equal(changes[j].modified) {
+			return changes[i].modified.Before(changes[j].modified)
+		}
+		return changes[i].order.ID < changes[j].order.ID
+	})
+
+	return changes, nil
+}
+
+// ParseOrderDate parses the RFC 2822 timestamps used by the V2 orders API,
+// such as "Tue, 20 Nov 2012 00:00:00 +0000".
+func ParseOrderDate(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC1123Z, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
@@ -0,0 +1,256 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeOrderStore serves GET /orders the way the V2 API filters and sorts it. Orders
+// sharing a date_modified come back in a different order on every request, as the
+// API doesn't guarantee an order for ties.
+type fakeOrderStore struct {
+	mu       sync.Mutex
+	orders   []Order
+	requests int
+}
+
+func (store *fakeOrderStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	query := r.URL.Query()
+	minModified, _ := time.Parse(time.RFC1123Z, query.Get("min_date_modified"))
+	maxModified, _ := time.Parse(time.RFC1123Z, query.Get("max_date_modified"))
+	deleted := query.Get("is_deleted") == "true"
+	minID, _ := strconv.Atoi(query.Get("min_id"))
+	page, _ := strconv.Atoi(query.Get("page"))
+	limit, _ := strconv.Atoi(query.Get("limit"))
+
+	matches := []Order{}
+	for _, order := range store.orders {
+		modified, _ := ParseOrderDate(order.DateModified)
+		if order.IsDeleted != deleted {
+			continue
+		}
+		if !minModified.IsZero() && modified.Before(minModified) {
+			continue
+		}
+		if !maxModified.IsZero() && modified.After(maxModified) {
+			continue
+		}
+		if order.ID < minID {
+			continue
+		}
+		matches = append(matches, order)
+	}
+
+	store.requests++
+	reverseTies := store.requests%2 == 0
+	sort.SliceStable(matches, func(i, j int) bool {
+		if query.Get("sort") == "id:asc" {
+			return matches[i].ID < matches[j].ID
+		}
+		a, _ := ParseOrderDate(matches[i].DateModified)
+		b, _ := ParseOrderDate(matches[j].DateModified)
+		if a.Equal(b) {
+			return (matches[i].ID < matches[j].ID) != reverseTies
+		}
+		return a.Before(b)
+	})
+
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * limit
+	if start >= len(matches) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	end := start + limit
+	if end > len(matches) {
+		end = len(matches)
+	}
+	json.NewEncoder(w).Encode(matches[start:end])
+}
+
+type memoryCheckpointStore struct {
+	cursor OrderSyncCursor
+}
+
+func (store *memoryCheckpointStore) Load() (OrderSyncCursor, error) { return store.cursor, nil }
+
+func (store *memoryCheckpointStore) Save(cursor OrderSyncCursor) error {
+	store.cursor = cursor
+	return nil
+}
+
+func orderAt(id int, created, modified time.Time) Order {
+	return Order{ID: id, DateCreated: created.Format(time.RFC1123Z), DateModified: modified.Format(time.RFC1123Z)}
+}
+
+func TestOrderSyncer_Sync(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := base.Add(time.Hour)
+
+	fake := &fakeOrderStore{orders: []Order{
+		orderAt(1, base, base),
+		orderAt(2, base, base.Add(time.Second)),
+		orderAt(3, base, base.Add(time.Second)),
+		orderAt(4, base, base.Add(time.Second)),
+		orderAt(5, base, base.Add(2*time.Second)),
+	}}
+	client := newTestServerClient(t, fake)
+	store := &memoryCheckpointStore{}
+
+	var created, updated []int
+	syncer := NewOrderSyncer(client.V2, store, OrderSyncOptions{
+		OnCreated: func(order Order) error { created = append(created, order.ID); return nil },
+		OnUpdated: func(order Order) error { updated = append(updated, order.ID); return nil },
+		PageSize:  2,
+		Now:       func() time.Time { return now },
+	})
+
+	result, err := syncer.Sync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 5 || result.Created != 5 {
+		t.Fatalf("expected 5 created orders, got %v", created)
+	}
+	if store.cursor.Orders.LastID != 5 {
+		t.Errorf("expected watermark at order 5, got %+v", store.cursor.Orders)
+	}
+
+	// A second run emits nothing new.
+	created = nil
+	if _, err := syncer.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 0 || len(updated) != 0 {
+		t.Fatalf("expected no events, got created %v updated %v", created, updated)
+	}
+
+	// Order 3 is modified, a new order 6 lands in the same second, and order 1 is deleted.
+	later := base.Add(10 * time.Second)
+	fake.mu.Lock()
+	fake.orders[2] = orderAt(3, base, later)
+	fake.orders = append(fake.orders, orderAt(6, later, later))
+	fake.orders[0].IsDeleted = true
+	fake.orders[0].DateModified = later.Format(time.RFC1123Z)
+	fake.mu.Unlock()
+
+	var deleted []int
+	syncer.opts.OnDeleted = func(order Order) error { deleted = append(deleted, order.ID); return nil }
+
+	if _, err := syncer.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if len(updated) != 1 || updated[0] != 3 {
+		t.Errorf("expected order 3 updated, got %v", updated)
+	}
+	if len(created) != 1 || created[0] != 6 {
+		t.Errorf("expected order 6 created, got %v", created)
+	}
+	if len(deleted) != 1 || deleted[0] != 1 {
+		t.Errorf("expected order 1 deleted, got %v", deleted)
+	}
+}
+
+func TestOrderSyncer_TiesLargerThanAPage(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	fake := &fakeOrderStore{}
+	for id := 1; id <= 7; id++ {
+		fake.orders = append(fake.orders, orderAt(id, base, base))
+	}
+	fake.orders = append(fake.orders, orderAt(8, base, base.Add(time.Second)))
+	client := newTestServerClient(t, fake)
+
+	seen := map[int]int{}
+	syncer := NewOrderSyncer(client.V2, &memoryCheckpointStore{}, OrderSyncOptions{
+		OnCreated: func(order Order) error { seen[order.ID]++; return nil },
+		PageSize:  2,
+		Now:       func() time.Time { return base.Add(time.Hour) },
+	})
+
+	result, err := syncer.Sync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Created != 8 || len(seen) != 8 {
+		t.Fatalf("expected all 8 orders once, got %v", seen)
+	}
+	for id, count := range seen {
+		if count != 1 {
+			t.Errorf("order %d emitted %d times", id, count)
+		}
+	}
+}
+
+func TestOrderSyncer_ResumesAfterHandlerError(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	fake := &fakeOrderStore{orders: []Order{
+		orderAt(1, base, base),
+		orderAt(2, base, base),
+		orderAt(3, base, base.Add(time.Second)),
+	}}
+	client := newTestServerClient(t, fake)
+	store := &memoryCheckpointStore{}
+
+	fail := true
+	var seen []int
+	syncer := NewOrderSyncer(client.V2, store, OrderSyncOptions{
+		OnCreated: func(order Order) error {
+			if order.ID == 2 && fail {
+				return errors.New("warehouse unavailable")
+			}
+			seen = append(seen, order.ID)
+			return nil
+		},
+		Now: func() time.Time { return base.Add(time.Hour) },
+	})
+
+	if _, err := syncer.Sync(); err == nil {
+		t.Fatal("expected handler error")
+	}
+
+	fail = false
+	if _, err := syncer.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(seen) != 3 || seen[0] != 1 || seen[1] != 2 || seen[2] != 3 {
+		t.Errorf("expected orders 1, 2, 3 exactly once, got %v", seen)
+	}
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "orders.json"))
+
+	cursor, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cursor.Orders.DateModified.IsZero() {
+		t.Fatal("expected zero cursor before first save")
+	}
+
+	cursor.Orders.advance(42, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	if err := store.Save(cursor); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Orders.LastID != 42 || !loaded.Orders.DateModified.Equal(cursor.Orders.DateModified) {
+		t.Errorf("expected %+v, got %+v", cursor, loaded)
+	}
+}
@@ -0,0 +1,150 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// OrderTransactionEvent is the payment event a transaction records
+type OrderTransactionEvent string
+
+const (
+	OrderTransactionEventPurchase      OrderTransactionEvent = "purchase"
+	OrderTransactionEventAuthorization OrderTransactionEvent = "authorization"
+	OrderTransactionEventCapture       OrderTransactionEvent = "capture"
+	OrderTransactionEventRefund        OrderTransactionEvent = "refund"
+	OrderTransactionEventVoid          OrderTransactionEvent = "void"
+	OrderTransactionEventPending       OrderTransactionEvent = "pending"
+	OrderTransactionEventSettled       OrderTransactionEvent = "settled"
+)
+
+// OrderTransactionMethod is the payment method used for a transaction
+type OrderTransactionMethod string
+
+const (
+	OrderTransactionMethodCreditCard       OrderTransactionMethod = "credit_card"
+	OrderTransactionMethodElectronicWallet OrderTransactionMethod = "electronic_wallet"
+	OrderTransactionMethodGiftCertificate  OrderTransactionMethod = "gift_certificate"
+	OrderTransactionMethodStoreCredit      OrderTransactionMethod = "store_credit"
+	OrderTransactionMethodApplePayCard     OrderTransactionMethod = "apple_pay_card"
+	OrderTransactionMethodBoltCard         OrderTransactionMethod = "bolt"
+	OrderTransactionMethodCash             OrderTransactionMethod = "cash"
+	OrderTransactionMethodCheck            OrderTransactionMethod = "check"
+	OrderTransactionMethodCustom           OrderTransactionMethod = "custom"
+	OrderTransactionMethodMoneyOrder       OrderTransactionMethod = "money_order"
+	OrderTransactionMethodBankDeposit      OrderTransactionMethod = "bank_deposit"
+)
+
+// OrderTransaction represents a payment transaction recorded against an order
+type OrderTransaction struct {
+	ID                     int                              `json:"id"`
+	OrderID                string                           `json:"order_id"`
+	Event                  OrderTransactionEvent            `json:"event"`
+	Method                 OrderTransactionMethod           `json:"method"`
+	Amount                 float64                          `json:"amount"`
+	Currency               string                           `json:"currency"`
+	Gateway                string                           `json:"gateway"`
+	GatewayTransactionID   string                           `json:"gateway_transaction_id"`
+	DateCreated            string                           `json:"date_created"`
+	Test                   bool                             `json:"test"`
+	Status                 string                           `json:"status"`
+	FraudReview            bool                             `json:"fraud_review"`
+	ReferenceTransactionID *int                             `json:"reference_transaction_id"`
+	PaymentMethodID        string                           `json:"payment_method_id"`
+	Offline                *OrderTransactionOffline         `json:"offline,omitempty"`
+	Custom                 *OrderTransactionCustom          `json:"custom,omitempty"`
+	CreditCard             *OrderTransactionCreditCard      `json:"credit_card,omitempty"`
+	GiftCertificate        *OrderTransactionGiftCertificate `json:"gift_certificate,omitempty"`
+	StoreCredit            *OrderTransactionStoreCredit     `json:"store_credit,omitempty"`
+	AVSResult              *OrderTransactionAVSResult       `json:"avs_result,omitempty"`
+	CVVResult              *OrderTransactionCVVResult       `json:"cvv_result,omitempty"`
+}
+
+type OrderTransactionOffline struct {
+	DisplayName string `json:"display_name"`
+}
+
+type OrderTransactionCustom struct {
+	PaymentMethod string `json:"payment_method"`
+}
+
+type OrderTransactionCreditCard struct {
+	CardType        string `json:"card_type"`
+	CardIIN         string `json:"card_iin"`
+	CardLast4       string `json:"card_last4"`
+	CardExpiryMonth int    `json:"card_expiry_month"`
+	CardExpiryYear  int    `json:"card_expiry_year"`
+}
+
+type OrderTransactionGiftCertificate struct {
+	Code             string  `json:"code"`
+	OriginalBalance  float64 `json:"original_balance"`
+	StartingBalance  float64 `json:"starting_balance"`
+	RemainingBalance float64 `json:"remaining_balance"`
+	Status           string  `json:"status"`
+}
+
+type OrderTransactionStoreCredit struct {
+	RemainingBalance float64 `json:"remaining_balance"`
+}
+
+type OrderTransactionAVSResult struct {
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	StreetMatch string `json:"street_match"`
+	PostalMatch string `json:"postal_match"`
+}
+
+type OrderTransactionCVVResult struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type OrderTransactionQueryParams struct {
+	Page  int `url:"page,omitempty"`
+	Limit int `url:"limit,omitempty"`
+}
+
+// GetOrderTransactions lists the payment transactions recorded against an order.
+func (client *V3Client) GetOrderTransactions(orderID int, params OrderTransactionQueryParams) ([]OrderTransaction, MetaData, error) {
+	type ResponseObject struct {
+		Data []OrderTransaction `json:"data"`
+		Meta MetaData           `json:"meta"`
+	}
+	var response ResponseObject
+
+	path, err := urlWithQueryParams(client.constructURL("orders", strconv.Itoa(orderID), "transactions"), params)
+	if err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to construct URL for GetOrderTransactions (order ID: %d): %w", orderID, err)
+	}
+
+	if err := client.Get(path, &response); err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to get transactions for order %d: %w", orderID, err)
+	}
+
+	return response.Data, response.Meta, nil
+}
+
+// CaptureOrderPayment captures the authorized payment for an order. The capture is
+// processed asynchronously by the payment gateway.
+func (client *V3Client) CaptureOrderPayment(orderID int) error {
+	path := client.constructURL("orders", strconv.Itoa(orderID), "payment_actions", "capture")
+
+	if err := client.Post(path, nil, nil); err != nil {
+		return fmt.Errorf("failed to capture payment for order %d: %w", orderID, err)
+	}
+
+	return nil
+}
+
+// VoidOrderPayment voids the authorized payment for an order. The void is processed
+// asynchronously by the payment gateway.
+func (client *V3Client) VoidOrderPayment(orderID int) error {
+	path := client.constructURL("orders", strconv.Itoa(orderID), "payment_actions", "void")
+
+	if err := client.Post(path, nil, nil); err != nil {
+		return fmt.Errorf("failed to void payment for order %d: %w", orderID, err)
+	}
+
+	return nil
+}
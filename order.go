@@ -215,3 +215,57 @@ func (client *V2Client) GetOrders(params OrderQueryParams) ([]Order, MetaData, e
 
 	return response.Data, response.Meta, nil
 }
+
+// GetAllOrders retrieves every order matching params. The Page and Limit fields of
+// params are overwritten.
+func (client *V2Client) GetAllOrders(params OrderQueryParams) ([]Order, error) {
+	var orders []Order
+	err := client.ForEachOrderPage(params, func(page []Order) error {
+		orders = append(orders, page...)
+		return nil
+	})
+	if err != nil {
+		return orders, err
+	}
+	return orders, nil
+}
+
+// ForEachOrderPage pages through every order matching params, calling fn with each
+// page of up to 250 orders. Iteration stops at the first error returned by fn.
+// The Page and Limit fields of params are overwritten.
+func (client *V2Client) ForEachOrderPage(params OrderQueryParams, fn func(orders []Order) error) error {
+	params.Page = 1
+	params.Limit = 250
+
+	for {
+		orders, _, err := client.GetOrders(params)
+		if err != nil {
+			return fmt.Errorf("failed to get orders at page %d: %w", params.Page, err)
+		}
+
+		if len(orders) > 0 {
+			if err := fn(orders); err != nil {
+				return err
+			}
+		}
+
+		if len(orders) < params.Limit {
+			return nil
+		}
+
+		params.Page++
+	}
+}
+
+// ForEachOrder calls fn with every order matching params, fetching 250 orders at a
+// time. Iteration stops at the first error returned by fn.
+func (client *V2Client) ForEachOrder(params OrderQueryParams, fn func(order Order) error) error {
+	return client.ForEachOrderPage(params, func(orders []Order) error {
+		for i := range orders {
+			if err := fn(orders[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,139 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PageTreeNode is a page with the pages whose ParentID is its ID.
+type PageTreeNode struct {
+	Page     Page
+	Parent   *PageTreeNode
+	Children []*PageTreeNode
+}
+
+// Depth is 0 for top level pages.
+func (node *PageTreeNode) Depth() int {
+	depth := 0
+	for n := node.Parent; n != nil; n = n.Parent {
+		depth++
+	}
+	return depth
+}
+
+// Path returns the pages from the top level down to node, for breadcrumbs.
+func (node *PageTreeNode) Path() []Page {
+	var path []Page
+	for n := node; n != nil; n = n.Parent {
+		path = append([]Page{n.Page}, path...)
+	}
+	return path
+}
+
+type PageTree struct {
+	Roots []*PageTreeNode
+	nodes map[int]*PageTreeNode
+}
+
+// BuildPageTree arranges pages by ParentID, with siblings ordered by SortOrder
+// and then Name. Pages whose parent is missing from pages, or that are part of a
+// parent cycle, are placed at the top level so that no page is lost.
+func BuildPageTree(pages []Page) *PageTree {
+	tree := &PageTree{nodes: make(map[int]*PageTreeNode, len(pages))}
+
+	for _, page := range pages {
+		tree.nodes[page.ID] = &PageTreeNode{Page: page}
+	}
+
+	for _, page := range pages {
+		node := tree.nodes[page.ID]
+		parent, ok := tree.nodes[page.ParentID]
+		if page.ParentID == 0 || !ok || parent == node || isPageAncestor(node, parent, tree.nodes) {
+			tree.Roots = append(tree.Roots, node)
+			continue
+		}
+		node.Parent = parent
+		parent.Children = append(parent.Children, node)
+	}
+
+	sortPageNodes(tree.Roots)
+	for _, node := range tree.nodes {
+		sortPageNodes(node.Children)
+	}
+
+	return tree
+}
+
+// isPageAncestor reports whether node is an ancestor of candidate by ParentID,
+// meaning that attaching node under candidate would create a cycle.
+func isPageAncestor(node, candidate *PageTreeNode, nodes map[int]*PageTreeNode) bool {
+	seen := map[int]bool{}
+	for n := candidate; n != nil && !seen[n.Page.ID]; n = nodes[n.Page.ParentID] {
+		if n == node {
+			return true
+		}
+		seen[n.Page.ID] = true
+		if n.Page.ParentID == 0 {
+			break
+		}
+	}
+	return false
+}
+
+func sortPageNodes(nodes []*PageTreeNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Page.SortOrder != nodes[j].Page.SortOrder {
+			return nodes[i].Page.SortOrder < nodes[j].Page.SortOrder
+		}
+		return strings.ToLower(nodes[i].Page.Name) < strings.ToLower(nodes[j].Page.Name)
+	})
+}
+
+// Find returns the node of the page with the given ID, or nil.
+func (tree *PageTree) Find(pageID int) *PageTreeNode {
+	return tree.nodes[pageID]
+}
+
+// Walk calls fn for every node depth first, parents before children, stopping at
+// the first error.
+func (tree *PageTree) Walk(fn func(node *PageTreeNode) error) error {
+	var walk func(nodes []*PageTreeNode) error
+	walk = func(nodes []*PageTreeNode) error {
+		for _, node := range nodes {
+			if err := fn(node); err != nil {
+				return err
+			}
+			if err := walk(node.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(tree.Roots)
+}
+
+// Visible returns a copy of the tree without hidden pages and their descendants,
+// as shown in storefront menus.
+func (tree *PageTree) Visible() *PageTree {
+	var pages []Page
+	tree.Walk(func(node *PageTreeNode) error {
+		for n := node; n != nil; n = n.Parent {
+			if !n.Page.IsVisible {
+				return nil
+			}
+		}
+		pages = append(pages, node.Page)
+		return nil
+	})
+	return BuildPageTree(pages)
+}
+
+// GetPageTree retrieves every page matching params and arranges them by ParentID.
+func (client *V3Client) GetPageTree(params GetPagesParams) (*PageTree, error) {
+	pages, err := client.GetAllPages(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build page tree: %w", err)
+	}
+	return BuildPageTree(pages), nil
+}
@@ -0,0 +1,95 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestBuildPageTree(t *testing.T) {
+	pages := []Page{
+		{ID: 1, Name: "About", IsVisible: true, SortOrder: 2},
+		{ID: 2, Name: "Shipping", IsVisible: true, ParentID: 4, SortOrder: 1},
+		{ID: 3, Name: "Team", IsVisible: true, ParentID: 1},
+		{ID: 4, Name: "Help", IsVisible: true, SortOrder: 1},
+		{ID: 5, Name: "Returns", IsVisible: false, ParentID: 4, SortOrder: 0},
+		{ID: 6, Name: "Orphan", IsVisible: true, ParentID: 99},
+		{ID: 7, Name: "Loop A", ParentID: 8},
+		{ID: 8, Name: "Loop B", ParentID: 7},
+	}
+
+	tree := BuildPageTree(pages)
+
+	var order []int
+	tree.Walk(func(node *PageTreeNode) error {
+		order = append(order, node.Page.ID)
+		return nil
+	})
+	if want := []int{7, 8, 6, 4, 5, 2, 1, 3}; !reflect.DeepEqual(order, want) {
+		t.Errorf("expected walk order %v, got %v", want, order)
+	}
+
+	shipping := tree.Find(2)
+	if shipping.Depth() != 1 || len(shipping.Path()) != 2 || shipping.Path()[0].Name != "Help" {
+		t.Errorf("unexpected path for shipping: %+v", shipping.Path())
+	}
+
+	if visible := tree.Visible(); visible.Find(5) != nil || visible.Find(2) == nil {
+		t.Error("expected hidden pages to be left out of the visible tree")
+	}
+}
+
+func TestContactFieldList(t *testing.T) {
+	var page Page
+	if err := json.Unmarshal([]byte(`{"type":"contact_form","contact_fields":"fullname,phone"}`), &page); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(page.ContactFields, ContactFieldList{FullnameField, PhoneField}) {
+		t.Errorf("unexpected contact fields %v", page.ContactFields)
+	}
+
+	b, err := json.Marshal(CreatePageParams{Name: "Contact", Type: ContactFormPage, ContactFields: []ContactField{PhoneField, RMAField}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body map[string]any
+	json.Unmarshal(b, &body)
+	if body["contact_fields"] != "phone,rma" {
+		t.Errorf("expected a comma separated string, got %v", body["contact_fields"])
+	}
+}
+
+func TestValidateCreatePageParams(t *testing.T) {
+	if err := ValidateCreatePageParams(CreatePageParams{Name: "Contact", Type: ContactFormPage}); err == nil {
+		t.Error("expected contact_form pages to need an email")
+	}
+	if err := ValidateCreatePageParams(CreatePageParams{Name: "Docs", Type: LinkPage, Link: "https://example.com"}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGetAllPages(t *testing.T) {
+	client := newTestServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("id:in"); got != "1,2" {
+			t.Errorf("expected id:in=1,2, got %q", got)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": []Page{{ID: 1}, {ID: 2}},
+			"meta": MetaData{Pagination: Pagination{CurrentPage: 1, TotalPages: 1}},
+		})
+	}))
+
+	pages, err := client.V3.GetAllPages(GetPagesParams{IDIn: []int{1, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 2 {
+		t.Errorf("expected 2 pages, got %d", len(pages))
+	}
+
+	// The deprecated ID field is sent as id:in too.
+	if _, err := client.V3.GetAllPages(GetPagesParams{ID: "1,2"}); err != nil {
+		t.Fatal(err)
+	}
+}
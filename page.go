@@ -1,35 +1,58 @@
 package bigcommerce
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 )
 
+// Page is a content page. Which of Body, Email, ContactFields, Feed and Link are
+// used depends on Type.
 type Page struct {
-	ID              int    `json:"id"`
-	ChannelID       int    `json:"channel_id"`
-	Name            string `json:"name" validate:"required,min=1,max=100"`
-	IsVisible       bool   `json:"is_visible"`
-	ParentID        int    `json:"parent_id"`
-	SortOrder       int    `json:"sort_order"`
-	Type            string `json:"type" validate:"required,oneof=page raw contact_form feed link blog"`
-	IsHomepage      bool   `json:"is_homepage"`
-	IsCustomersOnly bool   `json:"is_customers_only"`
-	URL             string `json:"url"`
-	MetaTitle       string `json:"meta_title"`
-	MetaKeywords    string `json:"meta_keywords"`
-	MetaDescription string `json:"meta_description"`
-	SearchKeywords  string `json:"search_keywords"`
+	ID              int      `json:"id"`
+	ChannelID       int      `json:"channel_id"`
+	Name            string   `json:"name" validate:"required,min=1,max=100"`
+	IsVisible       bool     `json:"is_visible"`
+	ParentID        int      `json:"parent_id"`
+	SortOrder       int      `json:"sort_order"`
+	Type            PageType `json:"type" validate:"required,oneof=page raw contact_form feed link blog"`
+	IsHomepage      bool     `json:"is_homepage"`
+	IsCustomersOnly bool     `json:"is_customers_only"`
+	URL             string   `json:"url"`
+	MetaTitle       string   `json:"meta_title"`
+	MetaKeywords    string   `json:"meta_keywords"`
+	MetaDescription string   `json:"meta_description"`
+	SearchKeywords  string   `json:"search_keywords"`
+	// Body is the HTML of page and contact_form pages and the raw content of raw
+	// pages. List requests only return it when Include is "body".
+	Body string `json:"body,omitempty"`
+	// ContentType is the MIME type raw pages are served with.
+	ContentType string `json:"content_type,omitempty"`
+	// Email receives contact_form submissions.
+	Email string `json:"email,omitempty"`
+	// ContactFields are the optional fields shown on contact_form pages.
+	ContactFields ContactFieldList `json:"contact_fields,omitempty"`
+	// Feed is the URL of the feed shown on rss_feed pages.
+	Feed string `json:"feed,omitempty"`
+	// Link is the URL link pages point to.
+	Link string `json:"link,omitempty"`
 }
 
 type GetPagesParams struct {
-	ChannelID int    `url:"channel_id,omitempty"`
-	ID        string `url:"id,in,omitempty"`
-	Name      string `url:"name,omitempty"`
-	NameLike  string `url:"name:like,omitempty"`
-	Limit     int    `url:"limit,omitempty"`
-	Page      int    `url:"page,omitempty"`
-	Include   string `url:"include,omitempty"`
+	ChannelID int `url:"channel_id,omitempty"`
+	// ID is a comma-separated list of page IDs, sent as id:in. Set only one of ID
+	// and IDIn.
+	//
+	// Deprecated: use IDIn.
+	ID       string `url:"id:in,omitempty"`
+	IDIn     []int  `url:"id:in,omitempty,comma"`
+	Name     string `url:"name,omitempty"`
+	NameLike string `url:"name:like,omitempty"`
+	Limit    int    `url:"limit,omitempty"`
+	Page     int    `url:"page,omitempty"`
+	// Include may be "body" to return page bodies.
+	Include string `url:"include,omitempty"`
 }
 
 func (client *V3Client) GetPages(queryParams GetPagesParams) ([]Page, MetaData, error) {
@@ -58,6 +81,10 @@ func (client *V3Client) CreatePage(params CreatePageParams) (Page, error) {
 	}
 	var response ResponseObject
 
+	if err := ValidateCreatePageParams(params); err != nil {
+		return Page{}, fmt.Errorf("failed to create page: invalid parameters: %w", err)
+	}
+
 	path := client.constructURL("/content/pages")
 
 	if err := client.Post(path, params, &response); err != nil {
@@ -110,46 +137,48 @@ func (client *V3Client) UpdatePage(pageID int, params UpdatePageParams) (Page, e
 }
 
 type CreatePageParams struct {
-	Email           string         `json:"email,omitempty" validate:"omitempty,max=255"`
-	MetaTitle       string         `json:"meta_title,omitempty"`
-	Body            string         `json:"body,omitempty"`
-	Feed            string         `json:"feed,omitempty"`
-	Link            string         `json:"link,omitempty"`
-	ContactFields   []ContactField `json:"contact_fields,omitempty"`
-	MetaKeywords    string         `json:"meta_keywords,omitempty"`
-	MetaDescription string         `json:"meta_description,omitempty"`
-	SearchKeywords  string         `json:"search_keywords,omitempty"`
-	URL             string         `json:"url,omitempty"`
-	ChannelID       int            `json:"channel_id,omitempty"`
-	Name            string         `json:"name" validate:"required,min=1,max=100"`
-	IsVisible       bool           `json:"is_visible,omitempty"`
-	ParentID        int            `json:"parent_id,omitempty"`
-	SortOrder       int            `json:"sort_order,omitempty"`
-	Type            PageType       `json:"type" validate:"required,oneof=page raw contact_form feed link blog"`
-	IsHomepage      bool           `json:"is_homepage,omitempty"`
-	IsCustomersOnly bool           `json:"is_customers_only,omitempty"`
+	Email           string           `json:"email,omitempty" validate:"omitempty,max=255"`
+	MetaTitle       string           `json:"meta_title,omitempty"`
+	Body            string           `json:"body,omitempty"`
+	Feed            string           `json:"feed,omitempty"`
+	Link            string           `json:"link,omitempty"`
+	ContactFields   ContactFieldList `json:"contact_fields,omitempty"`
+	ContentType     string           `json:"content_type,omitempty"`
+	MetaKeywords    string           `json:"meta_keywords,omitempty"`
+	MetaDescription string           `json:"meta_description,omitempty"`
+	SearchKeywords  string           `json:"search_keywords,omitempty"`
+	URL             string           `json:"url,omitempty"`
+	ChannelID       int              `json:"channel_id,omitempty"`
+	Name            string           `json:"name" validate:"required,min=1,max=100"`
+	IsVisible       bool             `json:"is_visible,omitempty"`
+	ParentID        int              `json:"parent_id,omitempty"`
+	SortOrder       int              `json:"sort_order,omitempty"`
+	Type            PageType         `json:"type" validate:"required,oneof=page raw contact_form feed link blog"`
+	IsHomepage      bool             `json:"is_homepage,omitempty"`
+	IsCustomersOnly bool             `json:"is_customers_only,omitempty"`
 }
 
 type UpdatePageParams struct {
-	Name            string         `json:"name,omitempty"`
-	IsVisible       bool           `json:"is_visible,omitempty"`
-	ParentID        int            `json:"parent_id,omitempty"`
-	SortOrder       int            `json:"sort_order,omitempty"`
-	Type            PageType       `json:"type,omitempty"`
-	IsHomepage      bool           `json:"is_homepage,omitempty"`
-	IsCustomersOnly bool           `json:"is_customers_only,omitempty"`
-	ID              int            `json:"id,omitempty"`
-	Email           string         `json:"email,omitempty"`
-	MetaTitle       string         `json:"meta_title,omitempty"`
-	Body            string         `json:"body,omitempty"`
-	Feed            string         `json:"feed,omitempty"`
-	Link            string         `json:"link,omitempty"`
-	ContactFields   []ContactField `json:"contact_fields,omitempty"`
-	MetaKeywords    string         `json:"meta_keywords,omitempty"`
-	MetaDescription string         `json:"meta_description,omitempty"`
-	SearchKeywords  string         `json:"search_keywords,omitempty"`
-	URL             string         `json:"url,omitempty"`
-	ChannelID       int            `json:"channel_id,omitempty"`
+	Name            string           `json:"name,omitempty"`
+	IsVisible       bool             `json:"is_visible,omitempty"`
+	ParentID        int              `json:"parent_id,omitempty"`
+	SortOrder       int              `json:"sort_order,omitempty"`
+	Type            PageType         `json:"type,omitempty"`
+	IsHomepage      bool             `json:"is_homepage,omitempty"`
+	IsCustomersOnly bool             `json:"is_customers_only,omitempty"`
+	ID              int              `json:"id,omitempty"`
+	Email           string           `json:"email,omitempty"`
+	MetaTitle       string           `json:"meta_title,omitempty"`
+	Body            string           `json:"body,omitempty"`
+	Feed            string           `json:"feed,omitempty"`
+	Link            string           `json:"link,omitempty"`
+	ContactFields   ContactFieldList `json:"contact_fields,omitempty"`
+	ContentType     string           `json:"content_type,omitempty"`
+	MetaKeywords    string           `json:"meta_keywords,omitempty"`
+	MetaDescription string           `json:"meta_description,omitempty"`
+	SearchKeywords  string           `json:"search_keywords,omitempty"`
+	URL             string           `json:"url,omitempty"`
+	ChannelID       int              `json:"channel_id,omitempty"`
 }
 
 type PageType string
@@ -191,3 +220,190 @@ var AllowedContactFields = []ContactField{
 	OrderNoField,
 	RMAField,
 }
+
+// ContactFieldList is sent by the API as a comma separated string such as
+// "fullname,phone".
+type ContactFieldList []ContactField
+
+func (fields ContactFieldList) MarshalJSON() ([]byte, error) {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = string(field)
+	}
+	return json.Marshal(strings.Join(names, ","))
+}
+
+func (fields *ContactFieldList) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		// Accept an array as well, as sent by earlier versions of this package.
+		var list []ContactField
+		if err := json.Unmarshal(data, &list); err != nil {
+			return fmt.Errorf("failed to decode contact_fields: %w", err)
+		}
+		*fields = list
+		return nil
+	}
+
+	*fields = nil
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			*fields = append(*fields, ContactField(name))
+		}
+	}
+	return nil
+}
+
+// ValidateCreatePageParams checks the fields each page type needs.
+func ValidateCreatePageParams(params CreatePageParams) error {
+	var errors ValidationErrors
+
+	if params.Name == "" {
+		errors = append(errors, "Name is required")
+	} else if len([]rune(params.Name)) > 100 {
+		errors = append(errors, "Name must be at most 100 characters")
+	}
+
+	if len(params.Email) > 255 {
+		errors = append(errors, "Email must be at most 255 characters")
+	}
+
+	for _, field := range params.ContactFields {
+		if !isAllowedContactField(field) {
+			errors = append(errors, fmt.Sprintf("ContactFields contains %q, which is not one of %v", field, AllowedContactFields))
+		}
+	}
+
+	switch params.Type {
+	case "":
+		errors = append(errors, "Type is required")
+	case ContactFormPage:
+		if params.Email == "" {
+			errors = append(errors, "Email is required for contact_form pages")
+		}
+	case LinkPage:
+		if params.Link == "" {
+			errors = append(errors, "Link is required for link pages")
+		}
+	case RSSFeedPage:
+		if params.Feed == "" {
+			errors = append(errors, "Feed is required for rss_feed pages")
+		}
+	case RawPage:
+		if params.Body == "" {
+			errors = append(errors, "Body is required for raw pages")
+		}
+	case UserDefinedPage, BlogPage:
+	default:
+		errors = append(errors, fmt.Sprintf("Type %q is not one of %v", params.Type, AllowedPageTypes))
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return nil
+}
+
+func isAllowedContactField(field ContactField) bool {
+	for _, allowed := range AllowedContactFields {
+		if field == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAllPages retrieves every page matching params. The Page field of params is
+// overwritten. Set Include to "body" to retrieve page bodies as well.
+func (client *V3Client) GetAllPages(params GetPagesParams) ([]Page, error) {
+	var pages []Page
+	params.Page = 1
+	if params.Limit < 1 {
+		params.Limit = 250
+	}
+
+	for {
+		p, meta, err := client.GetPages(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get all pages at page %d: %w", params.Page, err)
+		}
+		pages = append(pages, p...)
+
+		if meta.Pagination.CurrentPage >= meta.Pagination.TotalPages {
+			break
+		}
+
+		params.Page++
+	}
+
+	return pages, nil
+}
+
+// CreatePages creates several pages in one request.
+func (client *V3Client) CreatePages(params []CreatePageParams) ([]Page, error) {
+	type ResponseObject struct {
+		Data []Page   `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	for i, p := range params {
+		if err := ValidateCreatePageParams(p); err != nil {
+			return nil, fmt.Errorf("failed to create pages: invalid parameters for page %d %q: %w", i, p.Name, err)
+		}
+	}
+
+	path := client.constructURL("/content/pages")
+
+	if err := client.Post(path, params, &response); err != nil {
+		return nil, fmt.Errorf("failed to create pages: %w", err)
+	}
+
+	return response.Data, nil
+}
+
+// UpdatePages updates several pages in one request. ID must be set on every item.
+func (client *V3Client) UpdatePages(params []UpdatePageParams) ([]Page, error) {
+	type ResponseObject struct {
+		Data []Page   `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	for i, p := range params {
+		if p.ID == 0 {
+			return nil, fmt.Errorf("failed to update pages: item %d has no ID", i)
+		}
+	}
+
+	path := client.constructURL("/content/pages")
+
+	if err := client.Put(path, params, &response); err != nil {
+		return nil, fmt.Errorf("failed to update pages: %w", err)
+	}
+
+	return response.Data, nil
+}
+
+// DeletePages deletes several pages in one request.
+func (client *V3Client) DeletePages(pageIDs []int) error {
+	if len(pageIDs) == 0 {
+		return nil
+	}
+
+	params := struct {
+		IDIn []int `url:"id:in,comma"`
+	}{IDIn: pageIDs}
+
+	path, err := urlWithQueryParams(client.constructURL("/content/pages"), params)
+	if err != nil {
+		return fmt.Errorf("failed to construct URL for DeletePages: %w", err)
+	}
+
+	if err := client.Delete(path, nil); err != nil {
+		return fmt.Errorf("failed to delete pages %v: %w", pageIDs, err)
+	}
+
+	return nil
+}
@@ -577,14 +577,31 @@ func (client *V3Client) ForEachProduct(funcs []func(p *Product) bool) error {
 	return nil
 }
 
+// UpdateProduct updates a product. When the redirect guard is enabled and params
+// changes the product's custom URL, the old URL is redirected to the product.
 func (client *V3Client) UpdateProduct(productId int, params UpdateProductParams) (Product, error) {
 	var response ResponseObject
 
+	oldPath := ""
+	if client.redirectGuard != nil && params.CustomURL != nil && params.CustomURL.URL != "" {
+		current, err := client.GetProduct(productId, LimitedProductQueryParams{IncludeFields: []string{"custom_url"}})
+		if err != nil {
+			return response.Data, fmt.Errorf("failed to get current URL of product %d: %w", productId, err)
+		}
+		oldPath = current.CustomURL.URL
+	}
+
 	err := client.Put(client.constructURL("/catalog/products", strconv.Itoa(productId)), params, &response)
 	if err != nil {
 		return response.Data, err
 	}
 
+	if oldPath != "" {
+		if err := client.guardURLChange(RedirectToProduct, productId, oldPath, response.Data.CustomURL.URL); err != nil {
+			return response.Data, err
+		}
+	}
+
 	return response.Data, nil
 }
 
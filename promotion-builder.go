@@ -0,0 +1,945 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PromotionBuilder assembles promotion params step by step. Methods that add an
+// action start a new rule, and When, ApplyOnce and StopAfter change the rule added
+// last. Mistakes are collected and reported by Validate, CreateParams and UpdateParams.
+//
+//	params, err := NewPromotionBuilder("Brand week").
+//		DiscountItems(PromotionPercentOff("10"), PromotionBrands(4)).
+//		When(PromotionCartMinimumQuantity(2, PromotionBrands(4))).
+//		OnDays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday).
+//		Between("09:00", "17:00").
+//		MaxUses(500).
+//		ShipToCountries("GB").
+//		CreateParams()
+type PromotionBuilder struct {
+	promotion Promotion
+	errors    ValidationErrors
+}
+
+func NewPromotionBuilder(name string) *PromotionBuilder {
+	return &PromotionBuilder{
+		promotion: Promotion{
+			Name:               name,
+			RedemptionType:     PromotionRedemptionTypeAutomatic,
+			Status:             PromotionStatusEnabled,
+			CanBeUsedWithOther: true,
+		},
+	}
+}
+
+// PromotionPercentOff is a percentage discount, e.g. PromotionPercentOff("10")
+// for 10% off.
+func PromotionPercentOff(percentage string) PromotionDiscount {
+	return PromotionDiscount{PercentageAmount: percentage}
+}
+
+// PromotionAmountOff is a fixed discount in the promotion's currency.
+func PromotionAmountOff(amount string) PromotionDiscount {
+	return PromotionDiscount{FixedAmount: amount}
+}
+
+func PromotionBrands(ids ...int) PromotionItemMatcher {
+	return PromotionItemMatcher{Brands: ids}
+}
+
+func PromotionCategories(ids ...int) PromotionItemMatcher {
+	return PromotionItemMatcher{Categories: ids}
+}
+
+func PromotionProducts(ids ...int) PromotionItemMatcher {
+	return PromotionItemMatcher{Products: ids}
+}
+
+func PromotionVariants(ids ...int) PromotionItemMatcher {
+	return PromotionItemMatcher{Variants: ids}
+}
+
+// PromotionAllItems matches items matched by every one of matchers.
+func PromotionAllItems(matchers ...PromotionItemMatcher) PromotionItemMatcher {
+	return PromotionItemMatcher{And: matchers}
+}
+
+// PromotionAnyItem matches items matched by at least one of matchers.
+func PromotionAnyItem(matchers ...PromotionItemMatcher) PromotionItemMatcher {
+	return PromotionItemMatcher{Or: matchers}
+}
+
+// PromotionExceptItems matches items not matched by matcher.
+func PromotionExceptItems(matcher PromotionItemMatcher) PromotionItemMatcher {
+	return PromotionItemMatcher{Not: &matcher}
+}
+
+// PromotionCartMinimumSpend is met when the cart subtotal reaches amount.
+func PromotionCartMinimumSpend(amount string) PromotionCondition {
+	return PromotionCondition{Cart: &PromotionCartCondition{MinimumSpend: amount}}
+}
+
+// PromotionCartMinimumQuantity is met when the cart holds at least quantity of
+// the items matched by items.
+func PromotionCartMinimumQuantity(quantity int, items PromotionItemMatcher) PromotionCondition {
+	return PromotionCondition{Cart: &PromotionCartCondition{Items: &items, MinimumQuantity: quantity}}
+}
+
+// PromotionCartItemsMinimumSpend is met when the items matched by items cost at
+// least amount.
+func PromotionCartItemsMinimumSpend(amount string, items PromotionItemMatcher) PromotionCondition {
+	return PromotionCondition{Cart: &PromotionCartCondition{Items: &items, MinimumSpend: amount}}
+}
+
+func PromotionAllOf(conditions ...PromotionCondition) PromotionCondition {
+	return PromotionCondition{And: conditions}
+}
+
+func PromotionAnyOf(conditions ...PromotionCondition) PromotionCondition {
+	return PromotionCondition{Or: conditions}
+}
+
+func PromotionNot(condition PromotionCondition) PromotionCondition {
+	return PromotionCondition{Not: &condition}
+}
+
+// Coupon makes the promotion apply only when one of its codes is entered.
+func (b *PromotionBuilder) Coupon() *PromotionBuilder {
+	b.promotion.RedemptionType = PromotionRedemptionTypeCoupon
+	return b
+}
+
+func (b *PromotionBuilder) DisplayName(name string) *PromotionBuilder {
+	b.promotion.DisplayName = name
+	return b
+}
+
+func (b *PromotionBuilder) Channels(ids ...int) *PromotionBuilder {
+	for _, id := range ids {
+		b.promotion.Channels = append(b.promotion.Channels, PromotionChannel{ID: id})
+	}
+	return b
+}
+
+func (b *PromotionBuilder) Currency(code string) *PromotionBuilder {
+	b.promotion.CurrencyCode = code
+	return b
+}
+
+func (b *PromotionBuilder) Disabled() *PromotionBuilder {
+	b.promotion.Status = PromotionStatusDisabled
+	return b
+}
+
+// Exclusive stops the promotion being combined with other promotions.
+func (b *PromotionBuilder) Exclusive() *PromotionBuilder {
+	b.promotion.CanBeUsedWithOther = false
+	return b
+}
+
+// StopLowerPriority stops promotions with a lower priority from applying after this one.
+func (b *PromotionBuilder) StopLowerPriority() *PromotionBuilder {
+	b.promotion.Stop = true
+	return b
+}
+
+func (b *PromotionBuilder) MaxUses(uses int) *PromotionBuilder {
+	b.promotion.MaxUses = uses
+	return b
+}
+
+func (b *PromotionBuilder) StartsAt(t time.Time) *PromotionBuilder {
+	b.promotion.StartDate = t.Format(time.RFC3339)
+	return b
+}
+
+func (b *PromotionBuilder) EndsAt(t time.Time) *PromotionBuilder {
+	b.promotion.EndDate = t.Format(time.RFC3339)
+	return b
+}
+
+func (b *PromotionBuilder) customer() *PromotionCustomer {
+	if b.promotion.Customer == nil {
+		b.promotion.Customer = &PromotionCustomer{}
+	}
+	return b.promotion.Customer
+}
+
+func (b *PromotionBuilder) CustomerGroups(ids ...int) *PromotionBuilder {
+	b.customer().GroupIDs = append(b.customer().GroupIDs, ids...)
+	return b
+}
+
+func (b *PromotionBuilder) ExcludeCustomerGroups(ids ...int) *PromotionBuilder {
+	b.customer().ExcludedGroupIDs = append(b.customer().ExcludedGroupIDs, ids...)
+	return b
+}
+
+// MinimumOrderCount limits the promotion to customers with at least count previous orders.
+func (b *PromotionBuilder) MinimumOrderCount(count int) *PromotionBuilder {
+	b.customer().MinimumOrderCount = count
+	return b
+}
+
+// ShipToCountries limits the promotion to carts shipping to the given ISO 3166-1
+// alpha-2 country codes.
+func (b *PromotionBuilder) ShipToCountries(codes ...string) *PromotionBuilder {
+	if b.promotion.ShippingAddress == nil {
+		b.promotion.ShippingAddress = &PromotionShippingAddress{}
+	}
+	for _, code := range codes {
+		b.promotion.ShippingAddress.Countries = append(b.promotion.ShippingAddress.Countries, PromotionCountry{ISO2CountryCode: code})
+	}
+	return b
+}
+
+func (b *PromotionBuilder) schedule() *PromotionSchedule {
+	if b.promotion.Schedule == nil {
+		b.promotion.Schedule = &PromotionSchedule{WeekFrequency: 1}
+	}
+	return b.promotion.Schedule
+}
+
+// OnDays limits the promotion to the given days of the week.
+func (b *PromotionBuilder) OnDays(days ...time.Weekday) *PromotionBuilder {
+	schedule := b.schedule()
+	for _, day := range days {
+		schedule.WeekDays = append(schedule.WeekDays, day.String())
+	}
+	return b
+}
+
+// Between limits the promotion to a time of day, given as "15:04" or "15:04:05"
+// in the store's time zone.
+func (b *PromotionBuilder) Between(start, end string) *PromotionBuilder {
+	schedule := b.schedule()
+	schedule.DailyStartTime = normalizeScheduleTime(start)
+	schedule.DailyEndTime = normalizeScheduleTime(end)
+	return b
+}
+
+// EveryWeeks runs the schedule every n weeks instead of every week.
+func (b *PromotionBuilder) EveryWeeks(n int) *PromotionBuilder {
+	b.schedule().WeekFrequency = n
+	return b
+}
+
+func (b *PromotionBuilder) addRule(action PromotionAction) *PromotionBuilder {
+	b.promotion.Rules = append(b.promotion.Rules, PromotionRule{Action: action})
+	return b
+}
+
+func (b *PromotionBuilder) lastRule(method string) *PromotionRule {
+	if len(b.promotion.Rules) == 0 {
+		b.errors = append(b.errors, fmt.Sprintf("%s must follow an action such as DiscountCart", method))
+		return nil
+	}
+	return &b.promotion.Rules[len(b.promotion.Rules)-1]
+}
+
+// DiscountCart adds a rule discounting the cart subtotal.
+func (b *PromotionBuilder) DiscountCart(discount PromotionDiscount) *PromotionBuilder {
+	return b.addRule(PromotionAction{CartValue: &PromotionCartValueAction{Discount: discount}})
+}
+
+// DiscountItems adds a rule discounting every item matched by items.
+func (b *PromotionBuilder) DiscountItems(discount PromotionDiscount, items PromotionItemMatcher) *PromotionBuilder {
+	return b.addRule(PromotionAction{CartItems: &PromotionCartItemsAction{Discount: discount, Items: &items}})
+}
+
+// DiscountCheapestItems adds a rule discounting the quantity least expensive items
+// matched by items, as in "buy two, get the cheapest half price".
+func (b *PromotionBuilder) DiscountCheapestItems(discount PromotionDiscount, quantity int, items PromotionItemMatcher) *PromotionBuilder {
+	return b.addRule(PromotionAction{CartItems: &PromotionCartItemsAction{
+		Discount: discount,
+		Items:    &items,
+		Quantity: quantity,
+		Strategy: PromotionDiscountStrategyLeastExpensive,
+	}})
+}
+
+// GiftProduct adds a rule putting quantity of a product in the cart for free.
+func (b *PromotionBuilder) GiftProduct(productID, quantity int) *PromotionBuilder {
+	return b.addRule(PromotionAction{GiftItem: &PromotionGiftItemAction{ProductID: productID, Quantity: quantity}})
+}
+
+// GiftVariant adds a rule putting quantity of a variant in the cart for free.
+func (b *PromotionBuilder) GiftVariant(variantID, quantity int) *PromotionBuilder {
+	return b.addRule(PromotionAction{GiftItem: &PromotionGiftItemAction{VariantID: variantID, Quantity: quantity}})
+}
+
+// FreeShipping adds a rule making shipping free in the given zones, or in every
+// zone when no zone IDs are given.
+func (b *PromotionBuilder) FreeShipping(zoneIDs ...int) *PromotionBuilder {
+	return b.addRule(PromotionAction{Shipping: &PromotionShippingAction{FreeShipping: true, ZoneIDs: promotionZones(zoneIDs)}})
+}
+
+// DiscountShipping adds a rule discounting shipping in the given zones, or in
+// every zone when no zone IDs are given.
+func (b *PromotionBuilder) DiscountShipping(discount PromotionDiscount, zoneIDs ...int) *PromotionBuilder {
+	return b.addRule(PromotionAction{Shipping: &PromotionShippingAction{Discount: &discount, ZoneIDs: promotionZones(zoneIDs)}})
+}
+
+// FixedPriceSet adds a rule selling quantity of the items matched by items for price.
+func (b *PromotionBuilder) FixedPriceSet(quantity int, price string, items PromotionItemMatcher) *PromotionBuilder {
+	return b.addRule(PromotionAction{FixedPriceSet: &PromotionFixedPriceSetAction{Quantity: quantity, FixedPrice: price, Items: &items}})
+}
+
+// When sets the condition of the rule added last. Calling it again combines the
+// conditions so that all of them must be met.
+func (b *PromotionBuilder) When(condition PromotionCondition) *PromotionBuilder {
+	rule := b.lastRule("When")
+	if rule == nil {
+		return b
+	}
+
+	if rule.Condition == nil {
+		rule.Condition = &condition
+	} else if len(rule.Condition.And) > 0 {
+		rule.Condition.And = append(rule.Condition.And, condition)
+	} else {
+		rule.Condition = &PromotionCondition{And: []PromotionCondition{*rule.Condition, condition}}
+	}
+	return b
+}
+
+// ApplyOnce applies the rule added last once per order rather than every time its
+// condition is met.
+func (b *PromotionBuilder) ApplyOnce() *PromotionBuilder {
+	if rule := b.lastRule("ApplyOnce"); rule != nil {
+		rule.ApplyOnce = true
+	}
+	return b
+}
+
+// StopAfter stops later rules of the promotion from applying once the rule added
+// last has applied.
+func (b *PromotionBuilder) StopAfter() *PromotionBuilder {
+	if rule := b.lastRule("StopAfter"); rule != nil {
+		rule.Stop = true
+	}
+	return b
+}
+
+// Promotion returns the promotion as built so far, without validating it.
+func (b *PromotionBuilder) Promotion() Promotion {
+	return b.promotion
+}
+
+// Validate returns every problem with the promotion as ValidationErrors.
+func (b *PromotionBuilder) Validate() error {
+	errors := append(ValidationErrors{}, b.errors...)
+	errors = append(errors, ValidatePromotion(b.promotion)...)
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+func (b *PromotionBuilder) CreateParams() (CreatePromotionParams, error) {
+	if err := b.Validate(); err != nil {
+		return CreatePromotionParams{}, err
+	}
+
+	p := b.promotion
+	return CreatePromotionParams{
+		Name:                         p.Name,
+		DisplayName:                  p.DisplayName,
+		RedemptionType:               p.RedemptionType,
+		Channels:                     p.Channels,
+		Customer:                     p.Customer,
+		Rules:                        p.Rules,
+		MaxUses:                      p.MaxUses,
+		Status:                       p.Status,
+		StartDate:                    p.StartDate,
+		EndDate:                      p.EndDate,
+		Stop:                         p.Stop,
+		CurrencyCode:                 p.CurrencyCode,
+		Notifications:                p.Notifications,
+		ShippingAddress:              p.ShippingAddress,
+		Schedule:                     p.Schedule,
+		CanBeUsedWithOtherPromotions: &p.CanBeUsedWithOther,
+	}, nil
+}
+
+// UpdateParams returns params replacing the rules and settings of an existing
+// promotion with the ones built. The redemption type of a promotion cannot change.
+func (b *PromotionBuilder) UpdateParams() (PromotionUpdateParams, error) {
+	if err := b.Validate(); err != nil {
+		return PromotionUpdateParams{}, err
+	}
+
+	p := b.promotion
+	return PromotionUpdateParams{
+		Name:                         p.Name,
+		DisplayName:                  p.DisplayName,
+		Channels:                     p.Channels,
+		Customer:                     p.Customer,
+		Rules:                        p.Rules,
+		MaxUses:                      p.MaxUses,
+		Status:                       p.Status,
+		StartDate:                    p.StartDate,
+		EndDate:                      p.EndDate,
+		Stop:                         &p.Stop,
+		CanBeUsedWithOtherPromotions: &p.CanBeUsedWithOther,
+		CurrencyCode:                 p.CurrencyCode,
+		Notifications:                p.Notifications,
+		ShippingAddress:              p.ShippingAddress,
+		Schedule:                     p.Schedule,
+	}, nil
+}
+
+func promotionZones(ids []int) PromotionZoneIDs {
+	if len(ids) == 0 {
+		return PromotionZoneIDs{All: true}
+	}
+	return PromotionZoneIDs{IDs: ids}
+}
+
+func normalizeScheduleTime(s string) string {
+	if len(s) == len("15:04") {
+		return s + ":00"
+	}
+	return s
+}
+
+// ValidatePromotion checks a promotion's rules and schedule against the rules
+// BigCommerce applies and returns every problem found.
+func ValidatePromotion(promotion Promotion) ValidationErrors {
+	var errors ValidationErrors
+
+	if promotion.Name == "" {
+		errors = append(errors, "Name is required")
+	}
+
+	switch promotion.RedemptionType {
+	case PromotionRedemptionTypeAutomatic, PromotionRedemptionTypeCoupon:
+	default:
+		errors = append(errors, fmt.Sprintf("RedemptionType %q must be AUTOMATIC or COUPON", promotion.RedemptionType))
+	}
+
+	if len(promotion.Rules) == 0 {
+		errors = append(errors, "at least one rule is required")
+	}
+	for i, rule := range promotion.Rules {
+		errors = append(errors, validatePromotionRule(fmt.Sprintf("Rules[%d]", i), rule)...)
+	}
+
+	if promotion.MaxUses < 0 {
+		errors = append(errors, "MaxUses must not be negative")
+	}
+
+	var start, end time.Time
+	if promotion.StartDate != "" {
+		t, err := time.Parse(time.RFC3339, promotion.StartDate)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("StartDate %q must be an ISO 8601 date", promotion.StartDate))
+		}
+		start = t
+	}
+	if promotion.EndDate != "" {
+		t, err := time.Parse(time.RFC3339, promotion.EndDate)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("EndDate %q must be an ISO 8601 date", promotion.EndDate))
+		}
+		end = t
+	}
+	if !start.IsZero() && !end.IsZero() && !end.After(start) {
+		errors = append(errors, "EndDate must be after StartDate")
+	}
+
+	if promotion.Schedule != nil {
+		errors = append(errors, validatePromotionSchedule(*promotion.Schedule)...)
+	}
+
+	if promotion.ShippingAddress != nil {
+		for _, country := range promotion.ShippingAddress.Countries {
+			if !isISO2CountryCode(country.ISO2CountryCode) {
+				errors = append(errors, fmt.Sprintf("ShippingAddress.Countries contains %q, expected an ISO 3166-1 alpha-2 code such as 'GB'", country.ISO2CountryCode))
+			}
+		}
+	}
+
+	return errors
+}
+
+func validatePromotionSchedule(schedule PromotionSchedule) ValidationErrors {
+	var errors ValidationErrors
+
+	if schedule.WeekFrequency < 1 {
+		errors = append(errors, "Schedule.WeekFrequency must be at least 1")
+	}
+
+	seen := map[string]bool{}
+	for _, day := range schedule.WeekDays {
+		if _, ok := parseWeekday(day); !ok {
+			errors = append(errors, fmt.Sprintf("Schedule.WeekDays contains %q, expected a day such as 'Monday'", day))
+		}
+		if seen[day] {
+			errors = append(errors, fmt.Sprintf("Schedule.WeekDays contains %q twice", day))
+		}
+		seen[day] = true
+	}
+
+	if (schedule.DailyStartTime == "") != (schedule.DailyEndTime == "") {
+		errors = append(errors, "Schedule.DailyStartTime and Schedule.DailyEndTime must be set together")
+		return errors
+	}
+	if schedule.DailyStartTime == "" {
+		return errors
+	}
+
+	startTime, startErr := time.Parse("15:04:05", schedule.DailyStartTime)
+	if startErr != nil {
+		errors = append(errors, fmt.Sprintf("Schedule.DailyStartTime %q must look like 09:00:00", schedule.DailyStartTime))
+	}
+	endTime, endErr := time.Parse("15:04:05", schedule.DailyEndTime)
+	if endErr != nil {
+		errors = append(errors, fmt.Sprintf("Schedule.DailyEndTime %q must look like 17:00:00", schedule.DailyEndTime))
+	}
+	if startErr == nil && endErr == nil && !endTime.After(startTime) {
+		errors = append(errors, "Schedule.DailyEndTime must be after Schedule.DailyStartTime")
+	}
+
+	return errors
+}
+
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+func validatePromotionRule(field string, rule PromotionRule) ValidationErrors {
+	var errors ValidationErrors
+	action := rule.Action
+
+	actions := 0
+	if action.CartValue != nil {
+		actions++
+		errors = append(errors, validatePromotionDiscount(field+".Action.CartValue.Discount", action.CartValue.Discount)...)
+	}
+	if action.CartItems != nil {
+		actions++
+		errors = append(errors, validatePromotionDiscount(field+".Action.CartItems.Discount", action.CartItems.Discount)...)
+		if action.CartItems.Items == nil {
+			errors = append(errors, field+".Action.CartItems.Items is required")
+		} else {
+			errors = append(errors, validatePromotionItemMatcher(field+".Action.CartItems.Items", *action.CartItems.Items)...)
+		}
+		if action.CartItems.Quantity < 0 {
+			errors = append(errors, field+".Action.CartItems.Quantity must not be negative")
+		}
+	}
+	if action.GiftItem != nil {
+		actions++
+		if (action.GiftItem.ProductID == 0) == (action.GiftItem.VariantID == 0) {
+			errors = append(errors, field+".Action.GiftItem needs exactly one of ProductID and VariantID")
+		}
+		if action.GiftItem.Quantity < 1 {
+			errors = append(errors, field+".Action.GiftItem.Quantity must be at least 1")
+		}
+	}
+	if action.Shipping != nil {
+		actions++
+		if action.Shipping.FreeShipping == (action.Shipping.Discount != nil) {
+			errors = append(errors, field+".Action.Shipping needs exactly one of FreeShipping and Discount")
+		}
+		if action.Shipping.Discount != nil {
+			errors = append(errors, validatePromotionDiscount(field+".Action.Shipping.Discount", *action.Shipping.Discount)...)
+		}
+		if !action.Shipping.ZoneIDs.All && len(action.Shipping.ZoneIDs.IDs) == 0 {
+			errors = append(errors, field+".Action.Shipping.ZoneIDs must be all zones or at least one zone")
+		}
+	}
+	if action.FixedPriceSet != nil {
+		actions++
+		if action.FixedPriceSet.Quantity < 1 {
+			errors = append(errors, field+".Action.FixedPriceSet.Quantity must be at least 1")
+		}
+		if price, err := strconv.ParseFloat(action.FixedPriceSet.FixedPrice, 64); err != nil || price < 0 {
+			errors = append(errors, fmt.Sprintf("%s.Action.FixedPriceSet.FixedPrice %q must be a non-negative number", field, action.FixedPriceSet.FixedPrice))
+		}
+		if action.FixedPriceSet.Items == nil {
+			errors = append(errors, field+".Action.FixedPriceSet.Items is required")
+		} else {
+			errors = append(errors, validatePromotionItemMatcher(field+".Action.FixedPriceSet.Items", *action.FixedPriceSet.Items)...)
+		}
+	}
+	if actions != 1 {
+		errors = append(errors, fmt.Sprintf("%s.Action must have exactly one action, found %d", field, actions))
+	}
+
+	if rule.Condition != nil {
+		errors = append(errors, validatePromotionCondition(field+".Condition", *rule.Condition)...)
+	}
+
+	return errors
+}
+
+func validatePromotionDiscount(field string, discount PromotionDiscount) ValidationErrors {
+	var errors ValidationErrors
+
+	if (discount.FixedAmount == "") == (discount.PercentageAmount == "") {
+		return append(errors, field+" needs exactly one of FixedAmount and PercentageAmount")
+	}
+
+	if discount.FixedAmount != "" {
+		if amount, err := strconv.ParseFloat(discount.FixedAmount, 64); err != nil || amount <= 0 {
+			errors = append(errors, fmt.Sprintf("%s.FixedAmount %q must be a positive number", field, discount.FixedAmount))
+		}
+	}
+	if discount.PercentageAmount != "" {
+		if percentage, err := strconv.ParseFloat(discount.PercentageAmount, 64); err != nil || percentage <= 0 || percentage > 100 {
+			errors = append(errors, fmt.Sprintf("%s.PercentageAmount %q must be above 0 and at most 100", field, discount.PercentageAmount))
+		}
+	}
+
+	return errors
+}
+
+func validatePromotionItemMatcher(field string, matcher PromotionItemMatcher) ValidationErrors {
+	var errors ValidationErrors
+
+	kinds := 0
+	for _, ids := range [][]int{matcher.Brands, matcher.Categories, matcher.Products, matcher.Variants} {
+		if len(ids) > 0 {
+			kinds++
+		}
+	}
+	if len(matcher.And) > 0 {
+		kinds++
+	}
+	if len(matcher.Or) > 0 {
+		kinds++
+	}
+	if matcher.Not != nil {
+		kinds++
+	}
+	if kinds != 1 {
+		return append(errors, fmt.Sprintf("%s must match by exactly one of brands, categories, products, variants, and, or, not", field))
+	}
+
+	for i, m := range matcher.And {
+		errors = append(errors, validatePromotionItemMatcher(fmt.Sprintf("%s.And[%d]", field, i), m)...)
+	}
+	for i, m := range matcher.Or {
+		errors = append(errors, validatePromotionItemMatcher(fmt.Sprintf("%s.Or[%d]", field, i), m)...)
+	}
+	if matcher.Not != nil {
+		errors = append(errors, validatePromotionItemMatcher(field+".Not", *matcher.Not)...)
+	}
+
+	return errors
+}
+
+func validatePromotionCondition(field string, condition PromotionCondition) ValidationErrors {
+	var errors ValidationErrors
+
+	kinds := 0
+	if condition.Cart != nil {
+		kinds++
+	}
+	if len(condition.And) > 0 {
+		kinds++
+	}
+	if len(condition.Or) > 0 {
+		kinds++
+	}
+	if condition.Not != nil {
+		kinds++
+	}
+	if kinds != 1 {
+		return append(errors, fmt.Sprintf("%s must have exactly one of cart, and, or, not", field))
+	}
+
+	if cart := condition.Cart; cart != nil {
+		if cart.Items == nil && cart.MinimumSpend == "" && cart.MinimumQuantity == 0 {
+			errors = append(errors, field+".Cart needs Items, MinimumSpend or MinimumQuantity")
+		}
+		if cart.Items != nil {
+			errors = append(errors, validatePromotionItemMatcher(field+".Cart.Items", *cart.Items)...)
+		}
+		if cart.MinimumSpend != "" {
+			if spend, err := strconv.ParseFloat(cart.MinimumSpend, 64); err != nil || spend < 0 {
+				errors = append(errors, fmt.Sprintf("%s.Cart.MinimumSpend %q must be a non-negative number", field, cart.MinimumSpend))
+			}
+		}
+		if cart.MinimumQuantity < 0 {
+			errors = append(errors, field+".Cart.MinimumQuantity must not be negative")
+		}
+	}
+
+	for i, c := range condition.And {
+		errors = append(errors, validatePromotionCondition(fmt.Sprintf("%s.And[%d]", field, i), c)...)
+	}
+	for i, c := range condition.Or {
+		errors = append(errors, validatePromotionCondition(fmt.Sprintf("%s.Or[%d]", field, i), c)...)
+	}
+	if condition.Not != nil {
+		errors = append(errors, validatePromotionCondition(field+".Not", *condition.Not)...)
+	}
+
+	return errors
+}
+
+// DescribePromotion renders a promotion as a short human-readable summary, one
+// line for the promotion and one per rule.
+func DescribePromotion(promotion Promotion) string {
+	var b strings.Builder
+
+	redemption := "automatic"
+	if promotion.RedemptionType == PromotionRedemptionTypeCoupon {
+		redemption = "coupon"
+	}
+	fmt.Fprintf(&b, "%s (%s", promotion.Name, redemption)
+	if promotion.Status != "" {
+		fmt.Fprintf(&b, ", %s", strings.ToLower(string(promotion.Status)))
+	}
+	b.WriteString(")")
+
+	var limits []string
+	if promotion.StartDate != "" || promotion.EndDate != "" {
+		limits = append(limits, describePromotionDates(promotion.StartDate, promotion.EndDate))
+	}
+	if promotion.Schedule != nil {
+		limits = append(limits, describePromotionSchedule(*promotion.Schedule))
+	}
+	if promotion.MaxUses > 0 {
+		limits = append(limits, fmt.Sprintf("max %d uses", promotion.MaxUses))
+	}
+	if promotion.ShippingAddress != nil && len(promotion.ShippingAddress.Countries) > 0 {
+		countries := make([]string, len(promotion.ShippingAddress.Countries))
+		for i, country := range promotion.ShippingAddress.Countries {
+			countries[i] = country.ISO2CountryCode
+		}
+		limits = append(limits, "shipping to "+strings.Join(countries, ", "))
+	}
+	if customer := promotion.Customer; customer != nil {
+		if len(customer.GroupIDs) > 0 {
+			limits = append(limits, "customer groups "+joinInts(customer.GroupIDs))
+		}
+		if len(customer.ExcludedGroupIDs) > 0 {
+			limits = append(limits, "not customer groups "+joinInts(customer.ExcludedGroupIDs))
+		}
+		if customer.MinimumOrderCount > 0 {
+			limits = append(limits, fmt.Sprintf("customers with %d+ orders", customer.MinimumOrderCount))
+		}
+	}
+	if !promotion.CanBeUsedWithOther {
+		limits = append(limits, "not combinable with other promotions")
+	}
+	if len(limits) > 0 {
+		b.WriteString(": ")
+		b.WriteString(strings.Join(limits, "; "))
+	}
+
+	for _, rule := range promotion.Rules {
+		b.WriteString("\n- ")
+		b.WriteString(describePromotionAction(rule.Action, promotion.CurrencyCode))
+		if rule.Condition != nil {
+			b.WriteString(" when ")
+			b.WriteString(describePromotionCondition(*rule.Condition, promotion.CurrencyCode))
+		}
+		if rule.ApplyOnce {
+			b.WriteString(", once per order")
+		}
+		if rule.Stop {
+			b.WriteString(", then stop")
+		}
+	}
+
+	return b.String()
+}
+
+func describePromotionDates(start, end string) string {
+	switch {
+	case start != "" && end != "":
+		return fmt.Sprintf("from %s until %s", start, end)
+	case start != "":
+		return "from " + start
+	default:
+		return "until " + end
+	}
+}
+
+func describePromotionSchedule(schedule PromotionSchedule) string {
+	var parts []string
+
+	if len(schedule.WeekDays) > 0 {
+		days := make([]string, len(schedule.WeekDays))
+		for i, day := range schedule.WeekDays {
+			days[i] = day
+			if len(day) > 3 {
+				days[i] = day[:3]
+			}
+		}
+		parts = append(parts, strings.Join(days, ", "))
+	}
+	if schedule.WeekFrequency > 1 {
+		parts = append(parts, fmt.Sprintf("every %d weeks", schedule.WeekFrequency))
+	}
+	if schedule.DailyStartTime != "" {
+		parts = append(parts, strings.TrimSuffix(schedule.DailyStartTime, ":00")+"-"+strings.TrimSuffix(schedule.DailyEndTime, ":00"))
+	}
+	if len(parts) == 0 {
+		return "every day"
+	}
+	return strings.Join(parts, " ")
+}
+
+func describePromotionDiscount(discount PromotionDiscount, currency string) string {
+	if discount.PercentageAmount != "" {
+		return discount.PercentageAmount + "% off"
+	}
+	return strings.TrimSpace(discount.FixedAmount+" "+currency) + " off"
+}
+
+func describePromotionAction(action PromotionAction, currency string) string {
+	switch {
+	case action.CartValue != nil:
+		return describePromotionDiscount(action.CartValue.Discount, currency) + " the order"
+	case action.CartItems != nil:
+		items := "items"
+		if action.CartItems.Items != nil {
+			items = describePromotionItems(*action.CartItems.Items)
+		}
+		s := describePromotionDiscount(action.CartItems.Discount, currency) + " "
+		if action.CartItems.Quantity > 0 {
+			strategy := ""
+			switch action.CartItems.Strategy {
+			case PromotionDiscountStrategyLeastExpensive:
+				strategy = "cheapest "
+			case PromotionDiscountStrategyMostExpensive:
+				strategy = "most expensive "
+			}
+			s += fmt.Sprintf("the %d %s", action.CartItems.Quantity, strategy)
+		}
+		s += items
+		if action.CartItems.AsTotal {
+			s += " as a total"
+		}
+		if action.CartItems.ExcludeItemsOnSale {
+			s += " not on sale"
+		}
+		return s
+	case action.GiftItem != nil:
+		if action.GiftItem.VariantID != 0 {
+			return fmt.Sprintf("free %d x variant %d", action.GiftItem.Quantity, action.GiftItem.VariantID)
+		}
+		return fmt.Sprintf("free %d x product %d", action.GiftItem.Quantity, action.GiftItem.ProductID)
+	case action.Shipping != nil:
+		zones := "all zones"
+		if !action.Shipping.ZoneIDs.All {
+			zones = "zones " + joinInts(action.Shipping.ZoneIDs.IDs)
+		}
+		if action.Shipping.FreeShipping || action.Shipping.Discount == nil {
+			return "free shipping to " + zones
+		}
+		return describePromotionDiscount(*action.Shipping.Discount, currency) + " shipping to " + zones
+	case action.FixedPriceSet != nil:
+		items := "items"
+		if action.FixedPriceSet.Items != nil {
+			items = describePromotionItems(*action.FixedPriceSet.Items)
+		}
+		return fmt.Sprintf("%d %s for %s", action.FixedPriceSet.Quantity, items, strings.TrimSpace(action.FixedPriceSet.FixedPrice+" "+currency))
+	default:
+		return "no action"
+	}
+}
+
+func describePromotionItems(matcher PromotionItemMatcher) string {
+	switch {
+	case len(matcher.Brands) > 0:
+		return describeIDs("brand", matcher.Brands)
+	case len(matcher.Categories) > 0:
+		return describeIDs("category", matcher.Categories)
+	case len(matcher.Products) > 0:
+		return describeIDs("product", matcher.Products)
+	case len(matcher.Variants) > 0:
+		return describeIDs("variant", matcher.Variants)
+	case len(matcher.And) > 0:
+		return describeItemGroup(matcher.And, " and ")
+	case len(matcher.Or) > 0:
+		return describeItemGroup(matcher.Or, " or ")
+	case matcher.Not != nil:
+		return "items not " + strings.TrimPrefix(describePromotionItems(*matcher.Not), "items ")
+	default:
+		return "items"
+	}
+}
+
+func describeItemGroup(matchers []PromotionItemMatcher, separator string) string {
+	parts := make([]string, len(matchers))
+	for i, m := range matchers {
+		parts[i] = describePromotionItems(m)
+	}
+	return "(" + strings.Join(parts, separator) + ")"
+}
+
+func describeIDs(kind string, ids []int) string {
+	if len(ids) == 1 {
+		return fmt.Sprintf("items in %s %d", kind, ids[0])
+	}
+	plural := kind + "s"
+	if kind == "category" {
+		plural = "categories"
+	}
+	return fmt.Sprintf("items in %s %s", plural, joinInts(ids))
+}
+
+func describePromotionCondition(condition PromotionCondition, currency string) string {
+	switch {
+	case condition.Cart != nil:
+		cart := condition.Cart
+		subject := "the cart"
+		if cart.Items != nil {
+			subject = describePromotionItems(*cart.Items)
+		}
+		var parts []string
+		if cart.MinimumQuantity > 0 {
+			parts = append(parts, fmt.Sprintf("at least %d", cart.MinimumQuantity))
+		}
+		if cart.MinimumSpend != "" {
+			parts = append(parts, "a spend of at least "+strings.TrimSpace(cart.MinimumSpend+" "+currency))
+		}
+		if len(parts) == 0 {
+			return "the cart has " + subject
+		}
+		if cart.Items == nil {
+			return subject + " reaches " + strings.Join(parts, " and ")
+		}
+		return "the cart has " + strings.Join(parts, " and ") + " of " + subject
+	case len(condition.And) > 0:
+		return describeConditionGroup(condition.And, " and ", currency)
+	case len(condition.Or) > 0:
+		return describeConditionGroup(condition.Or, " or ", currency)
+	case condition.Not != nil:
+		return "not (" + describePromotionCondition(*condition.Not, currency) + ")"
+	default:
+		return "always"
+	}
+}
+
+func describeConditionGroup(conditions []PromotionCondition, separator, currency string) string {
+	parts := make([]string, len(conditions))
+	for i, c := range conditions {
+		parts[i] = describePromotionCondition(c, currency)
+	}
+	return "(" + strings.Join(parts, separator) + ")"
+}
+
+func joinInts(ids []int) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.Itoa(id)
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,78 @@
+package bigcommerce
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPromotionBuilder(t *testing.T) {
+	params, err := NewPromotionBuilder("Brand week").
+		DiscountItems(PromotionPercentOff("10"), PromotionBrands(4)).
+		When(PromotionCartMinimumQuantity(2, PromotionBrands(4))).
+		OnDays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday).
+		Between("09:00", "17:00").
+		MaxUses(500).
+		ShipToCountries("GB").
+		CreateParams()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if params.RedemptionType != PromotionRedemptionTypeAutomatic || params.MaxUses != 500 {
+		t.Errorf("unexpected params %+v", params)
+	}
+	if len(params.Rules) != 1 || params.Rules[0].Action.CartItems.Discount.PercentageAmount != "10" {
+		t.Errorf("unexpected rules %+v", params.Rules)
+	}
+	if cart := params.Rules[0].Condition.Cart; cart.MinimumQuantity != 2 || cart.Items.Brands[0] != 4 {
+		t.Errorf("unexpected condition %+v", cart)
+	}
+	if params.Schedule.DailyStartTime != "09:00:00" || len(params.Schedule.WeekDays) != 5 {
+		t.Errorf("unexpected schedule %+v", params.Schedule)
+	}
+	if params.ShippingAddress.Countries[0].ISO2CountryCode != "GB" {
+		t.Errorf("unexpected shipping address %+v", params.ShippingAddress)
+	}
+}
+
+func TestPromotionBuilder_Invalid(t *testing.T) {
+	_, err := NewPromotionBuilder("Broken").
+		When(PromotionCartMinimumSpend("50")).
+		DiscountCart(PromotionDiscount{FixedAmount: "5", PercentageAmount: "5"}).
+		DiscountItems(PromotionPercentOff("150"), PromotionItemMatcher{}).
+		Between("17:00", "09:00").
+		ShipToCountries("UK2").
+		UpdateParams()
+
+	var validationErrors ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	if len(validationErrors) != 6 {
+		t.Errorf("expected 6 problems, got %d:\n%s", len(validationErrors), strings.Join(validationErrors, "\n"))
+	}
+}
+
+func TestDescribePromotion(t *testing.T) {
+	promotion := NewPromotionBuilder("Brand week").
+		DiscountItems(PromotionPercentOff("10"), PromotionBrands(4)).
+		When(PromotionCartMinimumQuantity(2, PromotionBrands(4))).
+		FreeShipping().
+		When(PromotionCartMinimumSpend("100")).
+		ApplyOnce().
+		OnDays(time.Monday, time.Friday).
+		Between("09:00", "17:00").
+		MaxUses(500).
+		ShipToCountries("GB").
+		Currency("GBP").
+		Promotion()
+
+	want := "Brand week (automatic, enabled): Mon, Fri 09:00-17:00; max 500 uses; shipping to GB\n" +
+		"- 10% off items in brand 4 when the cart has at least 2 of items in brand 4\n" +
+		"- free shipping to all zones when the cart reaches a spend of at least 100 GBP, once per order"
+	if got := DescribePromotion(promotion); got != want {
+		t.Errorf("unexpected description:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,231 @@
+package bigcommerce
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// PromotionCode is a code that redeems a promotion with the COUPON redemption type.
+type PromotionCode struct {
+	ID                 int    `json:"id"`
+	Code               string `json:"code"`
+	CurrentUses        int    `json:"current_uses"`
+	MaxUses            int    `json:"max_uses"`
+	MaxUsesPerCustomer int    `json:"max_uses_per_customer"`
+	Created            string `json:"created"`
+}
+
+type PromotionCodeQueryParams struct {
+	Page  int `url:"page,omitempty"`
+	Limit int `url:"limit,omitempty"`
+}
+
+type CreatePromotionCodeParams struct {
+	Code               string `json:"code"`
+	MaxUses            int    `json:"max_uses,omitempty"`
+	MaxUsesPerCustomer int    `json:"max_uses_per_customer,omitempty"`
+}
+
+// maxPromotionCodegenBatchSize is the largest BatchSize accepted by the codegen endpoint.
+const maxPromotionCodegenBatchSize = 250
+
+// GeneratePromotionCodesParams configures codes generated by BigCommerce. Codes
+// are made of Prefix, random characters and Suffix, separated by Delimiter.
+type GeneratePromotionCodesParams struct {
+	BatchSize          int    `json:"batch_size"`
+	MaxUses            int    `json:"max_uses,omitempty"`
+	MaxUsesPerCustomer int    `json:"max_uses_per_customer,omitempty"`
+	Prefix             string `json:"prefix,omitempty"`
+	Suffix             string `json:"suffix,omitempty"`
+	Delimiter          string `json:"delimiter,omitempty"`
+}
+
+type PromotionCodeBatch struct {
+	BatchSize int             `json:"batch_size"`
+	Codes     []PromotionCode `json:"codes"`
+}
+
+// PromotionCodeFromCoupon converts a legacy V2 coupon into params for a promotion
+// code with the same code and usage limits. The discount itself has to be
+// recreated as a promotion rule.
+func PromotionCodeFromCoupon(coupon Coupon) CreatePromotionCodeParams {
+	return CreatePromotionCodeParams{
+		Code:               coupon.Code,
+		MaxUses:            coupon.MaxUses,
+		MaxUsesPerCustomer: coupon.MaxUsesPerCustomer,
+	}
+}
+
+func (client *V3Client) GetPromotionCodes(promotionID int, params PromotionCodeQueryParams) ([]PromotionCode, MetaData, error) {
+	type ResponseObject struct {
+		Data []PromotionCode `json:"data"`
+		Meta MetaData        `json:"meta"`
+	}
+	var response ResponseObject
+
+	path, err := urlWithQueryParams(client.constructURL("promotions", strconv.Itoa(promotionID), "codes"), params)
+	if err != nil {
+		return response.Data, response.Meta, fmt.Errorf("failed to construct URL with query params: %w", err)
+	}
+
+	if err := client.Get(path, &response); err != nil {
+		return response.Data, response.Meta, fmt.Errorf("failed to get codes for promotion %d: %w", promotionID, err)
+	}
+
+	return response.Data, response.Meta, nil
+}
+
+// GetAllPromotionCodes retrieves every code of a promotion.
+func (client *V3Client) GetAllPromotionCodes(promotionID int) ([]PromotionCode, error) {
+	var codes []PromotionCode
+	params := PromotionCodeQueryParams{Page: 1, Limit: 250}
+
+	for {
+		c, meta, err := client.GetPromotionCodes(promotionID, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get all codes for promotion %d at page %d: %w", promotionID, params.Page, err)
+		}
+		codes = append(codes, c...)
+
+		if len(c) < params.Limit || meta.Pagination.CurrentPage >= meta.Pagination.TotalPages {
+			break
+		}
+
+		params.Page++
+	}
+
+	return codes, nil
+}
+
+func (client *V3Client) CreatePromotionCode(promotionID int, params CreatePromotionCodeParams) (PromotionCode, error) {
+	type ResponseObject struct {
+		Data PromotionCode `json:"data"`
+		Meta MetaData      `json:"meta"`
+	}
+	var response ResponseObject
+
+	path := client.constructURL("promotions", strconv.Itoa(promotionID), "codes")
+
+	if err := client.Post(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to create code %q for promotion %d: %w", params.Code, promotionID, err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) DeletePromotionCode(promotionID, codeID int) error {
+	path := client.constructURL("promotions", strconv.Itoa(promotionID), "codes", strconv.Itoa(codeID))
+
+	if err := client.Delete(path, nil); err != nil {
+		return fmt.Errorf("failed to delete code %d of promotion %d: %w", codeID, promotionID, err)
+	}
+
+	return nil
+}
+
+// DeletePromotionCodes deletes several codes of a promotion in one request.
+func (client *V3Client) DeletePromotionCodes(promotionID int, codeIDs []int) error {
+	if len(codeIDs) == 0 {
+		return nil
+	}
+
+	params := struct {
+		IDIn []int `url:"id:in,comma"`
+	}{IDIn: codeIDs}
+
+	path, err := urlWithQueryParams(client.constructURL("promotions", strconv.Itoa(promotionID), "codes"), params)
+	if err != nil {
+		return fmt.Errorf("failed to construct URL with query params: %w", err)
+	}
+
+	if err := client.Delete(path, nil); err != nil {
+		return fmt.Errorf("failed to delete codes %v of promotion %d: %w", codeIDs, promotionID, err)
+	}
+
+	return nil
+}
+
+// GeneratePromotionCodes asks BigCommerce to generate a batch of random codes for
+// a promotion. BatchSize may be at most 250; use GeneratePromotionCodesCount for more.
+func (client *V3Client) GeneratePromotionCodes(promotionID int, params GeneratePromotionCodesParams) (PromotionCodeBatch, error) {
+	type ResponseObject struct {
+		Data PromotionCodeBatch `json:"data"`
+		Meta MetaData           `json:"meta"`
+	}
+	var response ResponseObject
+
+	if params.BatchSize < 1 || params.BatchSize > maxPromotionCodegenBatchSize {
+		return response.Data, fmt.Errorf("failed to generate codes for promotion %d: BatchSize must be between 1 and %d", promotionID, maxPromotionCodegenBatchSize)
+	}
+
+	path := client.constructURL("promotions", strconv.Itoa(promotionID), "codegen")
+
+	if err := client.Post(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to generate codes for promotion %d: %w", promotionID, err)
+	}
+
+	return response.Data, nil
+}
+
+// GeneratePromotionCodesCount generates count codes in as many batches as needed.
+// BatchSize in params is ignored. The codes generated before an error are returned
+// with it.
+func (client *V3Client) GeneratePromotionCodesCount(promotionID int, count int, params GeneratePromotionCodesParams) ([]PromotionCode, error) {
+	var codes []PromotionCode
+
+	for len(codes) < count {
+		params.BatchSize = count - len(codes)
+		if params.BatchSize > maxPromotionCodegenBatchSize {
+			params.BatchSize = maxPromotionCodegenBatchSize
+		}
+
+		batch, err := client.GeneratePromotionCodes(promotionID, params)
+		if err != nil {
+			return codes, err
+		}
+		if len(batch.Codes) == 0 {
+			return codes, fmt.Errorf("failed to generate codes for promotion %d: empty batch after %d codes", promotionID, len(codes))
+		}
+		codes = append(codes, batch.Codes...)
+	}
+
+	return codes, nil
+}
+
+// WritePromotionCodesCSV writes codes as CSV with a header row.
+func WritePromotionCodesCSV(w io.Writer, codes []PromotionCode) error {
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{{"id", "code", "current_uses", "max_uses", "max_uses_per_customer", "created"}}
+	for _, code := range codes {
+		rows = append(rows, []string{
+			strconv.Itoa(code.ID),
+			code.Code,
+			strconv.Itoa(code.CurrentUses),
+			strconv.Itoa(code.MaxUses),
+			strconv.Itoa(code.MaxUsesPerCustomer),
+			code.Created,
+		})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write promotion codes CSV: %w", err)
+	}
+	return nil
+}
+
+// ExportPromotionCodes writes every code of a promotion to w as CSV.
+func (client *V3Client) ExportPromotionCodes(w io.Writer, promotionID int) (int, error) {
+	codes, err := client.GetAllPromotionCodes(promotionID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := WritePromotionCodesCSV(w, codes); err != nil {
+		return 0, err
+	}
+
+	return len(codes), nil
+}
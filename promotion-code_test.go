@@ -0,0 +1,63 @@
+package bigcommerce
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGeneratePromotionCodesCount(t *testing.T) {
+	var batchSizes []int
+	client := newTestServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stores/test/v3/promotions/5/codegen" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+
+		var params GeneratePromotionCodesParams
+		json.NewDecoder(r.Body).Decode(&params)
+		batchSizes = append(batchSizes, params.BatchSize)
+
+		batch := PromotionCodeBatch{BatchSize: params.BatchSize}
+		for i := 0; i < params.BatchSize; i++ {
+			batch.Codes = append(batch.Codes, PromotionCode{ID: i + 1, Code: params.Prefix + "CODE"})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": batch})
+	}))
+
+	codes, err := client.V3.GeneratePromotionCodesCount(5, 300, GeneratePromotionCodesParams{Prefix: "SPRING", MaxUses: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != 300 || len(batchSizes) != 2 || batchSizes[0] != 250 || batchSizes[1] != 50 {
+		t.Errorf("expected batches of 250 and 50, got %v and %d codes", batchSizes, len(codes))
+	}
+}
+
+func TestExportPromotionCodes(t *testing.T) {
+	client := newTestServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": []PromotionCode{{ID: 1, Code: "A1", MaxUses: 1}, {ID: 2, Code: "B2", CurrentUses: 1, MaxUses: 1}},
+			"meta": MetaData{Pagination: Pagination{CurrentPage: 1, TotalPages: 1}},
+		})
+	}))
+
+	var buf bytes.Buffer
+	n, err := client.V3.ExportPromotionCodes(&buf, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if n != 2 || len(lines) != 3 || lines[2] != "2,B2,1,1,0," {
+		t.Errorf("unexpected export of %d codes:\n%s", n, buf.String())
+	}
+}
+
+func TestPromotionCodeFromCoupon(t *testing.T) {
+	params := PromotionCodeFromCoupon(Coupon{Code: "LEGACY", MaxUses: 10, MaxUsesPerCustomer: 1})
+	if params.Code != "LEGACY" || params.MaxUses != 10 || params.MaxUsesPerCustomer != 1 {
+		t.Errorf("unexpected params %+v", params)
+	}
+}
@@ -0,0 +1,584 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PromotionCart is a cart as seen by EvaluatePromotions.
+type PromotionCart struct {
+	Items []PromotionCartItem
+	// ChannelID is checked against the promotion's channels when set.
+	ChannelID int
+	// Currency is checked against the promotion's currency when set.
+	Currency           string
+	CustomerGroupID    int
+	CustomerOrderCount int
+	// ShippingCountry is an ISO 3166-1 alpha-2 code.
+	ShippingCountry string
+	ShippingZoneID  int
+	ShippingCost    float64
+	// CouponPromotionIDs are the promotions whose codes were entered. Promotions
+	// with the COUPON redemption type only apply when listed here.
+	CouponPromotionIDs []int
+	// Time is when the cart is checked out. Schedules are compared against its
+	// clock time, so it should be in the store's time zone. Defaults to now.
+	Time time.Time
+}
+
+type PromotionCartItem struct {
+	ProductID   int
+	VariantID   int
+	BrandID     int
+	CategoryIDs []int
+	// Price is the price of one unit.
+	Price    float64
+	Quantity int
+	OnSale   bool
+}
+
+type PromotionEvaluation struct {
+	Applied []AppliedPromotion
+	Skipped []SkippedPromotion
+	// ItemDiscounts is the discount on each cart item, in the order of PromotionCart.Items.
+	ItemDiscounts    []float64
+	CartDiscount     float64
+	ShippingDiscount float64
+	TotalDiscount    float64
+	FreeItems        []PromotionGiftItemAction
+}
+
+type AppliedPromotion struct {
+	PromotionID int
+	Name        string
+	Rules       []AppliedPromotionRule
+	Discount    float64
+}
+
+type AppliedPromotionRule struct {
+	// Index is the position of the rule in Promotion.Rules.
+	Index int
+	// Times is how often the rule applied. Rules without ApplyOnce apply once
+	// for every time their condition is met.
+	Times     int
+	Discount  float64
+	FreeItems []PromotionGiftItemAction
+}
+
+type SkippedPromotion struct {
+	PromotionID int
+	Name        string
+	Reason      string
+}
+
+// EvaluatePromotions works out offline which of promotions apply to cart and the
+// discounts they give. Promotions are considered in the order given, which should
+// be their priority order. A promotion with Stop set stops the promotions after
+// it once it applies, and a promotion that cannot be used with other promotions
+// only applies when no other promotion has, and stops the rest when it does.
+//
+// The evaluation is an approximation meant for testing rules before launch:
+// discounts are not rounded and items matched by a condition can also be
+// discounted by its action.
+func EvaluatePromotions(promotions []Promotion, cart PromotionCart) (PromotionEvaluation, error) {
+	evaluation := PromotionEvaluation{ItemDiscounts: make([]float64, len(cart.Items))}
+	if cart.Time.IsZero() {
+		cart.Time = time.Now()
+	}
+
+	state := &promotionCartState{cart: cart, itemDiscounts: evaluation.ItemDiscounts}
+
+	for i, promotion := range promotions {
+		skip := func(reason string) {
+			evaluation.Skipped = append(evaluation.Skipped, SkippedPromotion{PromotionID: promotion.ID, Name: promotion.Name, Reason: reason})
+		}
+
+		if reason := promotionIneligibility(promotion, cart); reason != "" {
+			skip(reason)
+			continue
+		}
+		if !promotion.CanBeUsedWithOther && len(evaluation.Applied) > 0 {
+			skip("cannot be used with other promotions")
+			continue
+		}
+
+		applied, err := state.apply(promotion)
+		if err != nil {
+			return evaluation, fmt.Errorf("failed to evaluate promotion %d %q: %w", promotion.ID, promotion.Name, err)
+		}
+		if len(applied.Rules) == 0 {
+			skip("no rule conditions met")
+			continue
+		}
+
+		evaluation.Applied = append(evaluation.Applied, applied)
+		for _, rule := range applied.Rules {
+			evaluation.FreeItems = append(evaluation.FreeItems, rule.FreeItems...)
+		}
+
+		if promotion.Stop || !promotion.CanBeUsedWithOther {
+			for _, rest := range promotions[i+1:] {
+				reason := fmt.Sprintf("stopped by promotion %d", promotion.ID)
+				evaluation.Skipped = append(evaluation.Skipped, SkippedPromotion{PromotionID: rest.ID, Name: rest.Name, Reason: reason})
+			}
+			break
+		}
+	}
+
+	evaluation.CartDiscount = state.cartDiscount
+	evaluation.ShippingDiscount = state.shippingDiscount
+	evaluation.TotalDiscount = state.cartDiscount + state.shippingDiscount
+	for _, discount := range evaluation.ItemDiscounts {
+		evaluation.TotalDiscount += discount
+	}
+
+	return evaluation, nil
+}
+
+// promotionIneligibility returns why promotion cannot apply to cart at all, or ""
+// when it can.
+func promotionIneligibility(promotion Promotion, cart PromotionCart) string {
+	if promotion.Status != "" && promotion.Status != PromotionStatusEnabled {
+		return "status is " + string(promotion.Status)
+	}
+	if promotion.MaxUses > 0 && promotion.CurrentUses >= promotion.MaxUses {
+		return fmt.Sprintf("used %d of %d times", promotion.CurrentUses, promotion.MaxUses)
+	}
+	if promotion.RedemptionType == PromotionRedemptionTypeCoupon && !containsInt(cart.CouponPromotionIDs, promotion.ID) {
+		return "no code entered"
+	}
+
+	if promotion.StartDate != "" {
+		start, err := time.Parse(time.RFC3339, promotion.StartDate)
+		if err == nil && cart.Time.Before(start) {
+			return "not started"
+		}
+	}
+	if promotion.EndDate != "" {
+		end, err := time.Parse(time.RFC3339, promotion.EndDate)
+		if err == nil && !cart.Time.Before(end) {
+			return "ended"
+		}
+	}
+	if promotion.Schedule != nil && !promotionScheduleActive(promotion, cart.Time) {
+		return "outside schedule"
+	}
+
+	if len(promotion.Channels) > 0 && cart.ChannelID != 0 {
+		found := false
+		for _, channel := range promotion.Channels {
+			if channel.ID == cart.ChannelID {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Sprintf("not available on channel %d", cart.ChannelID)
+		}
+	}
+	if promotion.CurrencyCode != "" && cart.Currency != "" && !strings.EqualFold(promotion.CurrencyCode, cart.Currency) {
+		return "currency is " + promotion.CurrencyCode
+	}
+
+	if customer := promotion.Customer; customer != nil {
+		if len(customer.GroupIDs) > 0 && !containsInt(customer.GroupIDs, cart.CustomerGroupID) {
+			return fmt.Sprintf("customer group %d not eligible", cart.CustomerGroupID)
+		}
+		if containsInt(customer.ExcludedGroupIDs, cart.CustomerGroupID) {
+			return fmt.Sprintf("customer group %d excluded", cart.CustomerGroupID)
+		}
+		if cart.CustomerOrderCount < customer.MinimumOrderCount {
+			return fmt.Sprintf("customer needs %d previous orders", customer.MinimumOrderCount)
+		}
+	}
+
+	if address := promotion.ShippingAddress; address != nil && len(address.Countries) > 0 {
+		found := false
+		for _, country := range address.Countries {
+			if strings.EqualFold(country.ISO2CountryCode, cart.ShippingCountry) {
+				found = true
+			}
+		}
+		if !found {
+			return "does not ship to " + cart.ShippingCountry
+		}
+	}
+
+	return ""
+}
+
+// promotionScheduleActive reports whether t falls on a scheduled day and time.
+// Schedules repeating every few weeks count weeks from the promotion's start date.
+func promotionScheduleActive(promotion Promotion, t time.Time) bool {
+	schedule := promotion.Schedule
+
+	if len(schedule.WeekDays) > 0 {
+		found := false
+		for _, name := range schedule.WeekDays {
+			if day, ok := parseWeekday(name); ok && day == t.Weekday() {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if schedule.WeekFrequency > 1 && promotion.StartDate != "" {
+		if start, err := time.Parse(time.RFC3339, promotion.StartDate); err == nil {
+			weeks := int(t.Sub(start).Hours() / (24 * 7))
+			if weeks%schedule.WeekFrequency != 0 {
+				return false
+			}
+		}
+	}
+
+	if schedule.DailyStartTime != "" && schedule.DailyEndTime != "" {
+		clock := t.Format("15:04:05")
+		if clock < schedule.DailyStartTime || clock >= schedule.DailyEndTime {
+			return false
+		}
+	}
+
+	return true
+}
+
+type promotionCartState struct {
+	cart             PromotionCart
+	itemDiscounts    []float64
+	cartDiscount     float64
+	shippingDiscount float64
+}
+
+// remaining is what is left to pay for a cart item after discounts.
+func (state *promotionCartState) remaining(item int) float64 {
+	line := state.cart.Items[item]
+	return line.Price*float64(line.Quantity) - state.itemDiscounts[item]
+}
+
+func (state *promotionCartState) subtotal() float64 {
+	var subtotal float64
+	for i := range state.cart.Items {
+		subtotal += state.remaining(i)
+	}
+	return subtotal - state.cartDiscount
+}
+
+func (state *promotionCartState) discountItem(item int, amount float64) float64 {
+	amount = math.Min(amount, state.remaining(item))
+	if amount <= 0 {
+		return 0
+	}
+	state.itemDiscounts[item] += amount
+	return amount
+}
+
+func (state *promotionCartState) apply(promotion Promotion) (AppliedPromotion, error) {
+	applied := AppliedPromotion{PromotionID: promotion.ID, Name: promotion.Name}
+
+	for index, rule := range promotion.Rules {
+		times := 1
+		if rule.Condition != nil {
+			met, err := state.conditionTimes(*rule.Condition)
+			if err != nil {
+				return applied, fmt.Errorf("rule %d: %w", index, err)
+			}
+			if met == 0 {
+				continue
+			}
+			if !rule.ApplyOnce {
+				times = met
+			}
+		}
+
+		result, err := state.applyAction(rule.Action, times)
+		if err != nil {
+			return applied, fmt.Errorf("rule %d: %w", index, err)
+		}
+		result.Index = index
+		result.Times = times
+
+		applied.Rules = append(applied.Rules, result)
+		applied.Discount += result.Discount
+
+		if rule.Stop {
+			break
+		}
+	}
+
+	return applied, nil
+}
+
+// conditionTimes returns how many times condition is met by the cart.
+func (state *promotionCartState) conditionTimes(condition PromotionCondition) (int, error) {
+	switch {
+	case condition.Cart != nil:
+		var spend float64
+		var quantity int
+		for _, item := range state.cart.Items {
+			if condition.Cart.Items == nil || promotionItemMatches(*condition.Cart.Items, item) {
+				spend += item.Price * float64(item.Quantity)
+				quantity += item.Quantity
+			}
+		}
+		if condition.Cart.Items != nil && quantity == 0 {
+			return 0, nil
+		}
+
+		times := math.MaxInt32
+		if condition.Cart.MinimumSpend != "" {
+			minimum, err := strconv.ParseFloat(condition.Cart.MinimumSpend, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid minimum spend %q", condition.Cart.MinimumSpend)
+			}
+			if minimum > 0 {
+				times = minInt(times, int(math.Floor(spend/minimum+1e-9)))
+			}
+		}
+		if condition.Cart.MinimumQuantity > 0 {
+			times = minInt(times, quantity/condition.Cart.MinimumQuantity)
+		}
+		if times == math.MaxInt32 {
+			times = 1
+		}
+		return times, nil
+	case len(condition.And) > 0:
+		times := math.MaxInt32
+		for _, c := range condition.And {
+			t, err := state.conditionTimes(c)
+			if err != nil {
+				return 0, err
+			}
+			times = minInt(times, t)
+		}
+		return times, nil
+	case len(condition.Or) > 0:
+		times := 0
+		for _, c := range condition.Or {
+			t, err := state.conditionTimes(c)
+			if err != nil {
+				return 0, err
+			}
+			if t > times {
+				times = t
+			}
+		}
+		return times, nil
+	case condition.Not != nil:
+		t, err := state.conditionTimes(*condition.Not)
+		if err != nil {
+			return 0, err
+		}
+		if t == 0 {
+			return 1, nil
+		}
+		return 0, nil
+	default:
+		return 1, nil
+	}
+}
+
+func promotionItemMatches(matcher PromotionItemMatcher, item PromotionCartItem) bool {
+	switch {
+	case len(matcher.Brands) > 0:
+		return containsInt(matcher.Brands, item.BrandID)
+	case len(matcher.Categories) > 0:
+		for _, id := range item.CategoryIDs {
+			if containsInt(matcher.Categories, id) {
+				return true
+			}
+		}
+		return false
+	case len(matcher.Products) > 0:
+		return containsInt(matcher.Products, item.ProductID)
+	case len(matcher.Variants) > 0:
+		return containsInt(matcher.Variants, item.VariantID)
+	case len(matcher.And) > 0:
+		for _, m := range matcher.And {
+			if !promotionItemMatches(m, item) {
+				return false
+			}
+		}
+		return true
+	case len(matcher.Or) > 0:
+		for _, m := range matcher.Or {
+			if promotionItemMatches(m, item) {
+				return true
+			}
+		}
+		return false
+	case matcher.Not != nil:
+		return !promotionItemMatches(*matcher.Not, item)
+	default:
+		return true
+	}
+}
+
+// promotionUnit is one unit of a cart item.
+type promotionUnit struct {
+	item  int
+	price float64
+}
+
+// matchingUnits lists the units matched by matcher, ordered by strategy.
+func (state *promotionCartState) matchingUnits(matcher *PromotionItemMatcher, excludeOnSale bool, strategy PromotionDiscountStrategy) []promotionUnit {
+	var units []promotionUnit
+	for i, item := range state.cart.Items {
+		if excludeOnSale && item.OnSale {
+			continue
+		}
+		if matcher != nil && !promotionItemMatches(*matcher, item) {
+			continue
+		}
+		for q := 0; q < item.Quantity; q++ {
+			units = append(units, promotionUnit{item: i, price: item.Price})
+		}
+	}
+
+	sort.SliceStable(units, func(i, j int) bool {
+		if strategy == PromotionDiscountStrategyLeastExpensive {
+			return units[i].price < units[j].price
+		}
+		return units[i].price > units[j].price
+	})
+
+	return units
+}
+
+func (state *promotionCartState) applyAction(action PromotionAction, times int) (AppliedPromotionRule, error) {
+	var result AppliedPromotionRule
+
+	switch {
+	case action.CartValue != nil:
+		subtotal := state.subtotal()
+		amount, err := promotionDiscountAmount(action.CartValue.Discount, subtotal, times)
+		if err != nil {
+			return result, err
+		}
+		amount = math.Min(amount, subtotal)
+		state.cartDiscount += amount
+		result.Discount = amount
+
+	case action.CartItems != nil:
+		a := action.CartItems
+		units := state.matchingUnits(a.Items, a.ExcludeItemsOnSale, a.Strategy)
+		if a.Quantity > 0 && len(units) > a.Quantity*times {
+			units = units[:a.Quantity*times]
+		}
+
+		if a.AsTotal && a.Discount.FixedAmount != "" {
+			// A fixed amount off the matched items as a whole, spread by price.
+			var total float64
+			for _, unit := range units {
+				total += unit.price
+			}
+			amount, err := promotionDiscountAmount(a.Discount, total, times)
+			if err != nil {
+				return result, err
+			}
+			amount = math.Min(amount, total)
+			for _, unit := range units {
+				if total > 0 {
+					result.Discount += state.discountItem(unit.item, amount*unit.price/total)
+				}
+			}
+			break
+		}
+
+		for _, unit := range units {
+			amount, err := promotionDiscountAmount(a.Discount, unit.price, 1)
+			if err != nil {
+				return result, err
+			}
+			result.Discount += state.discountItem(unit.item, math.Min(amount, unit.price))
+		}
+
+	case action.GiftItem != nil:
+		gift := *action.GiftItem
+		gift.Quantity *= times
+		result.FreeItems = append(result.FreeItems, gift)
+
+	case action.Shipping != nil:
+		a := action.Shipping
+		if !a.ZoneIDs.All && !containsInt(a.ZoneIDs.IDs, state.cart.ShippingZoneID) {
+			break
+		}
+		remaining := state.cart.ShippingCost - state.shippingDiscount
+		amount := remaining
+		if !a.FreeShipping && a.Discount != nil {
+			var err error
+			amount, err = promotionDiscountAmount(*a.Discount, remaining, 1)
+			if err != nil {
+				return result, err
+			}
+		}
+		amount = math.Max(0, math.Min(amount, remaining))
+		state.shippingDiscount += amount
+		result.Discount = amount
+
+	case action.FixedPriceSet != nil:
+		a := action.FixedPriceSet
+		price, err := strconv.ParseFloat(a.FixedPrice, 64)
+		if err != nil {
+			return result, fmt.Errorf("invalid fixed price %q", a.FixedPrice)
+		}
+		if a.Quantity < 1 {
+			return result, fmt.Errorf("fixed price set quantity must be at least 1")
+		}
+
+		units := state.matchingUnits(a.Items, a.ExcludeItemsOnSale, a.Strategy)
+		sets := minInt(len(units)/a.Quantity, times)
+		for set := 0; set < sets; set++ {
+			setUnits := units[set*a.Quantity : (set+1)*a.Quantity]
+			var total float64
+			for _, unit := range setUnits {
+				total += unit.price
+			}
+			if total <= price {
+				continue
+			}
+			saving := total - price
+			for _, unit := range setUnits {
+				result.Discount += state.discountItem(unit.item, saving*unit.price/total)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// promotionDiscountAmount is the discount given on base, times over for fixed amounts.
+func promotionDiscountAmount(discount PromotionDiscount, base float64, times int) (float64, error) {
+	if discount.PercentageAmount != "" {
+		percentage, err := strconv.ParseFloat(discount.PercentageAmount, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid percentage %q", discount.PercentageAmount)
+		}
+		return base * percentage / 100, nil
+	}
+
+	amount, err := strconv.ParseFloat(discount.FixedAmount, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid fixed amount %q", discount.FixedAmount)
+	}
+	return amount * float64(times), nil
+}
+
+func containsInt(ids []int, id int) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,125 @@
+package bigcommerce
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func testPromotionCart() PromotionCart {
+	return PromotionCart{
+		Items: []PromotionCartItem{
+			{ProductID: 1, BrandID: 4, CategoryIDs: []int{18}, Price: 20, Quantity: 2},
+			{ProductID: 2, BrandID: 5, CategoryIDs: []int{18}, Price: 50, Quantity: 1},
+			{ProductID: 3, BrandID: 4, CategoryIDs: []int{21}, Price: 10, Quantity: 1, OnSale: true},
+		},
+		CustomerGroupID: 1,
+		ShippingCountry: "GB",
+		ShippingZoneID:  2,
+		ShippingCost:    8,
+		// A Wednesday.
+		Time: time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func mustBuildPromotion(t *testing.T, b *PromotionBuilder, id int) Promotion {
+	t.Helper()
+	if err := b.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	promotion := b.Promotion()
+	promotion.ID = id
+	return promotion
+}
+
+func assertAmount(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 0.001 {
+		t.Errorf("expected %s %.2f, got %.2f", name, want, got)
+	}
+}
+
+func TestEvaluatePromotions(t *testing.T) {
+	promotions := []Promotion{
+		mustBuildPromotion(t, NewPromotionBuilder("Brand 4 10% off").
+			DiscountItems(PromotionPercentOff("10"), PromotionBrands(4)).
+			When(PromotionCartMinimumQuantity(2, PromotionBrands(4))).
+			ApplyOnce(), 1),
+		mustBuildPromotion(t, NewPromotionBuilder("5 off every 40").
+			DiscountCart(PromotionAmountOff("5")).
+			When(PromotionCartMinimumSpend("40")), 2),
+		mustBuildPromotion(t, NewPromotionBuilder("Free shipping").FreeShipping(2), 3),
+		mustBuildPromotion(t, NewPromotionBuilder("Weekend only").
+			DiscountCart(PromotionPercentOff("50")).
+			OnDays(time.Saturday, time.Sunday), 4),
+	}
+
+	evaluation, err := EvaluatePromotions(promotions, testPromotionCart())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(evaluation.Applied) != 3 || len(evaluation.Skipped) != 1 || evaluation.Skipped[0].Reason != "outside schedule" {
+		t.Fatalf("unexpected evaluation: applied %+v, skipped %+v", evaluation.Applied, evaluation.Skipped)
+	}
+
+	// 10% of 2 x 20 and 1 x 10.
+	assertAmount(t, "item discount", evaluation.ItemDiscounts[0]+evaluation.ItemDiscounts[2], 5)
+	// The cart is worth 100 before discounts, so the 5 off every 40 applies twice.
+	if evaluation.Applied[1].Rules[0].Times != 2 {
+		t.Errorf("expected the cart rule to apply twice, got %d", evaluation.Applied[1].Rules[0].Times)
+	}
+	assertAmount(t, "cart discount", evaluation.CartDiscount, 10)
+	assertAmount(t, "shipping discount", evaluation.ShippingDiscount, 8)
+	assertAmount(t, "total discount", evaluation.TotalDiscount, 23)
+}
+
+func TestEvaluatePromotions_Stacking(t *testing.T) {
+	exclusive := mustBuildPromotion(t, NewPromotionBuilder("Exclusive").DiscountCart(PromotionAmountOff("15")).Exclusive(), 1)
+	other := mustBuildPromotion(t, NewPromotionBuilder("Other").DiscountCart(PromotionAmountOff("1")), 2)
+
+	evaluation, err := EvaluatePromotions([]Promotion{other, exclusive}, testPromotionCart())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evaluation.Applied) != 1 || evaluation.Applied[0].PromotionID != 2 {
+		t.Errorf("expected only the first promotion to apply, got %+v", evaluation.Applied)
+	}
+
+	evaluation, err = EvaluatePromotions([]Promotion{exclusive, other}, testPromotionCart())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evaluation.Applied) != 1 || evaluation.Applied[0].PromotionID != 1 || evaluation.Skipped[0].Reason != "stopped by promotion 1" {
+		t.Errorf("expected the exclusive promotion to stop the other, got %+v %+v", evaluation.Applied, evaluation.Skipped)
+	}
+
+	usedUp := other
+	usedUp.MaxUses, usedUp.CurrentUses = 10, 10
+	evaluation, err = EvaluatePromotions([]Promotion{usedUp}, testPromotionCart())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evaluation.Applied) != 0 {
+		t.Errorf("expected a used up promotion to be skipped, got %+v", evaluation.Applied)
+	}
+}
+
+func TestEvaluatePromotions_FixedPriceSetAndGift(t *testing.T) {
+	promotion := mustBuildPromotion(t, NewPromotionBuilder("Any 2 from category 18 for 50").
+		FixedPriceSet(2, "50", PromotionCategories(18)).
+		GiftProduct(99, 1).
+		When(PromotionCartMinimumSpend("100")).
+		ApplyOnce(), 1)
+
+	evaluation, err := EvaluatePromotions([]Promotion{promotion}, testPromotionCart())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The most expensive pair is 50 + 20, sold for 50.
+	assertAmount(t, "total discount", evaluation.TotalDiscount, 20)
+	if len(evaluation.FreeItems) != 1 || evaluation.FreeItems[0].ProductID != 99 {
+		t.Errorf("expected a free product, got %+v", evaluation.FreeItems)
+	}
+}
@@ -1,85 +1,306 @@
 package bigcommerce
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"strconv"
 )
 
+// PromotionRedemptionType is how a promotion is applied to a cart
+type PromotionRedemptionType string
+
+const (
+	PromotionRedemptionTypeAutomatic PromotionRedemptionType = "AUTOMATIC"
+	PromotionRedemptionTypeCoupon    PromotionRedemptionType = "COUPON"
+)
+
+type PromotionStatus string
+
+const (
+	PromotionStatusEnabled  PromotionStatus = "ENABLED"
+	PromotionStatusDisabled PromotionStatus = "DISABLED"
+	// PromotionStatusInvalid is set by BigCommerce when a promotion refers to
+	// something that no longer exists, such as a deleted product.
+	PromotionStatusInvalid PromotionStatus = "INVALID"
+)
+
+// PromotionDiscountStrategy decides which items a discount applies to first when
+// only some of the matching items are discounted.
+type PromotionDiscountStrategy string
+
+const (
+	PromotionDiscountStrategyLeastExpensive PromotionDiscountStrategy = "LEAST_EXPENSIVE"
+	PromotionDiscountStrategyMostExpensive  PromotionDiscountStrategy = "MOST_EXPENSIVE"
+)
+
+type PromotionNotificationType string
+
+const (
+	PromotionNotificationTypeUpsell   PromotionNotificationType = "UPSELL"
+	PromotionNotificationTypeEligible PromotionNotificationType = "ELIGIBLE"
+	PromotionNotificationTypeApplied  PromotionNotificationType = "APPLIED"
+)
+
 type Promotion struct {
-	ID                       int                      `json:"id"`
-	RedemptionType           string                   `json:"redemption_type"`
-	Name                     string                   `json:"name"`
-	Channels                 []PromotionChannel       `json:"channels"`
-	Customer                 PromotionCustomer        `json:"customer"`
-	Segments                 PromotionSegments        `json:"segments"`
-	Status                   string                   `json:"status"`
-	StartDate                string                   `json:"start_date"`
-	EndDate                  string                   `json:"end_date"`
-	Stop                     bool                     `json:"stop"`
-	CanBeUsedWithOther       bool                     `json:"can_be_used_with_other_promotions"`
-	CurrencyCode             string                   `json:"currency_code"`
-	Notifications            []PromotionNotification  `json:"notifications"`
-	ShippingAddress          PromotionShippingAddress `json:"shipping_address"`
-	Schedule                 PromotionSchedule        `json:"schedule"`
-	CouponOverridesAutomatic bool                     `json:"coupon_overrides_automatic_when_offering_higher_discounts"`
+	ID                       int                       `json:"id,omitempty"`
+	RedemptionType           PromotionRedemptionType   `json:"redemption_type"`
+	Name                     string                    `json:"name"`
+	DisplayName              string                    `json:"display_name,omitempty"`
+	Channels                 []PromotionChannel        `json:"channels,omitempty"`
+	Customer                 *PromotionCustomer        `json:"customer,omitempty"`
+	Rules                    []PromotionRule           `json:"rules"`
+	CurrentUses              int                       `json:"current_uses"`
+	MaxUses                  int                       `json:"max_uses"`
+	Status                   PromotionStatus           `json:"status,omitempty"`
+	StartDate                string                    `json:"start_date,omitempty"`
+	EndDate                  string                    `json:"end_date,omitempty"`
+	Stop                     bool                      `json:"stop"`
+	CanBeUsedWithOther       bool                      `json:"can_be_used_with_other_promotions"`
+	CurrencyCode             string                    `json:"currency_code,omitempty"`
+	Notifications            []PromotionNotification   `json:"notifications,omitempty"`
+	ShippingAddress          *PromotionShippingAddress `json:"shipping_address,omitempty"`
+	Schedule                 *PromotionSchedule        `json:"schedule,omitempty"`
+	CouponOverridesAutomatic bool                      `json:"coupon_overrides_automatic_when_offering_higher_discounts"`
+	CreatedFrom              string                    `json:"created_from,omitempty"`
 }
 
 type PromotionSchedule struct {
-	WeekFrequency  int      `json:"week_frequency"`
-	WeekDays       []string `json:"week_days"`
-	DailyStartTime string   `json:"daily_start_time"`
-	DailyEndTime   string   `json:"daily_end_time"`
+	WeekFrequency  int      `json:"week_frequency,omitempty"`
+	WeekDays       []string `json:"week_days,omitempty"`
+	DailyStartTime string   `json:"daily_start_time,omitempty"`
+	DailyEndTime   string   `json:"daily_end_time,omitempty"`
 }
 
 type PromotionShippingAddress struct {
-	Countries []struct{} `json:"countries"`
+	Countries []PromotionCountry `json:"countries,omitempty"`
+}
+
+type PromotionCountry struct {
+	ISO2CountryCode string `json:"iso2_country_code"`
 }
 
 type PromotionNotification struct {
-	Content   string   `json:"content"`
-	Type      string   `json:"type"`
-	Locations []string `json:"locations"`
+	Content   string                    `json:"content"`
+	Type      PromotionNotificationType `json:"type"`
+	Locations []string                  `json:"locations"`
 }
+
 type PromotionChannel struct {
 	ID int `json:"id"`
 }
 
-type PromotionSegmentRuleCartValue struct {
-	Discount  string `json:"discount"`
-	ApplyOnce bool   `json:"apply_once"`
-	Stop      bool   `json:"stop"`
+type PromotionCustomer struct {
+	GroupIDs          []int                      `json:"group_ids,omitempty"`
+	MinimumOrderCount int                        `json:"minimum_order_count,omitempty"`
+	ExcludedGroupIDs  []int                      `json:"excluded_group_ids,omitempty"`
+	Segments          *PromotionCustomerSegments `json:"segments,omitempty"`
 }
 
-type PromotionSegmentRuleConditionCartItems struct {
-	Brands          []int  `json:"brands"`
-	MinimumSpend    string `json:"minimum_spend"`
-	MinimumQuantity int    `json:"minimum_quantity"`
+type PromotionCustomerSegments struct {
+	IDs []string `json:"id"`
 }
 
-type PromotionSegmentRuleConditionCart struct {
-	Items PromotionSegmentRuleConditionCartItems `json:"items"`
+// PromotionRule gives the action when the condition is met. A rule without a
+// condition always applies.
+type PromotionRule struct {
+	Action    PromotionAction     `json:"action"`
+	ApplyOnce bool                `json:"apply_once"`
+	Stop      bool                `json:"stop"`
+	Condition *PromotionCondition `json:"condition,omitempty"`
 }
 
-type PromotionSegmentRuleCondition struct {
-	Cart        PromotionSegmentRuleConditionCart `json:"cart"`
-	CurrentUses int                               `json:"current_uses"`
-	MaxUses     int                               `json:"max_uses"`
+// PromotionAction holds exactly one kind of action.
+type PromotionAction struct {
+	CartValue     *PromotionCartValueAction     `json:"cart_value,omitempty"`
+	CartItems     *PromotionCartItemsAction     `json:"cart_items,omitempty"`
+	GiftItem      *PromotionGiftItemAction      `json:"gift_item,omitempty"`
+	Shipping      *PromotionShippingAction      `json:"shipping,omitempty"`
+	FixedPriceSet *PromotionFixedPriceSetAction `json:"fixed_price_set,omitempty"`
 }
 
-type PromotionSegmentRule struct {
-	Action    string                        `json:"action"`
-	CartValue PromotionSegmentRuleCartValue `json:"cart_value"`
-	Condition PromotionSegmentRuleCondition `json:"condition"`
+// PromotionDiscount is either a fixed amount or a percentage, both as decimal strings.
+type PromotionDiscount struct {
+	FixedAmount      string `json:"fixed_amount,omitempty"`
+	PercentageAmount string `json:"percentage_amount,omitempty"`
 }
 
-type PromotionSegments struct {
-	IDs   []string               `json:"id"`
-	Rules []PromotionSegmentRule `json:"rules"`
+// PromotionCartValueAction discounts the cart subtotal.
+type PromotionCartValueAction struct {
+	Discount PromotionDiscount `json:"discount"`
 }
 
-type PromotionCustomer struct {
-	GroupIDs          []int `json:"group_ids"`
-	MinimumOrderCount int   `json:"minimum_order_count"`
-	ExcludedGroupIDs  []int `json:"excluded_group_ids"`
+// PromotionCartItemsAction discounts the items matched by Items.
+type PromotionCartItemsAction struct {
+	Discount                          PromotionDiscount         `json:"discount"`
+	AsTotal                           bool                      `json:"as_total,omitempty"`
+	IncludeItemsConsideredByCondition bool                      `json:"include_items_considered_by_condition,omitempty"`
+	ExcludeItemsOnSale                bool                      `json:"exclude_items_on_sale,omitempty"`
+	Items                             *PromotionItemMatcher     `json:"items,omitempty"`
+	Quantity                          int                       `json:"quantity,omitempty"`
+	Strategy                          PromotionDiscountStrategy `json:"strategy,omitempty"`
+	AddFreeItem                       bool                      `json:"add_free_item,omitempty"`
+}
+
+// PromotionGiftItemAction adds a free product or variant to the cart.
+type PromotionGiftItemAction struct {
+	Quantity  int `json:"quantity"`
+	ProductID int `json:"product_id,omitempty"`
+	VariantID int `json:"variant_id,omitempty"`
+}
+
+// PromotionShippingAction discounts shipping in the given zones.
+type PromotionShippingAction struct {
+	FreeShipping bool               `json:"free_shipping,omitempty"`
+	Discount     *PromotionDiscount `json:"discount,omitempty"`
+	ZoneIDs      PromotionZoneIDs   `json:"zone_ids"`
+}
+
+// PromotionFixedPriceSetAction sells Quantity of the matched items for FixedPrice.
+type PromotionFixedPriceSetAction struct {
+	Quantity                          int                       `json:"quantity"`
+	FixedPrice                        string                    `json:"fixed_price"`
+	Strategy                          PromotionDiscountStrategy `json:"strategy,omitempty"`
+	IncludeItemsConsideredByCondition bool                      `json:"include_items_considered_by_condition,omitempty"`
+	ExcludeItemsOnSale                bool                      `json:"exclude_items_on_sale,omitempty"`
+	Items                             *PromotionItemMatcher     `json:"items,omitempty"`
+}
+
+// PromotionZoneIDs is either every shipping zone, sent as "*", or a list of zone IDs.
+type PromotionZoneIDs struct {
+	All bool
+	IDs []int
+}
+
+func (zones PromotionZoneIDs) MarshalJSON() ([]byte, error) {
+	if zones.All {
+		return []byte(`"*"`), nil
+	}
+	if zones.IDs == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(zones.IDs)
+}
+
+func (zones *PromotionZoneIDs) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*zones = PromotionZoneIDs{}
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if s != "*" {
+			return fmt.Errorf("unexpected zone_ids value %q", s)
+		}
+		*zones = PromotionZoneIDs{All: true}
+		return nil
+	}
+
+	var ids []int
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return fmt.Errorf("failed to decode zone_ids: %w", err)
+	}
+	*zones = PromotionZoneIDs{IDs: ids}
+	return nil
+}
+
+// PromotionItemMatcher selects cart items. Set one field: a list of brand,
+// category, product or variant IDs, or a combination of other matchers.
+type PromotionItemMatcher struct {
+	Brands     []int                  `json:"brands,omitempty"`
+	Categories []int                  `json:"categories,omitempty"`
+	Products   []int                  `json:"products,omitempty"`
+	Variants   []int                  `json:"variants,omitempty"`
+	And        []PromotionItemMatcher `json:"and,omitempty"`
+	Or         []PromotionItemMatcher `json:"or,omitempty"`
+	Not        *PromotionItemMatcher  `json:"not,omitempty"`
+}
+
+// PromotionCondition is either a cart condition or a combination of other conditions.
+type PromotionCondition struct {
+	Cart *PromotionCartCondition `json:"cart,omitempty"`
+	And  []PromotionCondition    `json:"and,omitempty"`
+	Or   []PromotionCondition    `json:"or,omitempty"`
+	Not  *PromotionCondition     `json:"not,omitempty"`
+}
+
+// PromotionCartCondition is met when the cart, or the items matched by Items,
+// reach the minimum spend and quantity.
+type PromotionCartCondition struct {
+	Items           *PromotionItemMatcher `json:"items,omitempty"`
+	MinimumSpend    string                `json:"minimum_spend,omitempty"`
+	MinimumQuantity int                   `json:"minimum_quantity,omitempty"`
+}
+
+type PromotionSortField string
+
+const (
+	PromotionSortFieldID        PromotionSortField = "id"
+	PromotionSortFieldName      PromotionSortField = "name"
+	PromotionSortFieldPriority  PromotionSortField = "priority"
+	PromotionSortFieldStartDate PromotionSortField = "start_date"
+)
+
+type PromotionQueryParams struct {
+	ID             int                     `url:"id,omitempty"`
+	Name           string                  `url:"name,omitempty"`
+	Code           string                  `url:"code,omitempty"`
+	CurrencyCode   string                  `url:"currency_code,omitempty"`
+	RedemptionType PromotionRedemptionType `url:"redemption_type,omitempty"`
+	Status         PromotionStatus         `url:"status,omitempty"`
+	Channels       []int                   `url:"channels,omitempty,comma"`
+	Query          string                  `url:"query,omitempty"`
+	Sort           PromotionSortField      `url:"sort,omitempty"`
+	Direction      string                  `url:"direction,omitempty"`
+	Page           int                     `url:"page,omitempty"`
+	Limit          int                     `url:"limit,omitempty"`
+}
+
+type CreatePromotionParams struct {
+	Name            string                    `json:"name"`
+	DisplayName     string                    `json:"display_name,omitempty"`
+	RedemptionType  PromotionRedemptionType   `json:"redemption_type"`
+	Channels        []PromotionChannel        `json:"channels,omitempty"`
+	Customer        *PromotionCustomer        `json:"customer,omitempty"`
+	Rules           []PromotionRule           `json:"rules"`
+	MaxUses         int                       `json:"max_uses,omitempty"`
+	Status          PromotionStatus           `json:"status,omitempty"`
+	StartDate       string                    `json:"start_date,omitempty"`
+	EndDate         string                    `json:"end_date,omitempty"`
+	Stop            bool                      `json:"stop,omitempty"`
+	CurrencyCode    string                    `json:"currency_code,omitempty"`
+	Notifications   []PromotionNotification   `json:"notifications,omitempty"`
+	ShippingAddress *PromotionShippingAddress `json:"shipping_address,omitempty"`
+	Schedule        *PromotionSchedule        `json:"schedule,omitempty"`
+	// CanBeUsedWithOtherPromotions defaults to true when left nil.
+	CanBeUsedWithOtherPromotions                        *bool `json:"can_be_used_with_other_promotions,omitempty"`
+	CouponOverridesAutomaticWhenOfferingHigherDiscounts bool  `json:"coupon_overrides_automatic_when_offering_higher_discounts,omitempty"`
+}
+
+// PromotionUpdateParams only changes the fields that are set. Rules replaces
+// every rule of the promotion.
+type PromotionUpdateParams struct {
+	Name                                                string                    `json:"name,omitempty"`
+	DisplayName                                         string                    `json:"display_name,omitempty"`
+	Channels                                            []PromotionChannel        `json:"channels,omitempty"`
+	Customer                                            *PromotionCustomer        `json:"customer,omitempty"`
+	Rules                                               []PromotionRule           `json:"rules,omitempty"`
+	MaxUses                                             int                       `json:"max_uses,omitempty"`
+	Status                                              PromotionStatus           `json:"status,omitempty"`
+	StartDate                                           string                    `json:"start_date,omitempty"`
+	EndDate                                             string                    `json:"end_date,omitempty"`
+	Stop                                                *bool                     `json:"stop,omitempty"`
+	CanBeUsedWithOtherPromotions                        *bool                     `json:"can_be_used_with_other_promotions,omitempty"`
+	CurrencyCode                                        string                    `json:"currency_code,omitempty"`
+	Notifications                                       []PromotionNotification   `json:"notifications,omitempty"`
+	ShippingAddress                                     *PromotionShippingAddress `json:"shipping_address,omitempty"`
+	Schedule                                            *PromotionSchedule        `json:"schedule,omitempty"`
+	CouponOverridesAutomaticWhenOfferingHigherDiscounts *bool                     `json:"coupon_overrides_automatic_when_offering_higher_discounts,omitempty"`
 }
 
 func (c *V3Client) GetPromotion(id int) (Promotion, error) {
@@ -92,90 +313,71 @@ func (c *V3Client) GetPromotion(id int) (Promotion, error) {
 	path := c.constructURL("promotions", strconv.Itoa(id))
 	err := c.Get(path, &response)
 	if err != nil {
-		return response.Data, err
+		return response.Data, fmt.Errorf("failed to get promotion with ID %d: %w", id, err)
 	}
 
 	return response.Data, nil
 }
 
-type PromotionUpdateParams struct {
-	Name                                                string                `json:"name,omitempty" url:"name,omitempty"`
-	Channels                                            []PromotionChannel    `json:"channels,omitempty" url:"channels,omitempty"`
-	Customer                                            CustomerParams        `json:"customer,omitempty" url:"customer,omitempty"`
-	Rules                                               []RuleParams          `json:"rules,omitempty" url:"rules,omitempty"`
-	MaxUses                                             int                   `json:"max_uses,omitempty" url:"max_uses,omitempty"`
-	Status                                              string                `json:"status,omitempty" url:"status,omitempty"`
-	StartDate                                           string                `json:"start_date,omitempty" url:"start_date,omitempty"`
-	EndDate                                             string                `json:"end_date,omitempty" url:"end_date,omitempty"`
-	Stop                                                bool                  `json:"stop,omitempty" url:"stop,omitempty"`
-	CanBeUsedWithOtherPromotions                        bool                  `json:"can_be_used_with_other_promotions,omitempty" url:"can_be_used_with_other_promotions,omitempty"`
-	CurrencyCode                                        string                `json:"currency_code,omitempty" url:"currency_code,omitempty"`
-	Notifications                                       []NotificationParams  `json:"notifications,omitempty" url:"notifications,omitempty"`
-	ShippingAddress                                     ShippingAddressParams `json:"shipping_address,omitempty" url:"shipping_address,omitempty"`
-	Schedule                                            ScheduleParams        `json:"schedule,omitempty" url:"schedule,omitempty"`
-	CouponOverridesAutomaticWhenOfferingHigherDiscounts bool                  `json:"coupon_overrides_automatic_when_offering_higher_discounts,omitempty" url:"coupon_overrides_automatic_when_offering_higher_discounts,omitempty"`
-}
+func (c *V3Client) GetPromotions(params PromotionQueryParams) ([]Promotion, MetaData, error) {
+	type Response struct {
+		Data []Promotion `json:"data"`
+		Meta MetaData    `json:"meta"`
+	}
+	var response Response
 
-type CustomerParams struct {
-	GroupIDs          []int         `json:"group_ids,omitempty" url:"group_ids,omitempty"`
-	MinimumOrderCount int           `json:"minimum_order_count,omitempty" url:"minimum_order_count,omitempty"`
-	ExcludedGroupIDs  []int         `json:"excluded_group_ids,omitempty" url:"excluded_group_ids,omitempty"`
-	Segments          SegmentParams `json:"segments,omitempty" url:"segments,omitempty"`
-}
+	path, err := urlWithQueryParams(c.constructURL("promotions"), params)
+	if err != nil {
+		return response.Data, response.Meta, fmt.Errorf("failed to construct URL with query params: %w", err)
+	}
 
-type SegmentParams struct {
-	ID []string `json:"id,omitempty" url:"id,omitempty"`
-}
+	if err := c.Get(path, &response); err != nil {
+		return response.Data, response.Meta, fmt.Errorf("failed to get promotions: %w", err)
+	}
 
-type RuleParams struct {
-	Action    string          `json:"action,omitempty" url:"action,omitempty"`
-	ApplyOnce bool            `json:"apply_once,omitempty" url:"apply_once,omitempty"`
-	Stop      bool            `json:"stop,omitempty" url:"stop,omitempty"`
-	CartValue CartValueParams `json:"cart_value,omitempty" url:"cart_value,omitempty"`
-	Condition ConditionParams `json:"condition,omitempty" url:"condition,omitempty"`
+	return response.Data, response.Meta, nil
 }
 
-type CartValueParams struct {
-	Discount DiscountParams `json:"discount,omitempty" url:"discount,omitempty"`
-}
+// GetAllPromotions retrieves every promotion matching params. The Page field of
+// params is overwritten.
+func (c *V3Client) GetAllPromotions(params PromotionQueryParams) ([]Promotion, error) {
+	var promotions []Promotion
+	params.Page = 1
+	if params.Limit < 1 {
+		params.Limit = 250
+	}
 
-type DiscountParams struct {
-	FixedAmount string `json:"fixed_amount,omitempty" url:"fixed_amount,omitempty"`
-}
+	for {
+		p, meta, err := c.GetPromotions(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get all promotions at page %d: %w", params.Page, err)
+		}
+		promotions = append(promotions, p...)
 
-type ConditionParams struct {
-	Cart CartParams `json:"cart,omitempty" url:"cart,omitempty"`
-}
+		if meta.Pagination.CurrentPage >= meta.Pagination.TotalPages {
+			break
+		}
 
-type CartParams struct {
-	Items           CartItemParams `json:"items,omitempty" url:"items,omitempty"`
-	MinimumSpend    string         `json:"minimum_spend,omitempty" url:"minimum_spend,omitempty"`
-	MinimumQuantity int            `json:"minimum_quantity,omitempty" url:"minimum_quantity,omitempty"`
-}
+		params.Page++
+	}
 
-type CartItemParams struct {
-	Brands []int `json:"brands,omitempty" url:"brands,omitempty"`
+	return promotions, nil
 }
 
-type NotificationParams struct {
-	Content   string   `json:"content,omitempty" url:"content,omitempty"`
-	Type      string   `json:"type,omitempty" url:"type,omitempty"`
-	Locations []string `json:"locations,omitempty" url:"locations,omitempty"`
-}
+func (c *V3Client) CreatePromotion(params CreatePromotionParams) (Promotion, error) {
+	type Response struct {
+		Data Promotion `json:"data"`
+		Meta MetaData  `json:"meta"`
+	}
+	var response Response
 
-type ShippingAddressParams struct {
-	Countries []CountryParams `json:"countries,omitempty" url:"countries,omitempty"`
-}
+	path := c.constructURL("promotions")
 
-type CountryParams struct {
-	ISO2CountryCode string `json:"iso2_country_code,omitempty" url:"iso2_country_code,omitempty"`
-}
+	if err := c.Post(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to create promotion: %w", err)
+	}
 
-type ScheduleParams struct {
-	WeekFrequency  int      `json:"week_frequency,omitempty" url:"week_frequency,omitempty"`
-	WeekDays       []string `json:"week_days,omitempty" url:"week_days,omitempty"`
-	DailyStartTime string   `json:"daily_start_time,omitempty" url:"daily_start_time,omitempty"`
-	DailyEndTime   string   `json:"daily_end_time,omitempty" url:"daily_end_time,omitempty"`
+	return response.Data, nil
 }
 
 func (c *V3Client) UpdatePromotion(id int, params PromotionUpdateParams) (Promotion, error) {
@@ -189,8 +391,30 @@ func (c *V3Client) UpdatePromotion(id int, params PromotionUpdateParams) (Promot
 
 	err := c.Put(path, params, &response)
 	if err != nil {
-		return response.Data, err
+		return response.Data, fmt.Errorf("failed to update promotion with ID %d: %w", id, err)
 	}
 
 	return response.Data, nil
 }
+
+// DeletePromotions deletes the promotions with the given IDs.
+func (c *V3Client) DeletePromotions(ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	params := struct {
+		IDIn []int `url:"id:in,comma"`
+	}{IDIn: ids}
+
+	path, err := urlWithQueryParams(c.constructURL("promotions"), params)
+	if err != nil {
+		return fmt.Errorf("failed to construct URL with query params: %w", err)
+	}
+
+	if err := c.Delete(path, nil); err != nil {
+		return fmt.Errorf("failed to delete promotions %v: %w", ids, err)
+	}
+
+	return nil
+}
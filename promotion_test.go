@@ -0,0 +1,151 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const promotionJSON = `{
+	"id": 7,
+	"redemption_type": "AUTOMATIC",
+	"name": "Spring bundle",
+	"display_name": "Spring deals",
+	"channels": [{"id": 1}],
+	"customer": {
+		"group_ids": [2],
+		"minimum_order_count": 1,
+		"excluded_group_ids": [3],
+		"segments": {"id": ["0f3c7f2a-0d4d-4c55-8c51-3d2b9a7c1e11"]}
+	},
+	"rules": [
+		{
+			"action": {"cart_value": {"discount": {"fixed_amount": "10"}}},
+			"apply_once": true,
+			"stop": false,
+			"condition": {"cart": {"minimum_spend": "100"}}
+		},
+		{
+			"action": {
+				"cart_items": {
+					"discount": {"percentage_amount": "20"},
+					"as_total": true,
+					"exclude_items_on_sale": true,
+					"items": {"or": [{"categories": [18]}, {"and": [{"brands": [4]}, {"not": {"products": [81]}}]}]},
+					"quantity": 2,
+					"strategy": "LEAST_EXPENSIVE"
+				}
+			},
+			"apply_once": false,
+			"stop": true,
+			"condition": {
+				"and": [
+					{"cart": {"items": {"variants": [120]}, "minimum_quantity": 2}},
+					{"not": {"cart": {"items": {"brands": [9]}}}}
+				]
+			}
+		},
+		{"action": {"gift_item": {"quantity": 1, "product_id": 99}}, "apply_once": true, "stop": false},
+		{"action": {"shipping": {"free_shipping": true, "zone_ids": "*"}}, "apply_once": true, "stop": false},
+		{"action": {"shipping": {"discount": {"fixed_amount": "5"}, "zone_ids": [1, 2]}}, "apply_once": true, "stop": false},
+		{
+			"action": {"fixed_price_set": {"quantity": 3, "fixed_price": "25", "strategy": "MOST_EXPENSIVE", "items": {"categories": [21]}}},
+			"apply_once": false,
+			"stop": false,
+			"condition": {"or": [{"cart": {"minimum_spend": "50"}}, {"cart": {"minimum_quantity": 5}}]}
+		}
+	],
+	"current_uses": 12,
+	"max_uses": 100,
+	"status": "ENABLED",
+	"start_date": "2024-03-01T00:00:00+00:00",
+	"end_date": "2024-05-31T23:59:59+00:00",
+	"stop": false,
+	"can_be_used_with_other_promotions": true,
+	"currency_code": "EUR",
+	"notifications": [{"content": "Spend 100 to save 10", "type": "UPSELL", "locations": ["CART_PAGE"]}],
+	"shipping_address": {"countries": [{"iso2_country_code": "IE"}]},
+	"schedule": {"week_frequency": 1, "week_days": ["Monday", "Friday"], "daily_start_time": "09:00:00", "daily_end_time": "17:00:00"},
+	"coupon_overrides_automatic_when_offering_higher_discounts": false,
+	"created_from": "api"
+}`
+
+func TestPromotion_RoundTrip(t *testing.T) {
+	var promotion Promotion
+	if err := json.Unmarshal([]byte(promotionJSON), &promotion); err != nil {
+		t.Fatal(err)
+	}
+
+	if !promotion.Rules[3].Action.Shipping.ZoneIDs.All {
+		t.Error("expected zone_ids \"*\" to decode as all zones")
+	}
+	if ids := promotion.Rules[4].Action.Shipping.ZoneIDs.IDs; !reflect.DeepEqual(ids, []int{1, 2}) {
+		t.Errorf("unexpected zone IDs %v", ids)
+	}
+
+	b, err := json.Marshal(promotion)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want, got any
+	if err := json.Unmarshal([]byte(promotionJSON), &want); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("promotion did not round-trip:\nwant %v\ngot  %v", want, got)
+	}
+
+	// A promotion that hasn't been used yet keeps its zero counts.
+	promotion.CurrentUses, promotion.MaxUses = 0, 0
+	b, err = json.Marshal(promotion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"current_uses":0`) || !strings.Contains(string(b), `"max_uses":0`) {
+		t.Errorf("expected zero uses to be kept, got %s", b)
+	}
+}
+
+func TestGetAllPromotions(t *testing.T) {
+	var pages []string
+	client := newTestServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		pages = append(pages, query.Get("page"))
+		if query.Get("status") != "ENABLED" || query.Get("channels") != "1,2" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+
+		page := parseInt(query.Get("page"))
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": []Promotion{{ID: page, Name: "Promotion"}},
+			"meta": MetaData{Pagination: Pagination{CurrentPage: page, TotalPages: 2}},
+		})
+	}))
+
+	promotions, err := client.V3.GetAllPromotions(PromotionQueryParams{Status: PromotionStatusEnabled, Channels: []int{1, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(promotions) != 2 || !reflect.DeepEqual(pages, []string{"1", "2"}) {
+		t.Errorf("expected two pages, got %v from pages %v", promotions, pages)
+	}
+}
+
+func TestDeletePromotions(t *testing.T) {
+	client := newTestServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Query().Get("id:in") != "3,4" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	if err := client.V3.DeletePromotions([]int{3, 4}); err != nil {
+		t.Fatal(err)
+	}
+}
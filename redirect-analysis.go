@@ -0,0 +1,319 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// RedirectChain is a run of redirects where each one's target is redirected by the
+// next, on the same site. Shoppers and crawlers follow every hop, so chains are
+// best collapsed into a single redirect to the final target.
+type RedirectChain struct {
+	SiteID    int
+	Redirects []Redirect
+}
+
+// Paths returns the path of each hop, starting with the first from_path. The last
+// entry is where the final redirect points, and is a full URL when that is an
+// absolute url target.
+func (chain RedirectChain) Paths() []string {
+	paths := []string{}
+	for _, redirect := range chain.Redirects {
+		paths = append(paths, redirect.FromPath)
+	}
+	if len(chain.Redirects) > 0 {
+		last := chain.Redirects[len(chain.Redirects)-1]
+		if target, ok := redirectTarget(last); ok {
+			if target.Host != "" && last.To.Type == RedirectToURL {
+				paths = append(paths, target.String())
+			} else {
+				paths = append(paths, normalizeRedirectPath(target.Path))
+			}
+		}
+	}
+	return paths
+}
+
+type RedirectAnalysis struct {
+	// Chains start at a redirect that nothing else redirects to. A chain leading into
+	// a loop is reported here as well as the loop itself.
+	Chains []RedirectChain
+	// Loops are reported once each, starting at the redirect with the lowest ID.
+	Loops []RedirectChain
+	// Dangling holds redirects to a product, category, brand, page or post that no
+	// longer exists. It is only filled in when entities are given.
+	Dangling []Redirect
+}
+
+// RedirectEntities records which redirect target entities exist, by target type
+// and entity ID.
+type RedirectEntities map[RedirectTargetType]map[int]bool
+
+func (entities RedirectEntities) add(targetType RedirectTargetType, id int) {
+	if entities[targetType] == nil {
+		entities[targetType] = map[int]bool{}
+	}
+	entities[targetType][id] = true
+}
+
+// Exists reports whether the target of redirect exists. URL targets always exist.
+func (entities RedirectEntities) Exists(redirect Redirect) bool {
+	if redirect.To.Type == RedirectToURL {
+		return true
+	}
+	return entities[redirect.To.Type][redirect.To.EntityID]
+}
+
+// AnalyzeRedirects finds chains and loops in redirects and, when entities is not
+// nil, redirects whose target entity is gone.
+//
+// A redirect's target is matched against other redirects' from_path by path. For
+// url targets that is the path of To.URL, and for entity targets the path of
+// ToURL, so fetch redirects with include=to_url to follow entity targets too.
+// Absolute url targets are only followed when their host is the redirect's site,
+// looked up by site ID in siteURLs, such as RedirectResolver.SiteURLs. With nil
+// siteURLs they end the chain.
+func AnalyzeRedirects(redirects []Redirect, entities RedirectEntities, siteURLs map[int]string) RedirectAnalysis {
+	var analysis RedirectAnalysis
+
+	type siteKey struct {
+		siteID int
+		path   string
+	}
+
+	bySource := map[siteKey]Redirect{}
+	for _, redirect := range redirects {
+		bySource[siteKey{redirect.SiteID, normalizeRedirectPath(redirect.FromPath)}] = redirect
+	}
+
+	next := func(redirect Redirect) (Redirect, bool) {
+		target, ok := redirectTargetPath(redirect, siteURLs)
+		if !ok {
+			return Redirect{}, false
+		}
+		n, ok := bySource[siteKey{redirect.SiteID, target}]
+		return n, ok
+	}
+
+	targeted := map[int]bool{}
+	for _, redirect := range redirects {
+		if n, ok := next(redirect); ok {
+			targeted[n.ID] = true
+		}
+	}
+
+	loops := map[int]bool{}
+	for _, redirect := range redirects {
+		hops := []Redirect{redirect}
+		position := map[int]int{redirect.ID: 0}
+
+		current := redirect
+		for {
+			n, ok := next(current)
+			if !ok {
+				break
+			}
+			if start, seen := position[n.ID]; seen {
+				loop := rotateRedirectLoop(hops[start:])
+				if !loops[loop[0].ID] {
+					loops[loop[0].ID] = true
+					analysis.Loops = append(analysis.Loops, RedirectChain{SiteID: redirect.SiteID, Redirects: loop})
+				}
+				break
+			}
+			position[n.ID] = len(hops)
+			hops = append(hops, n)
+			current = n
+		}
+
+		if len(hops) > 1 && !targeted[redirect.ID] {
+			analysis.Chains = append(analysis.Chains, RedirectChain{SiteID: redirect.SiteID, Redirects: hops})
+		}
+
+		if entities != nil && !entities.Exists(redirect) {
+			analysis.Dangling = append(analysis.Dangling, redirect)
+		}
+	}
+
+	return analysis
+}
+
+// rotateRedirectLoop starts loop at its redirect with the lowest ID so that a loop
+// found from different redirects is reported the same way.
+func rotateRedirectLoop(loop []Redirect) []Redirect {
+	lowest := 0
+	for i, redirect := range loop {
+		if redirect.ID < loop[lowest].ID {
+			lowest = i
+		}
+	}
+	rotated := append([]Redirect{}, loop[lowest:]...)
+	return append(rotated, loop[:lowest]...)
+}
+
+// redirectTarget returns where redirect points, if known.
+func redirectTarget(redirect Redirect) (*url.URL, bool) {
+	target := redirect.ToURL
+	if redirect.To.Type == RedirectToURL && redirect.To.URL != "" {
+		target = redirect.To.URL
+	}
+	if target == "" {
+		return nil, false
+	}
+
+	u, err := url.Parse(target)
+	if err != nil || u.Path == "" {
+		return nil, false
+	}
+	return u, true
+}
+
+// redirectTargetPath returns the storefront path redirect points at on its own
+// site, if known. An absolute url target counts only when its host is the site's
+// in siteURLs, since a path on another host is a different page. ToURL of an
+// entity target is always on the redirect's site.
+func redirectTargetPath(redirect Redirect, siteURLs map[int]string) (string, bool) {
+	u, ok := redirectTarget(redirect)
+	if !ok {
+		return "", false
+	}
+
+	if u.Host != "" && redirect.To.Type == RedirectToURL {
+		site, err := url.Parse(siteURLs[redirect.SiteID])
+		if err != nil || site.Host == "" || !strings.EqualFold(u.Host, site.Host) {
+			return "", false
+		}
+	}
+
+	return normalizeRedirectPath(u.Path), true
+}
+
+// normalizeRedirectPath makes paths that only differ by a trailing slash equal.
+func normalizeRedirectPath(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path
+}
+
+// LoadRedirectEntities looks up which of the products, categories, brands, pages
+// and blog posts targeted by redirects still exist, for AnalyzeRedirects.
+func (client *Client) LoadRedirectEntities(redirects []Redirect) (RedirectEntities, error) {
+	paths, err := client.loadRedirectEntityPaths(redirects)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := RedirectEntities{}
+	for targetType, byID := range paths {
+		for id := range byID {
+			entities.add(targetType, id)
+		}
+	}
+
+	return entities, nil
+}
+
+// loadRedirectEntityPaths returns the storefront path of every existing entity
+// targeted by redirects, by target type and entity ID.
+func (client *Client) loadRedirectEntityPaths(redirects []Redirect) (map[RedirectTargetType]map[int]string, error) {
+	wanted := map[RedirectTargetType][]int{}
+	seen := map[RedirectTargetType]map[int]bool{}
+	for _, redirect := range redirects {
+		targetType := redirect.To.Type
+		if targetType == RedirectToURL || redirect.To.EntityID == 0 {
+			continue
+		}
+		if seen[targetType] == nil {
+			seen[targetType] = map[int]bool{}
+		}
+		if !seen[targetType][redirect.To.EntityID] {
+			seen[targetType][redirect.To.EntityID] = true
+			wanted[targetType] = append(wanted[targetType], redirect.To.EntityID)
+		}
+	}
+
+	paths := map[RedirectTargetType]map[int]string{}
+
+	const batchSize = 50
+	for targetType, ids := range wanted {
+		sort.Ints(ids)
+		paths[targetType] = map[int]string{}
+
+		if targetType == RedirectToPost {
+			posts, err := client.V2.GetAllBlogPosts(BlogQueryParams{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to load redirect targets: %w", err)
+			}
+			for _, post := range posts {
+				paths[targetType][post.ID] = post.URL
+			}
+			continue
+		}
+
+		for start := 0; start < len(ids); start += batchSize {
+			batch := ids[start:minInt(start+batchSize, len(ids))]
+
+			found, err := client.V3.redirectEntityPaths(targetType, batch)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load redirect targets: %w", err)
+			}
+			for id, path := range found {
+				paths[targetType][id] = path
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+func (client *V3Client) redirectEntityPaths(targetType RedirectTargetType, ids []int) (map[int]string, error) {
+	found := map[int]string{}
+
+	switch targetType {
+	case RedirectToProduct:
+		products, _, err := client.GetProducts(ProductQueryParams{IDIn: ids, Limit: len(ids), IncludeFields: []string{"custom_url"}})
+		if err != nil {
+			return nil, err
+		}
+		for _, product := range products {
+			found[product.ID] = product.CustomURL.URL
+		}
+	case RedirectToCategory:
+		categories, err := client.GetAllCategories(CategoryQueryParams{IDIn: ids, IncludeFields: "custom_url"})
+		if err != nil {
+			return nil, err
+		}
+		for _, category := range categories {
+			found[category.ID] = category.CustomURL.URL
+		}
+	case RedirectToBrand:
+		brands, err := client.GetAllBrands(BrandQueryParams{IDIn: ids, IncludeFields: "custom_url"})
+		if err != nil {
+			return nil, err
+		}
+		for _, brand := range brands {
+			found[brand.ID] = brand.CustomURL.URL
+		}
+	case RedirectToPage:
+		pages, err := client.GetAllPages(GetPagesParams{IDIn: ids})
+		if err != nil {
+			return nil, err
+		}
+		for _, page := range pages {
+			found[page.ID] = page.URL
+		}
+	default:
+		return nil, fmt.Errorf("unknown redirect target type %q", targetType)
+	}
+
+	return found, nil
+}
@@ -0,0 +1,45 @@
+package bigcommerce
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeRedirects(t *testing.T) {
+	redirects := []Redirect{
+		// /a -> /b -> /c/ -> product 10
+		{ID: 1, SiteID: 1, FromPath: "/a", To: RedirectToObject{Type: "url", URL: "/b"}},
+		{ID: 2, SiteID: 1, FromPath: "/b", To: RedirectToObject{Type: "url", URL: "https://store.example.com/c/"}},
+		{ID: 3, SiteID: 1, FromPath: "/c", To: RedirectToObject{Type: "product", EntityID: 10}, ToURL: "https://store.example.com/widget/"},
+		// /x -> /y -> /x
+		{ID: 5, SiteID: 1, FromPath: "/y", To: RedirectToObject{Type: "url", URL: "/x"}},
+		{ID: 4, SiteID: 1, FromPath: "/x", To: RedirectToObject{Type: "url", URL: "/y"}},
+		// Same paths on another site are unrelated.
+		{ID: 6, SiteID: 2, FromPath: "/a", To: RedirectToObject{Type: "category", EntityID: 99}},
+		// So is the same path on another host.
+		{ID: 7, SiteID: 1, FromPath: "/partner", To: RedirectToObject{Type: "url", URL: "https://partner.example.com/a"}},
+	}
+
+	entities := RedirectEntities{}
+	entities.add("product", 10)
+
+	analysis := AnalyzeRedirects(redirects, entities, map[int]string{1: "https://store.example.com"})
+
+	if len(analysis.Chains) != 1 {
+		t.Fatalf("expected 1 chain, got %+v", analysis.Chains)
+	}
+	if paths := analysis.Chains[0].Paths(); !reflect.DeepEqual(paths, []string{"/a", "/b", "/c", "/widget"}) {
+		t.Errorf("unexpected chain %v", paths)
+	}
+
+	if len(analysis.Loops) != 1 {
+		t.Fatalf("expected 1 loop, got %+v", analysis.Loops)
+	}
+	if first := analysis.Loops[0].Redirects[0].ID; first != 4 {
+		t.Errorf("expected loop to start at redirect 4, got %d", first)
+	}
+
+	if len(analysis.Dangling) != 1 || analysis.Dangling[0].ID != 6 {
+		t.Errorf("expected redirect 6 to dangle, got %+v", analysis.Dangling)
+	}
+}
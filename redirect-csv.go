@@ -0,0 +1,164 @@
+package bigcommerce
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// RedirectCSVColumns are the columns written by WriteRedirectsCSV. ReadRedirectsCSV
+// needs from_path, site_id and to_type and ignores id and resolved_url, so an
+// exported file can be edited and imported again.
+var RedirectCSVColumns = []string{"id", "site_id", "from_path", "to_type", "to_entity_id", "to_url", "resolved_url"}
+
+// WriteRedirectsCSV writes redirects as CSV with a header row. resolved_url is the
+// redirect's ToURL, which is only filled in when the redirects were fetched with
+// include=to_url.
+func WriteRedirectsCSV(w io.Writer, redirects []Redirect) error {
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{RedirectCSVColumns}
+	for _, redirect := range redirects {
+		entityID := ""
+		if redirect.To.EntityID != 0 {
+			entityID = strconv.Itoa(redirect.To.EntityID)
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(redirect.ID),
+			strconv.Itoa(redirect.SiteID),
+			redirect.FromPath,
+			string(redirect.To.Type),
+			entityID,
+			redirect.To.URL,
+			redirect.ToURL,
+		})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write redirects CSV: %w", err)
+	}
+	return nil
+}
+
+// ReadRedirectsCSV reads redirects written by WriteRedirectsCSV or kept in a
+// spreadsheet with the same column names, in any order. Every row is checked with
+// the rules UpsertRedirects applies, and all problems are returned together as
+// ValidationErrors naming the line they are on.
+func ReadRedirectsCSV(r io.Reader) ([]RedirectUpsert, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read redirects CSV: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"from_path", "site_id", "to_type"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("failed to read redirects CSV: missing %s column", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var redirects []RedirectUpsert
+	var errs ValidationErrors
+	seen := map[string]int{}
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read redirects CSV: %w", err)
+		}
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		redirect := RedirectUpsert{
+			FromPath: field(record, "from_path"),
+			To: RedirectTarget{
+				Type: RedirectTargetType(strings.ToLower(field(record, "to_type"))),
+				URL:  field(record, "to_url"),
+			},
+		}
+
+		siteID := field(record, "site_id")
+		if redirect.SiteID, err = strconv.Atoi(siteID); err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: site_id %q is not a number", line, siteID))
+			continue
+		}
+		if entityID := field(record, "to_entity_id"); entityID != "" {
+			if redirect.To.EntityID, err = strconv.Atoi(entityID); err != nil {
+				errs = append(errs, fmt.Sprintf("line %d: to_entity_id %q is not a number", line, entityID))
+				continue
+			}
+		}
+
+		if err := validateRedirectUpsert(redirect); err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+
+		key := strconv.Itoa(redirect.SiteID) + " " + redirect.FromPath
+		if previous, ok := seen[key]; ok {
+			errs = append(errs, fmt.Sprintf("line %d: %s is already redirected on line %d", line, redirect.FromPath, previous))
+			continue
+		}
+		seen[key] = line
+
+		redirects = append(redirects, redirect)
+	}
+
+	if len(errs) > 0 {
+		return redirects, errs
+	}
+
+	return redirects, nil
+}
+
+// ExportRedirectsCSV writes every redirect matching params to w as CSV, including
+// each redirect's resolved target URL.
+func (client *V3Client) ExportRedirectsCSV(w io.Writer, params RedirectQueryParams) (int, error) {
+	params.Include = RedirectIncludeToURL
+
+	redirects, err := client.GetAllRedirects(params)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := WriteRedirectsCSV(w, redirects); err != nil {
+		return 0, err
+	}
+
+	return len(redirects), nil
+}
+
+// ImportRedirectsCSV reads redirects from r and upserts them. Nothing is sent if
+// any row is invalid.
+func (client *V3Client) ImportRedirectsCSV(r io.Reader) ([]Redirect, error) {
+	redirects, err := ReadRedirectsCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.UpsertRedirects(redirects)
+}
@@ -0,0 +1,132 @@
+package bigcommerce
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeRedirectStore struct {
+	mu        sync.Mutex
+	redirects []Redirect
+	puts      []int
+}
+
+func (store *fakeRedirectStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		redirects := store.redirects
+		if keyword := r.URL.Query().Get("keyword"); keyword != "" {
+			redirects = nil
+			for _, redirect := range store.redirects {
+				if strings.Contains(redirect.FromPath, keyword) || strings.Contains(redirect.To.URL, keyword) {
+					redirects = append(redirects, redirect)
+				}
+			}
+		}
+		start := minInt((page-1)*limit, len(redirects))
+		end := minInt(start+limit, len(redirects))
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": redirects[start:end]})
+	case http.MethodPut:
+		var upserts []RedirectUpsert
+		json.NewDecoder(r.Body).Decode(&upserts)
+		store.puts = append(store.puts, len(upserts))
+		saved := []Redirect{}
+	upserts:
+		for _, upsert := range upserts {
+			redirect := Redirect{
+				ID:       len(store.redirects) + 1,
+				SiteID:   upsert.SiteID,
+				FromPath: upsert.FromPath,
+				To:       RedirectToObject{Type: upsert.To.Type, EntityID: upsert.To.EntityID, URL: upsert.To.URL},
+			}
+			// Like BigCommerce, a redirect from the same path on the same site is replaced.
+			for i, existing := range store.redirects {
+				if existing.SiteID == upsert.SiteID && existing.FromPath == upsert.FromPath {
+					redirect.ID = existing.ID
+					store.redirects[i] = redirect
+					saved = append(saved, redirect)
+					continue upserts
+				}
+			}
+			store.redirects = append(store.redirects, redirect)
+			saved = append(saved, redirect)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": saved})
+	}
+}
+
+func TestImportExportRedirectsCSV(t *testing.T) {
+	store := &fakeRedirectStore{}
+	client := newTestServerClient(t, store)
+
+	var csv strings.Builder
+	csv.WriteString("from_path,site_id,to_type,to_entity_id,to_url\n")
+	for i := 0; i < 260; i++ {
+		csv.WriteString("/old-" + strconv.Itoa(i) + ",1,product," + strconv.Itoa(i+1) + ",\n")
+	}
+	csv.WriteString("/old-page,1,url,,/new-page\n")
+
+	saved, err := client.V3.ImportRedirectsCSV(strings.NewReader(csv.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 261 {
+		t.Errorf("expected 261 redirects saved, got %d", len(saved))
+	}
+	for _, size := range store.puts {
+		if size > RedirectUpsertBatchSize {
+			t.Errorf("sent %d redirects in one request", size)
+		}
+	}
+
+	// 261 redirects span two pages of 250, and the short last page must be kept.
+	var out bytes.Buffer
+	n, err := client.V3.ExportRedirectsCSV(&out, RedirectQueryParams{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 261 {
+		t.Errorf("expected 261 redirects exported, got %d", n)
+	}
+
+	reimported, err := ReadRedirectsCSV(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reimported) != 261 || reimported[260].To.URL != "/new-page" {
+		t.Errorf("export did not round trip: %+v", reimported[len(reimported)-1])
+	}
+}
+
+func TestReadRedirectsCSV_Invalid(t *testing.T) {
+	input := "site_id,from_path,to_type,to_entity_id\n" +
+		"1,/a,product,5\n" +
+		"x,/b,product,5\n" +
+		"1,/c,widget,5\n" +
+		"1,/d,category,\n" +
+		"1,/a,brand,3\n"
+
+	_, err := ReadRedirectsCSV(strings.NewReader(input))
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	if len(errs) != 4 {
+		t.Fatalf("expected 4 errors, got %v", errs)
+	}
+	for i, line := range []string{"line 3", "line 4", "line 5", "line 6"} {
+		if !strings.HasPrefix(errs[i], line) {
+			t.Errorf("expected error on %s, got %q", line, errs[i])
+		}
+	}
+}
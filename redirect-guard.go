@@ -0,0 +1,139 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultRedirectSiteID is the site of the default storefront channel.
+const DefaultRedirectSiteID = 1000
+
+type RedirectGuardConfig struct {
+	// SiteID is the site redirects are created on. Defaults to DefaultRedirectSiteID.
+	SiteID int
+}
+
+// RedirectGuardError is returned when a product, category or brand was updated
+// but its old URL could not be redirected. The update itself is not undone, and
+// the updated entity is returned alongside the error.
+type RedirectGuardError struct {
+	EntityType RedirectTargetType
+	EntityID   int
+	OldURL     string
+	NewURL     string
+	Err        error
+}
+
+func (e *RedirectGuardError) Error() string {
+	return fmt.Sprintf("%s %d moved from %s to %s but the redirect failed: %v", e.EntityType, e.EntityID, e.OldURL, e.NewURL, e.Err)
+}
+
+func (e *RedirectGuardError) Unwrap() error {
+	return e.Err
+}
+
+// EnableRedirectGuard makes UpdateProduct, UpdateCategory and UpdateBrand redirect
+// the old custom URL whenever an update changes it. The redirect points at the
+// entity rather than the new URL, so it keeps working through later changes.
+// Existing relative URL redirects to the old URL are pointed at the entity too,
+// and a redirect from the new URL, which would now shadow the entity, is deleted.
+//
+// Each guarded URL change costs a read before the update and, after it, reads of
+// the site's redirects filtered by keyword to those mentioning the old or new
+// URL. Enable the guard before the client is shared between goroutines.
+func (client *V3Client) EnableRedirectGuard(config RedirectGuardConfig) {
+	if config.SiteID == 0 {
+		config.SiteID = DefaultRedirectSiteID
+	}
+	client.redirectGuard = &config
+}
+
+func (client *V3Client) DisableRedirectGuard() {
+	client.redirectGuard = nil
+}
+
+// guardURLChange redirects oldURL to the entity once its URL has become newURL.
+func (client *V3Client) guardURLChange(entityType RedirectTargetType, entityID int, oldURL, newURL string) error {
+	if client.redirectGuard == nil || oldURL == "" || newURL == "" {
+		return nil
+	}
+
+	oldPath := normalizeRedirectPath(oldURL)
+	newPath := normalizeRedirectPath(newURL)
+	if oldPath == newPath {
+		return nil
+	}
+
+	fail := func(err error) error {
+		return &RedirectGuardError{EntityType: entityType, EntityID: entityID, OldURL: oldURL, NewURL: newURL, Err: err}
+	}
+
+	siteID := client.redirectGuard.SiteID
+	target := RedirectTarget{Type: entityType, EntityID: entityID}
+
+	existing, err := client.redirectsMentioning(siteID, oldPath, newPath)
+	if err != nil {
+		return fail(err)
+	}
+
+	var fromOld, toOld []RedirectUpsert
+	var shadowing []int
+
+	for _, redirect := range existing {
+		if redirect.SiteID != siteID {
+			continue
+		}
+
+		fromPath := normalizeRedirectPath(redirect.FromPath)
+		switch {
+		case fromPath == oldPath:
+			// Upserting the stored from path replaces the redirect, where oldURL
+			// could add /old/ beside an existing /old.
+			fromOld = append(fromOld, RedirectUpsert{FromPath: redirect.FromPath, SiteID: siteID, To: target})
+		case fromPath == newPath:
+			shadowing = append(shadowing, redirect.ID)
+		case redirect.To.Type == RedirectToURL && strings.HasPrefix(redirect.To.URL, "/") && normalizeRedirectPath(redirect.To.URL) == oldPath:
+			toOld = append(toOld, RedirectUpsert{FromPath: redirect.FromPath, SiteID: siteID, To: target})
+		}
+	}
+
+	if len(fromOld) == 0 {
+		fromOld = []RedirectUpsert{{FromPath: oldURL, SiteID: siteID, To: target}}
+	}
+	upserts := append(fromOld, toOld...)
+
+	if _, err := client.UpsertRedirects(upserts); err != nil {
+		return fail(err)
+	}
+
+	if len(shadowing) > 0 {
+		if err := client.DeleteRedirect(DeleteRedirectsParams{ID: shadowing, SiteID: siteID}); err != nil {
+			return fail(err)
+		}
+	}
+
+	return nil
+}
+
+// redirectsMentioning returns the site's redirects whose from path or target
+// contains one of paths, using the keyword filter rather than reading every
+// redirect on the site.
+func (client *V3Client) redirectsMentioning(siteID int, paths ...string) ([]Redirect, error) {
+	var redirects []Redirect
+	seen := map[int]bool{}
+
+	for _, path := range paths {
+		found, err := client.GetAllRedirects(RedirectQueryParams{SiteID: siteID, Keyword: path})
+		if err != nil {
+			return nil, err
+		}
+		for _, redirect := range found {
+			if !seen[redirect.ID] {
+				seen[redirect.ID] = true
+				redirects = append(redirects, redirect)
+			}
+		}
+	}
+
+	return redirects, nil
+}
@@ -0,0 +1,115 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type fakeGuardedStore struct {
+	fakeRedirectStore
+	productURL string
+	deleted    string
+	failUpsert bool
+	keywords   []string
+}
+
+func (store *fakeGuardedStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/stores/test/v3/storefront/redirects") {
+		switch {
+		case r.Method == http.MethodDelete:
+			store.deleted = r.URL.Query().Get("id:in")
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet:
+			store.keywords = append(store.keywords, r.URL.Query().Get("keyword"))
+			store.fakeRedirectStore.ServeHTTP(w, r)
+		case r.Method == http.MethodPut && store.failUpsert:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		default:
+			store.fakeRedirectStore.ServeHTTP(w, r)
+		}
+		return
+	}
+
+	if r.Method == http.MethodPut {
+		var params UpdateProductParams
+		json.NewDecoder(r.Body).Decode(&params)
+		if params.CustomURL != nil {
+			store.productURL = params.CustomURL.URL
+		}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"data": Product{ID: 7, CustomURL: CustomURL{URL: store.productURL}},
+	})
+}
+
+func TestRedirectGuard(t *testing.T) {
+	store := &fakeGuardedStore{productURL: "/old-widget/"}
+	store.redirects = []Redirect{
+		{ID: 1, SiteID: 1000, FromPath: "/older-widget/", To: RedirectToObject{Type: "url", URL: "/old-widget/"}},
+		{ID: 2, SiteID: 1000, FromPath: "/new-widget/", To: RedirectToObject{Type: "url", URL: "/elsewhere/"}},
+		{ID: 3, SiteID: 1000, FromPath: "/unrelated/", To: RedirectToObject{Type: "url", URL: "https://example.com/old-widget/"}},
+		{ID: 4, SiteID: 1000, FromPath: "/old-widget", To: RedirectToObject{Type: "url", URL: "/somewhere/"}},
+	}
+	client := newTestServerClient(t, store)
+
+	// Without the guard nothing is redirected.
+	if _, err := client.V3.UpdateProduct(7, UpdateProductParams{CustomURL: &CustomURL{URL: "/old-widget/"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.puts) != 0 {
+		t.Fatal("redirects changed without the guard")
+	}
+
+	client.V3.EnableRedirectGuard(RedirectGuardConfig{})
+
+	// Updates that leave the URL alone don't touch redirects.
+	if _, err := client.V3.UpdateProduct(7, UpdateProductParams{Name: "Widget"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.puts) != 0 {
+		t.Fatal("redirects changed without a URL change")
+	}
+
+	product, err := client.V3.UpdateProduct(7, UpdateProductParams{CustomURL: &CustomURL{URL: "/new-widget/"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if product.CustomURL.URL != "/new-widget/" {
+		t.Errorf("unexpected product URL %q", product.CustomURL.URL)
+	}
+
+	// The existing redirect from /old-widget is replaced rather than joined by
+	// one from /old-widget/.
+	if len(store.redirects) != 4 || len(store.puts) != 1 || store.puts[0] != 2 {
+		t.Fatalf("expected 2 redirects replaced, got %+v", store.redirects)
+	}
+	for _, i := range []int{0, 3} {
+		if redirect := store.redirects[i]; redirect.To.Type != "product" || redirect.To.EntityID != 7 || redirect.SiteID != 1000 {
+			t.Errorf("unexpected redirect %+v", redirect)
+		}
+	}
+	if store.redirects[2].To.Type != "url" {
+		t.Errorf("absolute redirect should be left alone, got %+v", store.redirects[2])
+	}
+	for _, keyword := range store.keywords {
+		if keyword != "/old-widget" && keyword != "/new-widget" {
+			t.Errorf("expected redirects read by keyword, got %q", keyword)
+		}
+	}
+	if len(store.keywords) != 2 {
+		t.Errorf("expected one read per path, got %v", store.keywords)
+	}
+	if store.deleted != "2" {
+		t.Errorf("expected the redirect from the new URL to be deleted, got %q", store.deleted)
+	}
+
+	store.failUpsert = true
+	_, err = client.V3.UpdateProduct(7, UpdateProductParams{CustomURL: &CustomURL{URL: "/newest-widget/"}})
+	var guardErr *RedirectGuardError
+	if !errors.As(err, &guardErr) || guardErr.OldURL != "/new-widget/" {
+		t.Errorf("expected RedirectGuardError, got %v", err)
+	}
+}
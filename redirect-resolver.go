@@ -0,0 +1,163 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RedirectResolver turns redirect targets into absolute storefront URLs without a
+// request per redirect, as an alternative to fetching with RedirectIncludeToURL.
+type RedirectResolver struct {
+	// SiteURLs maps site IDs to the site's base URL, such as https://example.com.
+	SiteURLs map[int]string
+	// EntityPaths maps target types and entity IDs to the entity's storefront path.
+	EntityPaths map[RedirectTargetType]map[int]string
+}
+
+// NewRedirectResolver loads the base URL of every site in redirects and the
+// storefront path of every product, category, brand, page and blog post they
+// target.
+func (client *Client) NewRedirectResolver(redirects []Redirect) (*RedirectResolver, error) {
+	resolver := &RedirectResolver{SiteURLs: map[int]string{}}
+
+	for _, redirect := range redirects {
+		if _, ok := resolver.SiteURLs[redirect.SiteID]; ok {
+			continue
+		}
+		site, err := client.V3.GetSite(redirect.SiteID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create redirect resolver: %w", err)
+		}
+		resolver.SiteURLs[redirect.SiteID] = site.URL
+	}
+
+	paths, err := client.loadRedirectEntityPaths(redirects)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create redirect resolver: %w", err)
+	}
+	resolver.EntityPaths = paths
+
+	return resolver, nil
+}
+
+// TargetURL returns the absolute URL redirect sends shoppers to. Absolute url
+// targets are returned as they are, and relative ones are joined to the site's
+// base URL.
+func (resolver *RedirectResolver) TargetURL(redirect Redirect) (string, error) {
+	path := redirect.To.URL
+
+	if redirect.To.Type == RedirectToURL {
+		if u, err := url.Parse(path); err == nil && u.IsAbs() {
+			return path, nil
+		}
+	} else {
+		entityPath, ok := resolver.EntityPaths[redirect.To.Type][redirect.To.EntityID]
+		if !ok {
+			return "", fmt.Errorf("redirect %d targets %s %d, which was not found", redirect.ID, redirect.To.Type, redirect.To.EntityID)
+		}
+		path = entityPath
+	}
+
+	base, ok := resolver.SiteURLs[redirect.SiteID]
+	if !ok || base == "" {
+		return "", fmt.Errorf("redirect %d is on site %d, which has no known URL", redirect.ID, redirect.SiteID)
+	}
+
+	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(path, "/"), nil
+}
+
+// Resolve returns copies of redirects with ToURL filled in. Redirects whose target
+// can't be resolved, such as ones to a deleted product, are returned separately
+// and left out of resolved.
+func (resolver *RedirectResolver) Resolve(redirects []Redirect) (resolved []Redirect, unresolved []Redirect) {
+	for _, redirect := range redirects {
+		target, err := resolver.TargetURL(redirect)
+		if err != nil {
+			unresolved = append(unresolved, redirect)
+			continue
+		}
+		redirect.ToURL = target
+		resolved = append(resolved, redirect)
+	}
+	return resolved, unresolved
+}
+
+// RedirectTable answers where a path goes using a local copy of the redirects,
+// following chains the way a browser would.
+type RedirectTable struct {
+	bySource map[int]map[string]Redirect
+	siteURLs map[int]string
+}
+
+// RedirectLookup is the result of looking up a path in a RedirectTable.
+type RedirectLookup struct {
+	// Redirects are the redirects followed, in order.
+	Redirects []Redirect
+	// Destination is where the last redirect points: its ToURL when known and
+	// otherwise its To.URL. Resolve redirects first to get destinations for entity
+	// targets.
+	Destination string
+	// Loop is true when the redirects lead back to a path already visited.
+	Loop bool
+}
+
+// NewRedirectTable indexes redirects by site and from_path. Paths that only differ
+// by a trailing slash or query string are treated as the same path. siteURLs maps
+// site IDs to base URLs, such as RedirectResolver.SiteURLs, and is used to tell
+// which absolute targets are on the site. It may be nil.
+func NewRedirectTable(redirects []Redirect, siteURLs map[int]string) *RedirectTable {
+	table := &RedirectTable{bySource: map[int]map[string]Redirect{}, siteURLs: siteURLs}
+	for _, redirect := range redirects {
+		if table.bySource[redirect.SiteID] == nil {
+			table.bySource[redirect.SiteID] = map[string]Redirect{}
+		}
+		table.bySource[redirect.SiteID][normalizeRedirectPath(redirect.FromPath)] = redirect
+	}
+	return table
+}
+
+// Lookup reports where path goes on the site, following any chain of redirects.
+// path may also be a full URL, in which case only its path is used. As with
+// AnalyzeRedirects, absolute url targets are only followed when their host is the
+// site's. The second result is false when path isn't redirected.
+func (table *RedirectTable) Lookup(siteID int, path string) (RedirectLookup, bool) {
+	var lookup RedirectLookup
+
+	if u, err := url.Parse(path); err == nil && u.IsAbs() {
+		path = u.Path
+	}
+
+	visited := map[string]bool{}
+	current := normalizeRedirectPath(path)
+	for {
+		redirect, ok := table.bySource[siteID][current]
+		if !ok {
+			break
+		}
+		if visited[current] {
+			lookup.Loop = true
+			break
+		}
+		visited[current] = true
+		lookup.Redirects = append(lookup.Redirects, redirect)
+
+		next, ok := redirectTargetPath(redirect, table.siteURLs)
+		if !ok {
+			break
+		}
+		current = next
+	}
+
+	if len(lookup.Redirects) == 0 {
+		return lookup, false
+	}
+
+	last := lookup.Redirects[len(lookup.Redirects)-1]
+	lookup.Destination = last.ToURL
+	if lookup.Destination == "" {
+		lookup.Destination = last.To.URL
+	}
+
+	return lookup, true
+}
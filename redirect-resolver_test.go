@@ -0,0 +1,81 @@
+package bigcommerce
+
+import (
+	"testing"
+)
+
+func TestRedirectResolver(t *testing.T) {
+	resolver := &RedirectResolver{
+		SiteURLs: map[int]string{1000: "https://store.example.com/"},
+		EntityPaths: map[RedirectTargetType]map[int]string{
+			RedirectToProduct: {10: "/widget/"},
+			RedirectToPost:    {3: "/blog/hello/"},
+		},
+	}
+
+	redirects := []Redirect{
+		{ID: 1, SiteID: 1000, FromPath: "/old-widget", To: RedirectToObject{Type: RedirectToProduct, EntityID: 10}},
+		{ID: 2, SiteID: 1000, FromPath: "/news/hello", To: RedirectToObject{Type: RedirectToPost, EntityID: 3}},
+		{ID: 3, SiteID: 1000, FromPath: "/sale", To: RedirectToObject{Type: RedirectToURL, URL: "/old-widget/"}},
+		{ID: 4, SiteID: 1000, FromPath: "/partner", To: RedirectToObject{Type: RedirectToURL, URL: "https://partner.example.com/"}},
+		{ID: 5, SiteID: 1000, FromPath: "/gone", To: RedirectToObject{Type: RedirectToBrand, EntityID: 8}},
+	}
+
+	resolved, unresolved := resolver.Resolve(redirects)
+	if len(unresolved) != 1 || unresolved[0].ID != 5 {
+		t.Errorf("expected redirect 5 unresolved, got %+v", unresolved)
+	}
+
+	expected := []string{
+		"https://store.example.com/widget/",
+		"https://store.example.com/blog/hello/",
+		"https://store.example.com/old-widget/",
+		"https://partner.example.com/",
+	}
+	for i, redirect := range resolved {
+		if redirect.ToURL != expected[i] {
+			t.Errorf("redirect %d: expected %s, got %s", redirect.ID, expected[i], redirect.ToURL)
+		}
+	}
+
+	table := NewRedirectTable(resolved, resolver.SiteURLs)
+
+	lookup, ok := table.Lookup(1000, "https://store.example.com/sale/")
+	if !ok {
+		t.Fatal("expected /sale to be redirected")
+	}
+	if len(lookup.Redirects) != 2 || lookup.Destination != "https://store.example.com/widget/" {
+		t.Errorf("unexpected lookup %+v", lookup)
+	}
+
+	if _, ok := table.Lookup(1000, "/widget/"); ok {
+		t.Error("expected /widget/ not to be redirected")
+	}
+	if _, ok := table.Lookup(2000, "/sale"); ok {
+		t.Error("expected redirects to be per site")
+	}
+
+	loop := NewRedirectTable([]Redirect{
+		{ID: 1, SiteID: 1, FromPath: "/a", To: RedirectToObject{Type: RedirectToURL, URL: "/b"}},
+		{ID: 2, SiteID: 1, FromPath: "/b", To: RedirectToObject{Type: RedirectToURL, URL: "/a"}},
+	}, nil)
+	if lookup, _ := loop.Lookup(1, "/a"); !lookup.Loop || len(lookup.Redirects) != 2 {
+		t.Errorf("expected loop, got %+v", lookup)
+	}
+
+	absolute := []Redirect{
+		{ID: 1, SiteID: 1000, FromPath: "/here", To: RedirectToObject{Type: RedirectToURL, URL: "https://STORE.example.com/there"}},
+		{ID: 2, SiteID: 1000, FromPath: "/away", To: RedirectToObject{Type: RedirectToURL, URL: "https://partner.example.com/there"}},
+		{ID: 3, SiteID: 1000, FromPath: "/there", To: RedirectToObject{Type: RedirectToURL, URL: "/final"}},
+	}
+	table = NewRedirectTable(absolute, resolver.SiteURLs)
+	if lookup, _ := table.Lookup(1000, "/here"); len(lookup.Redirects) != 2 || lookup.Destination != "/final" {
+		t.Errorf("expected a target on the site's host to be followed, got %+v", lookup)
+	}
+	if lookup, _ := table.Lookup(1000, "/away"); len(lookup.Redirects) != 1 || lookup.Destination != "https://partner.example.com/there" {
+		t.Errorf("expected a target on another host not to be followed, got %+v", lookup)
+	}
+	if lookup, _ := NewRedirectTable(absolute, nil).Lookup(1000, "/here"); len(lookup.Redirects) != 1 {
+		t.Errorf("expected absolute targets not to be followed without site URLs, got %+v", lookup)
+	}
+}
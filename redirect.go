@@ -2,6 +2,7 @@ package bigcommerce
 
 import (
 	"errors"
+	"fmt"
 )
 
 type Redirect struct {
@@ -20,10 +21,38 @@ func FromPaths(redirects []Redirect) []string {
 	return fromPaths
 }
 
+type RedirectTargetType string
+
+const (
+	RedirectToProduct  RedirectTargetType = "product"
+	RedirectToBrand    RedirectTargetType = "brand"
+	RedirectToCategory RedirectTargetType = "category"
+	RedirectToPage     RedirectTargetType = "page"
+	RedirectToPost     RedirectTargetType = "post"
+	RedirectToURL      RedirectTargetType = "url"
+)
+
+var AllowedRedirectTargetTypes = []RedirectTargetType{
+	RedirectToProduct,
+	RedirectToBrand,
+	RedirectToCategory,
+	RedirectToPage,
+	RedirectToPost,
+	RedirectToURL,
+}
+
+// RedirectInclude names extra fields to return with redirects.
+type RedirectInclude string
+
+const (
+	// RedirectIncludeToURL fills in Redirect.ToURL with the absolute URL of the target.
+	RedirectIncludeToURL RedirectInclude = "to_url"
+)
+
 type RedirectToObject struct {
-	Type     string `json:"type"`
-	EntityID int    `json:"entity_id"`
-	URL      string `json:"url"`
+	Type     RedirectTargetType `json:"type"`
+	EntityID int                `json:"entity_id"`
+	URL      string             `json:"url"`
 }
 
 func (client *V3Client) GetAllRedirects(params RedirectQueryParams) ([]Redirect, error) {
@@ -33,14 +62,15 @@ func (client *V3Client) GetAllRedirects(params RedirectQueryParams) ([]Redirect,
 	for {
 		res, err := client.GetRedirects(params)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to get all redirects at page %d: %w", params.Page, err)
 		}
+
+		redirects = append(redirects, res...)
+
 		if len(res) < params.Limit {
 			return redirects, nil
 		}
 
-		redirects = append(redirects, res...)
-
 		params.Page++
 	}
 }
@@ -54,25 +84,25 @@ func (client *V3Client) GetRedirects(params RedirectQueryParams) ([]Redirect, er
 
 	getRedirectsURL, err := urlWithQueryParams(client.constructURL("/storefront/redirects"), params)
 	if err != nil {
-		return response.Data, err
+		return response.Data, fmt.Errorf("failed to construct URL for GetRedirects: %w", err)
 	}
 
 	if err := client.Get(getRedirectsURL, &response); err != nil {
-		return response.Data, err
+		return response.Data, fmt.Errorf("failed to get redirects: %w", err)
 	}
 
 	return response.Data, nil
 }
 
 type RedirectQueryParams struct {
-	SiteID    int    `url:"site_id,omitempty"`
-	IDs       []int  `url:"id,omitempty"`
-	Limit     int    `url:"limit,omitempty"`
-	Page      int    `url:"page,omitempty"`
-	Sort      string `url:"sort,omitempty"`
-	Direction string `url:"direction,omitempty"`
-	Include   string `url:"include,omitempty"`
-	Keyword   string `url:"keyword,omitempty"`
+	SiteID    int             `url:"site_id,omitempty"`
+	IDs       []int           `url:"id:in,omitempty,comma"`
+	Limit     int             `url:"limit,omitempty"`
+	Page      int             `url:"page,omitempty"`
+	Sort      string          `url:"sort,omitempty"`
+	Direction string          `url:"direction,omitempty"`
+	Include   RedirectInclude `url:"include,omitempty"`
+	Keyword   string          `url:"keyword,omitempty"`
 }
 
 func validateRedirectUpsert(redirect RedirectUpsert) error {
@@ -88,16 +118,21 @@ func validateRedirectUpsert(redirect RedirectUpsert) error {
 		return errors.New("to.type is required")
 	}
 
-	if redirect.To.Type != "product" && redirect.To.Type != "brand" && redirect.To.Type != "category" &&
-		redirect.To.Type != "page" && redirect.To.Type != "post" && redirect.To.Type != "url" {
+	validType := false
+	for _, t := range AllowedRedirectTargetTypes {
+		if redirect.To.Type == t {
+			validType = true
+		}
+	}
+	if !validType {
 		return errors.New("to.type has an invalid value")
 	}
 
-	if redirect.To.Type != "url" && redirect.To.EntityID <= 0 {
+	if redirect.To.Type != RedirectToURL && redirect.To.EntityID <= 0 {
 		return errors.New("to.entity_id must be a positive integer")
 	}
 
-	if redirect.To.Type == "url" && len(redirect.To.URL) > 2048 {
+	if redirect.To.Type == RedirectToURL && len(redirect.To.URL) > 2048 {
 		return errors.New("to.url must be 2048 characters or less")
 	}
 
@@ -111,48 +146,64 @@ type RedirectUpsert struct {
 }
 
 type RedirectTarget struct {
-	Type     string `json:"type"`
-	EntityID int    `json:"entity_id"`
-	URL      string `json:"url"`
+	Type     RedirectTargetType `json:"type"`
+	EntityID int                `json:"entity_id"`
+	URL      string             `json:"url"`
 }
 
+// RedirectUpsertBatchSize is the number of redirects UpsertRedirects sends per
+// request, keeping each request within the API's per-request limit.
+const RedirectUpsertBatchSize = 50
+
+// UpsertRedirects creates or updates redirects, matched on site and from_path.
+// Every redirect is validated before anything is sent, and the redirects are then
+// sent in batches of RedirectUpsertBatchSize. If a batch fails, the redirects
+// saved by earlier batches are returned along with the error.
 func (client *V3Client) UpsertRedirects(redirects []RedirectUpsert) ([]Redirect, error) {
 	type ResponseObject struct {
 		Data []Redirect `json:"data"`
 		Meta MetaData   `json:"meta"`
 	}
-	var response ResponseObject
 
+	var errs ValidationErrors
 	for i := 0; i < len(redirects); i++ {
-		err := validateRedirectUpsert(redirects[i])
-		if err != nil {
-			return response.Data, err
+		if err := validateRedirectUpsert(redirects[i]); err != nil {
+			errs = append(errs, fmt.Sprintf("redirect %d (%s): %v", i, redirects[i].FromPath, err))
 		}
 	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
 
 	path := client.constructURL("/storefront/redirects")
 
-	err := client.Put(path, redirects, &response)
-	if err != nil {
-		return response.Data, err
+	saved := []Redirect{}
+	for start := 0; start < len(redirects); start += RedirectUpsertBatchSize {
+		end := minInt(start+RedirectUpsertBatchSize, len(redirects))
+
+		var response ResponseObject
+		if err := client.Put(path, redirects[start:end], &response); err != nil {
+			return saved, fmt.Errorf("failed to upsert redirects %d to %d: %w", start, end-1, err)
+		}
+		saved = append(saved, response.Data...)
 	}
 
-	return response.Data, nil
+	return saved, nil
 }
 
 type DeleteRedirectsParams struct {
-	ID     []int `url:"id:in,omitempty"`
+	ID     []int `url:"id:in,omitempty,comma"`
 	SiteID int   `url:"site_id,omitempty"`
 }
 
 func (client *V3Client) DeleteRedirect(params DeleteRedirectsParams) error {
 	path, err := urlWithQueryParams(client.constructURL("/storefront/redirects"), params)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to construct URL for DeleteRedirect: %w", err)
 	}
 
 	if err := client.Delete(path, nil); err != nil {
-		return err
+		return fmt.Errorf("failed to delete redirects: %w", err)
 	}
 
 	return nil
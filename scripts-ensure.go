@@ -0,0 +1,231 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// EnsureScriptsOptions says which installed scripts EnsureScripts owns. At least
+// one of APIClientID and NamePrefix must be set.
+type EnsureScriptsOptions struct {
+	// APIClientID limits the scripts considered ours to those installed by this API
+	// client. BigCommerce sets api_client_id on every script it creates.
+	APIClientID string
+	// NamePrefix limits the scripts considered ours to those whose name starts with it.
+	NamePrefix string
+	// DryRun reports what would change without creating, updating or deleting anything.
+	DryRun bool
+}
+
+type ScriptSyncAction string
+
+const (
+	ScriptSyncCreate    ScriptSyncAction = "create"
+	ScriptSyncUpdate    ScriptSyncAction = "update"
+	ScriptSyncDelete    ScriptSyncAction = "delete"
+	ScriptSyncUnchanged ScriptSyncAction = "unchanged"
+)
+
+// ScriptFieldDiff is a field that differs between the installed script and the
+// declared one.
+type ScriptFieldDiff struct {
+	Field     string
+	Installed string
+	Desired   string
+}
+
+type ScriptSyncChange struct {
+	Name   string
+	UUID   string
+	Action ScriptSyncAction
+	Diffs  []ScriptFieldDiff
+	// Script is the script after the change. For deletions and dry runs it is the
+	// installed script, and it is empty for creations in a dry run.
+	Script Script
+}
+
+type EnsureScriptsResult struct {
+	Changes []ScriptSyncChange
+}
+
+// HasDrift reports whether the installed scripts differ from the declared set.
+func (result EnsureScriptsResult) HasDrift() bool {
+	for _, change := range result.Changes {
+		if change.Action != ScriptSyncUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// EnsureScripts makes the installed scripts match desired. Scripts are matched by
+// name: missing ones are created and ones that differ are updated. Installed
+// scripts we own (see EnsureScriptsOptions) that are not declared are deleted, as
+// are duplicates of a declared name.
+//
+// Fields left empty in desired are not compared, so BigCommerce's defaults for
+// them never count as drift. AutoUninstall and Enabled are empty when false:
+// EnsureScripts turns them on but never off.
+func (client *V3Client) EnsureScripts(desired []CreateScriptParams, opts EnsureScriptsOptions) (EnsureScriptsResult, error) {
+	var result EnsureScriptsResult
+
+	if opts.APIClientID == "" && opts.NamePrefix == "" {
+		return result, fmt.Errorf("ensure scripts: APIClientID or NamePrefix must be set so scripts owned by others are not deleted")
+	}
+
+	names := map[string]bool{}
+	for _, params := range desired {
+		if names[params.Name] {
+			return result, fmt.Errorf("ensure scripts: %q is declared more than once", params.Name)
+		}
+		names[params.Name] = true
+
+		if err := ValidateCreateScriptParams(params); err != nil {
+			return result, fmt.Errorf("ensure scripts: invalid script %q: %w", params.Name, err)
+		}
+		if opts.NamePrefix != "" && !strings.HasPrefix(params.Name, opts.NamePrefix) {
+			return result, fmt.Errorf("ensure scripts: %q does not start with %q", params.Name, opts.NamePrefix)
+		}
+	}
+
+	installed, err := client.GetAllScripts(250)
+	if err != nil {
+		return result, fmt.Errorf("ensure scripts: %w", err)
+	}
+
+	owned := func(script Script) bool {
+		if opts.APIClientID != "" && script.APIClientID != opts.APIClientID {
+			return false
+		}
+		return strings.HasPrefix(script.Name, opts.NamePrefix)
+	}
+
+	byName := map[string]Script{}
+	var stale []Script
+	for _, script := range installed {
+		if !owned(script) {
+			continue
+		}
+		if _, seen := byName[script.Name]; seen || !names[script.Name] {
+			stale = append(stale, script)
+			continue
+		}
+		byName[script.Name] = script
+	}
+
+	for _, params := range desired {
+		change := ScriptSyncChange{Name: params.Name}
+
+		script, ok := byName[params.Name]
+		if !ok {
+			change.Action = ScriptSyncCreate
+			if !opts.DryRun {
+				created, err := client.CreateScript(params)
+				if err != nil {
+					return result, fmt.Errorf("ensure scripts: %w", err)
+				}
+				change.UUID = created.UUID
+				change.Script = created
+			}
+			result.Changes = append(result.Changes, change)
+			continue
+		}
+
+		change.UUID = script.UUID
+		change.Script = script
+		change.Diffs = diffScript(script, params)
+		if len(change.Diffs) == 0 {
+			change.Action = ScriptSyncUnchanged
+			result.Changes = append(result.Changes, change)
+			continue
+		}
+
+		change.Action = ScriptSyncUpdate
+		if !opts.DryRun {
+			updated, err := client.UpdateScript(script.UUID, scriptUpdateParams(params))
+			if err != nil {
+				return result, fmt.Errorf("ensure scripts: %w", err)
+			}
+			change.Script = updated
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	for _, script := range stale {
+		if !opts.DryRun {
+			if err := client.DeleteScript(script.UUID); err != nil {
+				return result, fmt.Errorf("ensure scripts: %w", err)
+			}
+		}
+		result.Changes = append(result.Changes, ScriptSyncChange{
+			Name:   script.Name,
+			UUID:   script.UUID,
+			Action: ScriptSyncDelete,
+			Script: script,
+		})
+	}
+
+	return result, nil
+}
+
+// DiffScripts reports drift between desired and the installed scripts without
+// changing anything. It is EnsureScripts with DryRun set.
+func (client *V3Client) DiffScripts(desired []CreateScriptParams, opts EnsureScriptsOptions) (EnsureScriptsResult, error) {
+	opts.DryRun = true
+	return client.EnsureScripts(desired, opts)
+}
+
+func diffScript(script Script, params CreateScriptParams) []ScriptFieldDiff {
+	var diffs []ScriptFieldDiff
+
+	compare := func(field, installed, desired string) {
+		if desired != "" && installed != desired {
+			diffs = append(diffs, ScriptFieldDiff{Field: field, Installed: installed, Desired: desired})
+		}
+	}
+
+	compare("description", script.Description, params.Description)
+	compare("html", script.HTML, params.HTML)
+	compare("src", script.Src, params.Src)
+	compare("load_method", script.LoadMethod, params.LoadMethod)
+	compare("location", script.Location, params.Location)
+	compare("visibility", script.Visibility, params.Visibility)
+	compare("kind", script.Kind, params.Kind)
+	compare("consent_category", script.ConsentCategory, params.ConsentCategory)
+	if params.ChannelID != 0 {
+		compare("channel_id", strconv.Itoa(script.ChannelID), strconv.Itoa(params.ChannelID))
+	}
+	if params.AutoUninstall && !script.AutoUninstall {
+		diffs = append(diffs, ScriptFieldDiff{Field: "auto_uninstall", Installed: strconv.FormatBool(script.AutoUninstall), Desired: strconv.FormatBool(params.AutoUninstall)})
+	}
+	if params.Enabled && !script.Enabled {
+		diffs = append(diffs, ScriptFieldDiff{Field: "enabled", Installed: strconv.FormatBool(script.Enabled), Desired: strconv.FormatBool(params.Enabled)})
+	}
+
+	return diffs
+}
+
+func scriptUpdateParams(params CreateScriptParams) UpdateScriptParams {
+	update := UpdateScriptParams{
+		Name:            params.Name,
+		Description:     params.Description,
+		HTML:            params.HTML,
+		Src:             params.Src,
+		LoadMethod:      params.LoadMethod,
+		Location:        params.Location,
+		Visibility:      params.Visibility,
+		Kind:            params.Kind,
+		ConsentCategory: params.ConsentCategory,
+		ChannelID:       params.ChannelID,
+	}
+	// Like the other fields, false is left out rather than sent.
+	if params.AutoUninstall {
+		update.AutoUninstall = &params.AutoUninstall
+	}
+	if params.Enabled {
+		update.Enabled = &params.Enabled
+	}
+
+	return update
+}
@@ -0,0 +1,187 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeScriptStore struct {
+	mu      sync.Mutex
+	scripts []Script
+	nextID  int
+	deleted []string
+}
+
+func (store *fakeScriptStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	uuid := strings.TrimPrefix(r.URL.Path, "/stores/test/v3/content/scripts")
+	uuid = strings.TrimPrefix(uuid, "/")
+
+	switch r.Method {
+	case http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]any{"data": store.scripts})
+	case http.MethodPost:
+		var params CreateScriptParams
+		json.NewDecoder(r.Body).Decode(&params)
+		store.nextID++
+		script := Script{
+			UUID:          fmt.Sprintf("uuid-%d", store.nextID),
+			Name:          params.Name,
+			HTML:          params.HTML,
+			Src:           params.Src,
+			Kind:          params.Kind,
+			Location:      params.Location,
+			Enabled:       params.Enabled,
+			AutoUninstall: params.AutoUninstall,
+			APIClientID:   "app",
+		}
+		store.scripts = append(store.scripts, script)
+		json.NewEncoder(w).Encode(map[string]any{"data": script})
+	case http.MethodPut:
+		var params UpdateScriptParams
+		json.NewDecoder(r.Body).Decode(&params)
+		for i, script := range store.scripts {
+			if script.UUID == uuid {
+				script.HTML = params.HTML
+				script.Location = params.Location
+				if params.Enabled != nil {
+					script.Enabled = *params.Enabled
+				}
+				store.scripts[i] = script
+				json.NewEncoder(w).Encode(map[string]any{"data": script})
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case http.MethodDelete:
+		for i, script := range store.scripts {
+			if script.UUID == uuid {
+				store.scripts = append(store.scripts[:i], store.scripts[i+1:]...)
+				store.deleted = append(store.deleted, uuid)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestEnsureScripts(t *testing.T) {
+	store := &fakeScriptStore{nextID: 10, scripts: []Script{
+		{UUID: "a", Name: "app: analytics", Kind: "script_tag", HTML: "<script>old()</script>", Location: "head", Enabled: true, APIClientID: "app"},
+		{UUID: "b", Name: "app: retired", Kind: "script_tag", HTML: "<script></script>", Enabled: true, APIClientID: "app"},
+		{UUID: "c", Name: "app: chat", Kind: "src", Src: "https://example.com/chat.js", Enabled: true, APIClientID: "app"},
+		{UUID: "d", Name: "other: widget", Kind: "src", Src: "https://example.com/w.js", Enabled: true, APIClientID: "other"},
+	}}
+	client := newTestServerClient(t, store)
+
+	desired := []CreateScriptParams{
+		{Name: "app: analytics", Kind: "script_tag", HTML: "<script>track()</script>", Location: "footer", Enabled: true},
+		{Name: "app: chat", Kind: "src", Src: "https://example.com/chat.js", Enabled: true},
+		{Name: "app: banner", Kind: "script_tag", HTML: "<script>banner()</script>", Enabled: true},
+	}
+	opts := EnsureScriptsOptions{APIClientID: "app", NamePrefix: "app: "}
+
+	result, err := client.V3.DiffScripts(desired, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.HasDrift() {
+		t.Fatal("expected drift")
+	}
+	if len(store.scripts) != 4 || store.nextID != 10 {
+		t.Fatal("dry run changed the store")
+	}
+
+	actions := map[string]ScriptSyncAction{}
+	for _, change := range result.Changes {
+		actions[change.Name] = change.Action
+		if change.Name == "app: analytics" && len(change.Diffs) != 2 {
+			t.Errorf("expected html and location to drift, got %+v", change.Diffs)
+		}
+	}
+	expected := map[string]ScriptSyncAction{
+		"app: analytics": ScriptSyncUpdate,
+		"app: chat":      ScriptSyncUnchanged,
+		"app: banner":    ScriptSyncCreate,
+		"app: retired":   ScriptSyncDelete,
+	}
+	for name, action := range expected {
+		if actions[name] != action {
+			t.Errorf("%s: expected %s, got %s", name, action, actions[name])
+		}
+	}
+	if _, ok := actions["other: widget"]; ok {
+		t.Error("script owned by another app was touched")
+	}
+
+	if _, err := client.V3.EnsureScripts(desired, opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.deleted) != 1 || store.deleted[0] != "b" {
+		t.Errorf("expected only the retired script deleted, got %v", store.deleted)
+	}
+
+	result, err = client.V3.DiffScripts(desired, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.HasDrift() {
+		t.Errorf("expected no drift after ensuring, got %+v", result.Changes)
+	}
+}
+
+func TestEnsureScripts_Ownership(t *testing.T) {
+	store := &fakeScriptStore{scripts: []Script{
+		{UUID: "a", Name: "theme: fonts", Kind: "src", Src: "https://example.com/f.js", Enabled: false, APIClientID: "theme"},
+	}}
+	client := newTestServerClient(t, store)
+
+	desired := []CreateScriptParams{{Name: "theme: fonts", Kind: "src", Src: "https://example.com/f.js"}}
+	if _, err := client.V3.EnsureScripts(nil, EnsureScriptsOptions{}); err == nil {
+		t.Fatal("expected an error without an ownership criterion")
+	}
+	if len(store.deleted) != 0 {
+		t.Fatalf("expected nothing deleted, got %v", store.deleted)
+	}
+
+	// Enabled is left false in desired, so the disabled script is not drift.
+	result, err := client.V3.DiffScripts(desired, EnsureScriptsOptions{NamePrefix: "theme: "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.HasDrift() {
+		t.Errorf("expected no drift for fields left empty, got %+v", result.Changes)
+	}
+
+	desired[0].Enabled = true
+	result, err = client.V3.DiffScripts(desired, EnsureScriptsOptions{NamePrefix: "theme: "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Changes) != 1 || len(result.Changes[0].Diffs) != 1 || result.Changes[0].Diffs[0].Field != "enabled" {
+		t.Errorf("expected enabled to drift, got %+v", result.Changes)
+	}
+}
+
+func TestValidateCreateScriptParams(t *testing.T) {
+	if err := ValidateCreateScriptParams(StorefrontFooterHTMLScript("footer", "<script></script>")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := ValidateCreateScriptParams(CreateScriptParams{Kind: "src", HTML: "<script></script>", Location: "body"})
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+	// Missing name, bad location, missing src and html on a src script.
+	if len(errs) != 4 {
+		t.Errorf("expected 4 errors, got %v", errs)
+	}
+}
@@ -1,5 +1,10 @@
 package bigcommerce
 
+import (
+	"fmt"
+	"strings"
+)
+
 type Script struct {
 	Name            string `json:"name"`
 	UUID            string `json:"uuid"`
@@ -19,27 +24,28 @@ type Script struct {
 	ChannelID       int    `json:"channel_id"`
 }
 type UpdateScriptParams struct {
-	Name            string `json:"name,omitempty"`
-	Description     string `json:"description,omitempty"`
-	HTML            string `json:"html,omitempty"`
-	Src             string `json:"src,omitempty"`
-	AutoUninstall   bool   `json:"auto_uninstall,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	HTML        string `json:"html,omitempty"`
+	Src         string `json:"src,omitempty"`
+	// AutoUninstall and Enabled are pointers so that they can be switched off.
+	AutoUninstall   *bool  `json:"auto_uninstall,omitempty"`
 	LoadMethod      string `json:"load_method,omitempty"`
 	Location        string `json:"location,omitempty"`
 	Visibility      string `json:"visibility,omitempty"`
 	Kind            string `json:"kind,omitempty"`
 	APIClientID     string `json:"api_client_id,omitempty"`
 	ConsentCategory string `json:"consent_category,omitempty"`
-	Enabled         bool   `json:"enabled,omitempty"`
+	Enabled         *bool  `json:"enabled,omitempty"`
 	ChannelID       int    `json:"channel_id,omitempty"`
 }
 
 type ScriptsQuery struct {
-	Page       int      `json:"page,omitempty"`
-	Limit      int      `json:"limit,omitempty"`
-	Sort       string   `json:"sort,omitempty"`
-	Direction  string   `json:"direction,omitempty"`
-	ChannelIDs []string `json:"channel_id,omitempty"`
+	Page       int      `url:"page,omitempty"`
+	Limit      int      `url:"limit,omitempty"`
+	Sort       string   `url:"sort,omitempty"`
+	Direction  string   `url:"direction,omitempty"`
+	ChannelIDs []string `url:"channel_id:in,omitempty,comma"`
 }
 
 func (client *V3Client) GetScripts(params ScriptsQuery) ([]Script, MetaData, error) {
@@ -49,19 +55,25 @@ func (client *V3Client) GetScripts(params ScriptsQuery) ([]Script, MetaData, err
 	}
 	var response ResponseObject
 
-	path := client.constructURL("/content/scripts")
-
-	err := client.Get(path, &response)
+	path, err := urlWithQueryParams(client.constructURL("/content/scripts"), params)
 	if err != nil {
-		return response.Data, response.Meta, err
+		return response.Data, response.Meta, fmt.Errorf("failed to construct URL for GetScripts: %w", err)
+	}
+
+	if err := client.Get(path, &response); err != nil {
+		return response.Data, response.Meta, fmt.Errorf("failed to get scripts: %w", err)
 	}
 
 	return response.Data, response.Meta, nil
 }
 
+// GetAllScripts retrieves every script, limit at a time. limit defaults to 250.
 func (client *V3Client) GetAllScripts(limit int) ([]Script, error) {
 	var scripts []Script
 	page := 1
+	if limit < 1 {
+		limit = 250
+	}
 
 	for {
 		p, _, err := client.GetScripts(ScriptsQuery{Limit: limit, Page: page})
@@ -84,17 +96,17 @@ func (client *V3Client) GetAllScripts(limit int) ([]Script, error) {
 }
 
 type CreateScriptParams struct {
-	Name            string `json:"name" validate:"required,min=1,max=255"`
+	Name            string `json:"name"`
 	Description     string `json:"description,omitempty"`
-	HTML            string `json:"html,omitempty" validate:"max=65536"`
+	HTML            string `json:"html,omitempty"`
 	Src             string `json:"src,omitempty"`
 	AutoUninstall   bool   `json:"auto_uninstall,omitempty"`
-	LoadMethod      string `json:"load_method,omitempty" validate:"omitempty,oneof=default async defer"`
-	Location        string `json:"location,omitempty" validate:"omitempty,oneof=head footer"`
-	Visibility      string `json:"visibility,omitempty" validate:"omitempty,oneof=storefront all_pages checkout order_confirmation"`
-	Kind            string `json:"kind,omitempty" validate:"omitempty,oneof=src script_tag"`
+	LoadMethod      string `json:"load_method,omitempty"`
+	Location        string `json:"location,omitempty"`
+	Visibility      string `json:"visibility,omitempty"`
+	Kind            string `json:"kind,omitempty"`
 	APIClientID     string `json:"api_client_id,omitempty"`
-	ConsentCategory string `json:"consent_category,omitempty" validate:"omitempty,oneof=essential functional analytics targeting"`
+	ConsentCategory string `json:"consent_category,omitempty"`
 	Enabled         bool   `json:"enabled,omitempty"`
 	ChannelID       int    `json:"channel_id,omitempty"`
 }
@@ -122,15 +134,17 @@ func (client *V3Client) CreateScript(params CreateScriptParams) (Script, error)
 
 	var response ResponseObject
 
+	if err := ValidateCreateScriptParams(params); err != nil {
+		return response.Data, fmt.Errorf("failed to create script: invalid parameters: %w", err)
+	}
+
 	path := client.constructURL("/content/scripts")
 
-	err := client.Post(path, params, &response)
-	if err != nil {
-		return response.Data, err
+	if err := client.Post(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to create script: %w", err)
 	}
 
 	return response.Data, nil
-
 }
 
 func (client *V3Client) UpdateScript(uuid string, params UpdateScriptParams) (Script, error) {
@@ -143,10 +157,98 @@ func (client *V3Client) UpdateScript(uuid string, params UpdateScriptParams) (Sc
 
 	updateScriptURL := client.constructURL("content", "scripts", uuid)
 
-	err := client.Put(updateScriptURL, params, &response)
-	if err != nil {
-		return response.Data, err
+	if err := client.Put(updateScriptURL, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to update script %s: %w", uuid, err)
 	}
 
 	return response.Data, nil
 }
+
+func (client *V3Client) GetScript(uuid string) (Script, error) {
+	type ResponseObject struct {
+		Data Script   `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+
+	var response ResponseObject
+
+	path := client.constructURL("content", "scripts", uuid)
+
+	if err := client.Get(path, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to get script %s: %w", uuid, err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) DeleteScript(uuid string) error {
+	path := client.constructURL("content", "scripts", uuid)
+
+	if err := client.Delete(path, nil); err != nil {
+		return fmt.Errorf("failed to delete script %s: %w", uuid, err)
+	}
+
+	return nil
+}
+
+const maxScriptHTMLLength = 65536
+
+// ValidateCreateScriptParams checks params against the rules BigCommerce applies
+// to scripts. A script_tag script needs HTML and a src script needs Src.
+func ValidateCreateScriptParams(params CreateScriptParams) error {
+	var errors ValidationErrors
+
+	if params.Name == "" {
+		errors = append(errors, "Name is required")
+	} else if len(params.Name) > 255 {
+		errors = append(errors, "Name must be at most 255 characters")
+	}
+
+	if len(params.HTML) > maxScriptHTMLLength {
+		errors = append(errors, fmt.Sprintf("HTML must be at most %d characters", maxScriptHTMLLength))
+	}
+
+	checkOneOf := func(field, value string, allowed ...string) {
+		if value == "" {
+			return
+		}
+		for _, a := range allowed {
+			if value == a {
+				return
+			}
+		}
+		errors = append(errors, fmt.Sprintf("%s %q must be one of %s", field, value, strings.Join(allowed, ", ")))
+	}
+	checkOneOf("LoadMethod", params.LoadMethod, "default", "async", "defer")
+	checkOneOf("Location", params.Location, "head", "footer")
+	checkOneOf("Visibility", params.Visibility, "storefront", "all_pages", "checkout", "order_confirmation")
+	checkOneOf("Kind", params.Kind, "src", "script_tag")
+	checkOneOf("ConsentCategory", params.ConsentCategory, "essential", "functional", "analytics", "targeting")
+
+	switch params.Kind {
+	case "script_tag":
+		if params.HTML == "" {
+			errors = append(errors, "HTML is required for script_tag scripts")
+		}
+		if params.Src != "" {
+			errors = append(errors, "Src cannot be used with script_tag scripts, use Kind src")
+		}
+	case "src":
+		if params.Src == "" {
+			errors = append(errors, "Src is required for src scripts")
+		}
+		if params.HTML != "" {
+			errors = append(errors, "HTML cannot be used with src scripts, use Kind script_tag")
+		}
+	case "":
+		if params.HTML == "" && params.Src == "" {
+			errors = append(errors, "one of HTML and Src is required")
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return nil
+}
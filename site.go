@@ -0,0 +1,39 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Site is the storefront a channel is served from.
+type Site struct {
+	ID        int       `json:"id"`
+	URL       string    `json:"url"`
+	ChannelID int       `json:"channel_id"`
+	SSLStatus string    `json:"ssl_status"`
+	URLs      []SiteURL `json:"urls"`
+	CreatedAt string    `json:"created_at"`
+	UpdatedAt string    `json:"updated_at"`
+}
+
+type SiteURL struct {
+	URL  string `json:"url"`
+	Type string `json:"type"`
+}
+
+func (client *V3Client) GetSite(siteID int) (Site, error) {
+	type ResponseObject struct {
+		Data Site     `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+
+	var response ResponseObject
+
+	path := client.constructURL("/sites", strconv.Itoa(siteID))
+
+	if err := client.Get(path, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to get site %d: %w", siteID, err)
+	}
+
+	return response.Data, nil
+}
@@ -0,0 +1,190 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WebhookSubscription is a webhook EnsureWebhooks should keep in place.
+type WebhookSubscription struct {
+	Scope WebhookScope
+	// Headers are sent with every delivery, for example to authenticate it.
+	Headers map[string]string
+}
+
+type EnsureWebhooksOptions struct {
+	// Destination is the URL every subscription delivers to. Hooks with any other
+	// destination are left alone.
+	Destination string
+	// DryRun reports what would change without creating, updating or deleting anything.
+	DryRun bool
+}
+
+type WebhookSyncAction string
+
+const (
+	WebhookSyncCreate     WebhookSyncAction = "create"
+	WebhookSyncUpdate     WebhookSyncAction = "update"
+	WebhookSyncReactivate WebhookSyncAction = "reactivate"
+	WebhookSyncDelete     WebhookSyncAction = "delete"
+	WebhookSyncUnchanged  WebhookSyncAction = "unchanged"
+)
+
+type WebhookSyncChange struct {
+	Scope  WebhookScope
+	ID     int
+	Action WebhookSyncAction
+	// Webhook is the hook after the change. For deletions and dry runs it is the
+	// installed hook, and it is empty for creations in a dry run.
+	Webhook Webhook
+}
+
+type EnsureWebhooksResult struct {
+	Changes []WebhookSyncChange
+}
+
+// HasDrift reports whether the installed hooks differ from the declared set.
+func (result EnsureWebhooksResult) HasDrift() bool {
+	for _, change := range result.Changes {
+		if change.Action != WebhookSyncUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// EnsureWebhooks makes the hooks delivering to opts.Destination match desired, one
+// active hook per scope. Missing hooks are created and hooks with different
+// headers are updated. Hooks BigCommerce deactivated, usually after repeated
+// delivery failures, are switched back on. Hooks to the destination for scopes
+// that are not declared are deleted, as are duplicates.
+func (client *V3Client) EnsureWebhooks(desired []WebhookSubscription, opts EnsureWebhooksOptions) (EnsureWebhooksResult, error) {
+	var result EnsureWebhooksResult
+
+	if opts.Destination == "" {
+		return result, fmt.Errorf("ensure webhooks: Destination is required")
+	}
+
+	scopes := map[WebhookScope]bool{}
+	for _, subscription := range desired {
+		if subscription.Scope == "" {
+			return result, fmt.Errorf("ensure webhooks: subscription without a scope")
+		}
+		if scopes[subscription.Scope] {
+			return result, fmt.Errorf("ensure webhooks: %s is declared more than once", subscription.Scope)
+		}
+		scopes[subscription.Scope] = true
+	}
+
+	installed, err := client.GetAllWebhooks(WebhookQueryParams{Destination: opts.Destination})
+	if err != nil {
+		return result, fmt.Errorf("ensure webhooks: %w", err)
+	}
+
+	// Prefer keeping an active hook when a scope has several.
+	sort.SliceStable(installed, func(i, j int) bool {
+		return installed[i].IsActive && !installed[j].IsActive
+	})
+
+	byScope := map[WebhookScope]Webhook{}
+	var stale []Webhook
+	for _, hook := range installed {
+		if hook.Destination != opts.Destination {
+			continue
+		}
+		if _, seen := byScope[hook.Scope]; seen || !scopes[hook.Scope] {
+			stale = append(stale, hook)
+			continue
+		}
+		byScope[hook.Scope] = hook
+	}
+
+	active := true
+	for _, subscription := range desired {
+		change := WebhookSyncChange{Scope: subscription.Scope}
+
+		hook, ok := byScope[subscription.Scope]
+		if !ok {
+			change.Action = WebhookSyncCreate
+			if !opts.DryRun {
+				created, err := client.CreateWebhook(CreateWebhookParams{
+					Scope:       subscription.Scope,
+					Destination: opts.Destination,
+					IsActive:    true,
+					Headers:     subscription.Headers,
+				})
+				if err != nil {
+					return result, fmt.Errorf("ensure webhooks: %w", err)
+				}
+				change.ID = created.ID
+				change.Webhook = created
+			}
+			result.Changes = append(result.Changes, change)
+			continue
+		}
+
+		change.ID = hook.ID
+		change.Webhook = hook
+
+		switch {
+		case !hook.IsActive:
+			change.Action = WebhookSyncReactivate
+		case !equalWebhookHeaders(hook.Headers, subscription.Headers):
+			change.Action = WebhookSyncUpdate
+		default:
+			change.Action = WebhookSyncUnchanged
+			result.Changes = append(result.Changes, change)
+			continue
+		}
+
+		if !opts.DryRun {
+			// An empty map, rather than nil, clears headers the subscription no longer
+			// declares.
+			headers := subscription.Headers
+			if headers == nil {
+				headers = map[string]string{}
+			}
+			updated, err := client.UpdateWebhook(hook.ID, UpdateWebhookParams{IsActive: &active, Headers: &headers})
+			if err != nil {
+				return result, fmt.Errorf("ensure webhooks: %w", err)
+			}
+			change.Webhook = updated
+		}
+		result.Changes = append(result.Changes, change)
+	}
+
+	for _, hook := range stale {
+		if !opts.DryRun {
+			if _, err := client.DeleteWebhook(hook.ID); err != nil {
+				return result, fmt.Errorf("ensure webhooks: %w", err)
+			}
+		}
+		result.Changes = append(result.Changes, WebhookSyncChange{
+			Scope:   hook.Scope,
+			ID:      hook.ID,
+			Action:  WebhookSyncDelete,
+			Webhook: hook,
+		})
+	}
+
+	return result, nil
+}
+
+// equalWebhookHeaders compares headers ignoring the case of header names, which
+// HTTP treats as equal.
+func equalWebhookHeaders(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	lower := map[string]string{}
+	for name, value := range a {
+		lower[strings.ToLower(name)] = value
+	}
+	for name, value := range b {
+		if v, ok := lower[strings.ToLower(name)]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
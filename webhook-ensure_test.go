@@ -0,0 +1,160 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeWebhookStore struct {
+	mu      sync.Mutex
+	hooks   []Webhook
+	nextID  int
+	deleted []int
+}
+
+func (store *fakeWebhookStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	id, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/stores/test/v3/hooks/"))
+	respond := func(data interface{}) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": data,
+			"meta": map[string]interface{}{"pagination": map[string]int{"current_page": 1, "total_pages": 1}},
+		})
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		destination := r.URL.Query().Get("destination")
+		hooks := []Webhook{}
+		for _, hook := range store.hooks {
+			if destination == "" || hook.Destination == destination {
+				hooks = append(hooks, hook)
+			}
+		}
+		respond(hooks)
+	case http.MethodPost:
+		var params CreateWebhookParams
+		json.NewDecoder(r.Body).Decode(&params)
+		store.nextID++
+		hook := Webhook{ID: store.nextID, Scope: params.Scope, Destination: params.Destination, IsActive: params.IsActive, Headers: params.Headers}
+		store.hooks = append(store.hooks, hook)
+		respond(hook)
+	case http.MethodPut:
+		var params UpdateWebhookParams
+		json.NewDecoder(r.Body).Decode(&params)
+		for i, hook := range store.hooks {
+			if hook.ID == id {
+				hook.IsActive = *params.IsActive
+				// Like BigCommerce, headers left out of the update are kept.
+				if params.Headers != nil {
+					hook.Headers = *params.Headers
+				}
+				store.hooks[i] = hook
+				respond(hook)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case http.MethodDelete:
+		for i, hook := range store.hooks {
+			if hook.ID == id {
+				store.hooks = append(store.hooks[:i], store.hooks[i+1:]...)
+				store.deleted = append(store.deleted, id)
+				respond(hook)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestEnsureWebhooks(t *testing.T) {
+	const destination = "https://app.example.com/webhooks"
+	auth := map[string]string{"X-Secret": "s3cret"}
+
+	store := &fakeWebhookStore{nextID: 10, hooks: []Webhook{
+		{ID: 1, Scope: WebhookScopeOrderCreated, Destination: destination, IsActive: false, Headers: auth},
+		{ID: 2, Scope: WebhookScopeProductUpdated, Destination: destination, IsActive: true, Headers: map[string]string{"x-secret": "old"}},
+		{ID: 3, Scope: WebhookScopeCartAbandoned, Destination: destination, IsActive: true},
+		{ID: 4, Scope: WebhookScopeOrderCreated, Destination: "https://other.example.com", IsActive: true},
+		{ID: 5, Scope: WebhookScopeAppUninstalled, Destination: destination, IsActive: true, Headers: map[string]string{"x-secret": "s3cret"}},
+	}}
+	client := newTestServerClient(t, store)
+
+	desired := []WebhookSubscription{
+		{Scope: WebhookScopeOrderCreated, Headers: auth},
+		{Scope: WebhookScopeProductUpdated, Headers: auth},
+		{Scope: WebhookScopeAppUninstalled, Headers: auth},
+		{Scope: WebhookScopeCategoryAll, Headers: auth},
+	}
+	opts := EnsureWebhooksOptions{Destination: destination}
+
+	result, err := client.V3.EnsureWebhooks(desired, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actions := map[WebhookScope]WebhookSyncAction{}
+	for _, change := range result.Changes {
+		actions[change.Scope] = change.Action
+	}
+	expected := map[WebhookScope]WebhookSyncAction{
+		WebhookScopeOrderCreated:   WebhookSyncReactivate,
+		WebhookScopeProductUpdated: WebhookSyncUpdate,
+		WebhookScopeAppUninstalled: WebhookSyncUnchanged,
+		WebhookScopeCategoryAll:    WebhookSyncCreate,
+		WebhookScopeCartAbandoned:  WebhookSyncDelete,
+	}
+	for scope, action := range expected {
+		if actions[scope] != action {
+			t.Errorf("%s: expected %s, got %s", scope, action, actions[scope])
+		}
+	}
+	if len(store.deleted) != 1 || store.deleted[0] != 3 {
+		t.Errorf("expected only hook 3 deleted, got %v", store.deleted)
+	}
+
+	result, err = client.V3.EnsureWebhooks(desired, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.HasDrift() {
+		t.Errorf("expected no drift on second run, got %+v", result.Changes)
+	}
+}
+
+func TestEnsureWebhooks_RemovesHeaders(t *testing.T) {
+	const destination = "https://app.example.com/webhooks"
+	store := &fakeWebhookStore{nextID: 1, hooks: []Webhook{
+		{ID: 1, Scope: WebhookScopeOrderCreated, Destination: destination, IsActive: true, Headers: map[string]string{"x-secret": "old"}},
+	}}
+	client := newTestServerClient(t, store)
+
+	desired := []WebhookSubscription{{Scope: WebhookScopeOrderCreated}}
+	opts := EnsureWebhooksOptions{Destination: destination}
+
+	result, err := client.V3.EnsureWebhooks(desired, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Changes) != 1 || result.Changes[0].Action != WebhookSyncUpdate {
+		t.Fatalf("expected the headers to be removed, got %+v", result.Changes)
+	}
+	if len(store.hooks[0].Headers) != 0 {
+		t.Fatalf("expected no headers left, got %v", store.hooks[0].Headers)
+	}
+
+	result, err = client.V3.EnsureWebhooks(desired, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.HasDrift() {
+		t.Errorf("expected no drift once the headers are removed, got %+v", result.Changes)
+	}
+}
@@ -0,0 +1,520 @@
+package bigcommerce
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// QueuedWebhook is a webhook event waiting in a WebhookQueue.
+type QueuedWebhook struct {
+	// ID identifies the event. Redeliveries of an event share it.
+	ID string `json:"id"`
+	// Key groups events for the same resource, such as order/250. Events with the
+	// same key are processed one at a time, in the order they were queued.
+	Key         string       `json:"key"`
+	Event       WebhookEvent `json:"event"`
+	Attempts    int          `json:"attempts"`
+	EnqueuedAt  time.Time    `json:"enqueued_at"`
+	NextAttempt time.Time    `json:"next_attempt"`
+	LastError   string       `json:"last_error,omitempty"`
+}
+
+// ErrQueuedWebhookNotFound is returned by WebhookQueue methods given an ID that is
+// not in the queue.
+var ErrQueuedWebhookNotFound = errors.New("queued webhook not found")
+
+// WebhookQueue stores webhook events between their delivery and their processing
+// by WebhookReceiver.Run. Implementations must be safe for concurrent use.
+type WebhookQueue interface {
+	// Enqueue adds an event to the end of the queue.
+	Enqueue(ctx context.Context, item QueuedWebhook) error
+	// Pending returns the queued events in the order they were queued.
+	Pending(ctx context.Context) ([]QueuedWebhook, error)
+	// Update saves an event after a failed attempt.
+	Update(ctx context.Context, item QueuedWebhook) error
+	// Remove deletes a processed event.
+	Remove(ctx context.Context, id string) error
+	// DeadLetter moves an event that ran out of attempts out of the queue.
+	DeadLetter(ctx context.Context, item QueuedWebhook) error
+	// DeadLetters returns the dead-lettered events, oldest first.
+	DeadLetters(ctx context.Context) ([]QueuedWebhook, error)
+	// Replay moves a dead-lettered event back to the end of the queue with its
+	// attempts reset.
+	Replay(ctx context.Context, id string) error
+}
+
+type webhookQueueState struct {
+	Pending []QueuedWebhook `json:"pending"`
+	Dead    []QueuedWebhook `json:"dead"`
+}
+
+func (state *webhookQueueState) update(item QueuedWebhook) error {
+	for i := range state.Pending {
+		if state.Pending[i].ID == item.ID {
+			state.Pending[i] = item
+			return nil
+		}
+	}
+	return ErrQueuedWebhookNotFound
+}
+
+func (state *webhookQueueState) remove(id string) (QueuedWebhook, error) {
+	for i, item := range state.Pending {
+		if item.ID == id {
+			state.Pending = append(state.Pending[:i], state.Pending[i+1:]...)
+			return item, nil
+		}
+	}
+	return QueuedWebhook{}, ErrQueuedWebhookNotFound
+}
+
+func (state *webhookQueueState) deadLetter(item QueuedWebhook) error {
+	if _, err := state.remove(item.ID); err != nil {
+		return err
+	}
+	state.Dead = append(state.Dead, item)
+	return nil
+}
+
+func (state *webhookQueueState) replay(id string) error {
+	for i, item := range state.Dead {
+		if item.ID == id {
+			state.Dead = append(state.Dead[:i], state.Dead[i+1:]...)
+			item.Attempts = 0
+			item.NextAttempt = time.Time{}
+			item.LastError = ""
+			state.Pending = append(state.Pending, item)
+			return nil
+		}
+	}
+	return ErrQueuedWebhookNotFound
+}
+
+// MemoryWebhookQueue keeps events in memory. Queued events are lost when the
+// process exits, so use FileWebhookQueue or a queue of your own where that matters.
+type MemoryWebhookQueue struct {
+	mu    sync.Mutex
+	state webhookQueueState
+}
+
+func NewMemoryWebhookQueue() *MemoryWebhookQueue {
+	return &MemoryWebhookQueue{}
+}
+
+func (queue *MemoryWebhookQueue) Enqueue(ctx context.Context, item QueuedWebhook) error {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	queue.state.Pending = append(queue.state.Pending, item)
+	return nil
+}
+
+func (queue *MemoryWebhookQueue) Pending(ctx context.Context) ([]QueuedWebhook, error) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	return append([]QueuedWebhook{}, queue.state.Pending...), nil
+}
+
+func (queue *MemoryWebhookQueue) Update(ctx context.Context, item QueuedWebhook) error {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	return queue.state.update(item)
+}
+
+func (queue *MemoryWebhookQueue) Remove(ctx context.Context, id string) error {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	_, err := queue.state.remove(id)
+	return err
+}
+
+func (queue *MemoryWebhookQueue) DeadLetter(ctx context.Context, item QueuedWebhook) error {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	return queue.state.deadLetter(item)
+}
+
+func (queue *MemoryWebhookQueue) DeadLetters(ctx context.Context) ([]QueuedWebhook, error) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	return append([]QueuedWebhook{}, queue.state.Dead...), nil
+}
+
+func (queue *MemoryWebhookQueue) Replay(ctx context.Context, id string) error {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	return queue.state.replay(id)
+}
+
+// FileWebhookQueue keeps events in a JSON file, rewritten on every change, so
+// queued and dead-lettered events survive restarts. It suits the modest volume of
+// a single store; only one process may use a file at a time.
+type FileWebhookQueue struct {
+	path  string
+	mu    sync.Mutex
+	state webhookQueueState
+}
+
+// NewFileWebhookQueue opens the queue stored at path, creating it if needed.
+func NewFileWebhookQueue(path string) (*FileWebhookQueue, error) {
+	queue := &FileWebhookQueue{path: path}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return queue, queue.save()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("webhook queue: failed to open %s: %w", path, err)
+	}
+	if err := json.Unmarshal(b, &queue.state); err != nil {
+		return nil, fmt.Errorf("webhook queue: failed to read %s: %w", path, err)
+	}
+
+	return queue, nil
+}
+
+// save writes the queue to a temporary file and renames it over the old one, so
+// a crash mid-write never leaves a truncated queue.
+func (queue *FileWebhookQueue) save() error {
+	b, err := json.Marshal(queue.state)
+	if err != nil {
+		return fmt.Errorf("webhook queue: failed to encode queue: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(queue.path), filepath.Base(queue.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("webhook queue: failed to save %s: %w", queue.path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("webhook queue: failed to save %s: %w", queue.path, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("webhook queue: failed to save %s: %w", queue.path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("webhook queue: failed to save %s: %w", queue.path, err)
+	}
+	if err := os.Rename(tmp.Name(), queue.path); err != nil {
+		return fmt.Errorf("webhook queue: failed to save %s: %w", queue.path, err)
+	}
+
+	return nil
+}
+
+// change applies fn to the queue and saves it, restoring the previous state if
+// saving fails.
+func (queue *FileWebhookQueue) change(fn func(state *webhookQueueState) error) error {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	previous := webhookQueueState{
+		Pending: append([]QueuedWebhook{}, queue.state.Pending...),
+		Dead:    append([]QueuedWebhook{}, queue.state.Dead...),
+	}
+
+	if err := fn(&queue.state); err != nil {
+		return err
+	}
+	if err := queue.save(); err != nil {
+		queue.state = previous
+		return err
+	}
+	return nil
+}
+
+func (queue *FileWebhookQueue) Enqueue(ctx context.Context, item QueuedWebhook) error {
+	return queue.change(func(state *webhookQueueState) error {
+		state.Pending = append(state.Pending, item)
+		return nil
+	})
+}
+
+func (queue *FileWebhookQueue) Pending(ctx context.Context) ([]QueuedWebhook, error) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	return append([]QueuedWebhook{}, queue.state.Pending...), nil
+}
+
+func (queue *FileWebhookQueue) Update(ctx context.Context, item QueuedWebhook) error {
+	return queue.change(func(state *webhookQueueState) error {
+		return state.update(item)
+	})
+}
+
+func (queue *FileWebhookQueue) Remove(ctx context.Context, id string) error {
+	return queue.change(func(state *webhookQueueState) error {
+		_, err := state.remove(id)
+		return err
+	})
+}
+
+func (queue *FileWebhookQueue) DeadLetter(ctx context.Context, item QueuedWebhook) error {
+	return queue.change(func(state *webhookQueueState) error {
+		return state.deadLetter(item)
+	})
+}
+
+func (queue *FileWebhookQueue) DeadLetters(ctx context.Context) ([]QueuedWebhook, error) {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+	return append([]QueuedWebhook{}, queue.state.Dead...), nil
+}
+
+func (queue *FileWebhookQueue) Replay(ctx context.Context, id string) error {
+	return queue.change(func(state *webhookQueueState) error {
+		return state.replay(id)
+	})
+}
+
+type WebhookProcessingOptions struct {
+	// Workers is the number of events processed at once. Defaults to 4.
+	Workers int
+	// MaxAttempts is the number of times an event is tried before it is dead
+	// lettered. Defaults to 8.
+	MaxAttempts int
+	// Backoff returns how long to wait after the given failed attempt, counting from
+	// 1. Defaults to 2^attempt seconds, capped at an hour.
+	Backoff func(attempt int) time.Duration
+	// PollInterval is how often the queue is checked for events that are due.
+	// Defaults to a second.
+	PollInterval time.Duration
+}
+
+func defaultWebhookBackoff(attempt int) time.Duration {
+	if attempt > 12 {
+		return time.Hour
+	}
+	backoff := time.Second << uint(attempt)
+	if backoff > time.Hour {
+		return time.Hour
+	}
+	return backoff
+}
+
+// enqueue queues event for Run, skipping redeliveries of events already queued or
+// handled.
+func (receiver *WebhookReceiver) enqueue(ctx context.Context, event WebhookEvent, body []byte) error {
+	if receiver.handler(event.Scope) == nil {
+		return nil
+	}
+
+	id := webhookEventID(event, body)
+
+	seen, err := receiver.options.Deduplicator.MarkSeen(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to check webhook hash: %w", err)
+	}
+	if seen {
+		return nil
+	}
+
+	now := time.Now()
+	item := QueuedWebhook{ID: id, Key: webhookOrderingKey(event, id), Event: event, EnqueuedAt: now, NextAttempt: now}
+	if err := receiver.options.Queue.Enqueue(ctx, item); err != nil {
+		if forgetErr := receiver.options.Deduplicator.Forget(ctx, id); forgetErr != nil {
+			return fmt.Errorf("failed to queue webhook: %w (and failed to forget hash: %v)", err, forgetErr)
+		}
+		return fmt.Errorf("failed to queue webhook: %w", err)
+	}
+
+	return nil
+}
+
+// webhookOrderingKey names the resource an event is about, such as order/250.
+// Events without an ID are keyed by their own ID and so are not ordered.
+func webhookOrderingKey(event WebhookEvent, id string) string {
+	var data struct {
+		Type string          `json:"type"`
+		ID   json.RawMessage `json:"id"`
+	}
+	json.Unmarshal(event.Data, &data)
+
+	resourceID := strings.Trim(string(data.ID), `"`)
+	if resourceID == "" || resourceID == "null" {
+		return string(event.Scope) + "#" + id
+	}
+
+	resource := data.Type
+	if resource == "" {
+		parts := strings.Split(string(event.Scope), "/")
+		if len(parts) > 1 {
+			resource = parts[1]
+		}
+	}
+
+	return resource + "/" + resourceID
+}
+
+// Run processes queued events until ctx is done. Events for the same resource are
+// handled one at a time in the order they arrived, so a later event waits while
+// an earlier one is being retried. A failed event is retried after Backoff and
+// dead-lettered once MaxAttempts is reached.
+func (receiver *WebhookReceiver) Run(ctx context.Context) error {
+	queue := receiver.options.Queue
+	if queue == nil {
+		return errors.New("webhook receiver: Run needs a Queue")
+	}
+
+	opts := receiver.options.Processing
+	if opts.Workers < 1 {
+		opts.Workers = 4
+	}
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 8
+	}
+	if opts.Backoff == nil {
+		opts.Backoff = defaultWebhookBackoff
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = time.Second
+	}
+
+	var mu sync.Mutex
+	inFlight := map[string]bool{}
+	// finished holds events processed since Pending was last called. Pending may
+	// still list them as they were, before being removed or rescheduled.
+	finished := map[string]bool{}
+	wake := make(chan struct{}, 1)
+	jobs := make(chan QueuedWebhook)
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range jobs {
+				receiver.process(ctx, item, opts)
+
+				mu.Lock()
+				delete(inFlight, item.Key)
+				finished[item.ID] = true
+				mu.Unlock()
+
+				select {
+				case wake <- struct{}{}:
+				default:
+				}
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(jobs)
+
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		mu.Lock()
+		finished = map[string]bool{}
+		mu.Unlock()
+
+		items, err := queue.Pending(ctx)
+		if err != nil {
+			receiver.logf("webhook queue: failed to list pending events: %v", err)
+		}
+
+		now := time.Now()
+		blocked := map[string]bool{}
+		for _, item := range items {
+			mu.Lock()
+			busy := inFlight[item.Key] || finished[item.ID]
+			mu.Unlock()
+
+			if blocked[item.Key] || busy {
+				blocked[item.Key] = true
+				continue
+			}
+			// Only the oldest event for a key may run.
+			blocked[item.Key] = true
+			if item.NextAttempt.After(now) {
+				continue
+			}
+
+			mu.Lock()
+			inFlight[item.Key] = true
+			mu.Unlock()
+
+			select {
+			case jobs <- item:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-wake:
+		}
+	}
+}
+
+// process handles one queued event and records the outcome in the queue.
+func (receiver *WebhookReceiver) process(ctx context.Context, item QueuedWebhook, opts WebhookProcessingOptions) {
+	queue := receiver.options.Queue
+
+	handler := receiver.handler(item.Event.Scope)
+	var err error
+	if handler != nil {
+		err = handler(ctx, item.Event)
+	}
+
+	if err == nil {
+		if err := queue.Remove(ctx, item.ID); err != nil {
+			receiver.logf("webhook queue: failed to remove %s: %v", item.ID, err)
+		}
+		return
+	}
+
+	item.Attempts++
+	item.LastError = err.Error()
+
+	if item.Attempts >= opts.MaxAttempts {
+		if err := queue.DeadLetter(ctx, item); err != nil {
+			receiver.logf("webhook queue: failed to dead-letter %s: %v", item.ID, err)
+		}
+		return
+	}
+
+	item.NextAttempt = time.Now().Add(opts.Backoff(item.Attempts))
+	if err := queue.Update(ctx, item); err != nil {
+		receiver.logf("webhook queue: failed to update %s: %v", item.ID, err)
+	}
+}
+
+// DeadLetters returns the events that ran out of attempts.
+func (receiver *WebhookReceiver) DeadLetters(ctx context.Context) ([]QueuedWebhook, error) {
+	if receiver.options.Queue == nil {
+		return nil, errors.New("webhook receiver: no Queue configured")
+	}
+	return receiver.options.Queue.DeadLetters(ctx)
+}
+
+// Replay queues a dead-lettered event again, for example once the bug that made it
+// fail has been fixed.
+func (receiver *WebhookReceiver) Replay(ctx context.Context, id string) error {
+	if receiver.options.Queue == nil {
+		return errors.New("webhook receiver: no Queue configured")
+	}
+	if err := receiver.options.Queue.Replay(ctx, id); err != nil {
+		return fmt.Errorf("failed to replay webhook %s: %w", id, err)
+	}
+	return nil
+}
+
+func (receiver *WebhookReceiver) logf(format string, v ...interface{}) {
+	if receiver.options.Client != nil && receiver.options.Client.V3.logger != nil {
+		receiver.options.Client.V3.logger.Printf(format, v...)
+	}
+}
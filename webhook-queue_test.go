@@ -0,0 +1,196 @@
+package bigcommerce
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWebhookQueueProcessing(t *testing.T) {
+	queue := NewMemoryWebhookQueue()
+	receiver := NewWebhookReceiver(WebhookReceiverOptions{
+		Queue: queue,
+		Processing: WebhookProcessingOptions{
+			Workers:      4,
+			MaxAttempts:  2,
+			Backoff:      func(attempt int) time.Duration { return time.Millisecond },
+			PollInterval: 5 * time.Millisecond,
+		},
+	})
+
+	var mu sync.Mutex
+	var statuses []string
+	failures := 0
+	done := make(chan struct{}, 10)
+	receiver.On(WebhookScopeOrderStatusUpdated, func(ctx context.Context, event WebhookEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		// The first event fails once; the second must wait for it.
+		if event.Hash == "a" && failures == 0 {
+			failures++
+			return errors.New("database down")
+		}
+		statuses = append(statuses, event.Hash)
+		done <- struct{}{}
+		return nil
+	})
+	receiver.On(WebhookScopeProductUpdated, func(ctx context.Context, event WebhookEvent) error {
+		done <- struct{}{}
+		return errors.New("always fails")
+	})
+
+	deliveries := []string{
+		`{"scope":"store/order/statusUpdated","store_id":"1","hash":"a","data":{"type":"order","id":250}}`,
+		`{"scope":"store/order/statusUpdated","store_id":"1","hash":"b","data":{"type":"order","id":250}}`,
+		`{"scope":"store/order/statusUpdated","store_id":"1","hash":"a","data":{"type":"order","id":250}}`,
+		`{"scope":"store/product/updated","store_id":"1","hash":"p","data":{"type":"product","id":7}}`,
+	}
+	for _, body := range deliveries {
+		if code := deliverWebhook(receiver, body, nil); code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", code)
+		}
+	}
+
+	pending, _ := queue.Pending(context.Background())
+	if len(pending) != 3 {
+		t.Fatalf("expected the redelivery to be skipped, got %d queued", len(pending))
+	}
+	if pending[0].Key != "order/250" || pending[2].Key != "product/7" {
+		t.Fatalf("unexpected keys %q and %q", pending[0].Key, pending[2].Key)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- receiver.Run(ctx) }()
+
+	for i := 0; i < 4; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for events")
+		}
+	}
+
+	var dead []QueuedWebhook
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		dead, _ = receiver.DeadLetters(context.Background())
+		pending, _ = queue.Pending(context.Background())
+		if len(dead) == 1 && len(pending) == 0 {
+			break
+		}
+	}
+	cancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected Run to stop with context.Canceled, got %v", err)
+	}
+
+	mu.Lock()
+	if len(statuses) != 2 || statuses[0] != "a" || statuses[1] != "b" {
+		t.Fatalf("expected order events in order after the retry, got %v", statuses)
+	}
+	mu.Unlock()
+
+	if len(dead) != 1 || dead[0].Event.Hash != "p" || dead[0].Attempts != 2 || dead[0].LastError != "always fails" {
+		t.Fatalf("unexpected dead letters %+v", dead)
+	}
+
+	if err := receiver.Replay(context.Background(), dead[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	pending, _ = queue.Pending(context.Background())
+	if len(pending) != 1 || pending[0].Attempts != 0 {
+		t.Fatalf("expected the replayed event to be queued afresh, got %+v", pending)
+	}
+	if err := receiver.Replay(context.Background(), dead[0].ID); !errors.Is(err, ErrQueuedWebhookNotFound) {
+		t.Fatalf("expected ErrQueuedWebhookNotFound, got %v", err)
+	}
+}
+
+// An event finishing while Run waits to hand out another must not be handed out
+// again from the list of pending events Run read before it finished.
+func TestWebhookQueueRun_NoStaleDispatch(t *testing.T) {
+	queue := NewMemoryWebhookQueue()
+	receiver := NewWebhookReceiver(WebhookReceiverOptions{
+		Queue:      queue,
+		Processing: WebhookProcessingOptions{Workers: 1, PollInterval: 5 * time.Millisecond},
+	})
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+	receiver.On(WebhookScopeOrderUpdated, func(ctx context.Context, event WebhookEvent) error {
+		mu.Lock()
+		calls[event.Hash]++
+		mu.Unlock()
+		if event.Hash == "slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		return nil
+	})
+
+	// The older event only falls due while the slow one is being handled, so it
+	// is listed first and Run waits for the worker to hand it out.
+	ctx := context.Background()
+	now := time.Now()
+	queue.Enqueue(ctx, QueuedWebhook{ID: "later", Key: "order/1", Event: WebhookEvent{Scope: WebhookScopeOrderUpdated, Hash: "later"}, NextAttempt: now.Add(100 * time.Millisecond)})
+	queue.Enqueue(ctx, QueuedWebhook{ID: "slow", Key: "order/2", Event: WebhookEvent{Scope: WebhookScopeOrderUpdated, Hash: "slow"}, NextAttempt: now})
+
+	runCtx, cancel := context.WithCancel(ctx)
+	errs := make(chan error, 1)
+	go func() { errs <- receiver.Run(runCtx) }()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if pending, _ := queue.Pending(ctx); len(pending) == 0 {
+			break
+		}
+	}
+	time.Sleep(300 * time.Millisecond)
+	cancel()
+	<-errs
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls["slow"] != 1 || calls["later"] != 1 {
+		t.Fatalf("expected each event handled once, got %v", calls)
+	}
+}
+
+func TestFileWebhookQueue(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "webhooks.json")
+
+	queue, err := NewFileWebhookQueue(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		item := QueuedWebhook{ID: id, Key: "order/1", Event: WebhookEvent{Scope: WebhookScopeOrderUpdated, Hash: id}}
+		if err := queue.Enqueue(ctx, item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := queue.Remove(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := queue.DeadLetter(ctx, QueuedWebhook{ID: "b", Key: "order/1", Attempts: 8, LastError: "boom"}); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewFileWebhookQueue(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending, _ := reopened.Pending(ctx)
+	dead, _ := reopened.DeadLetters(ctx)
+	if len(pending) != 1 || pending[0].ID != "c" || pending[0].Event.Scope != WebhookScopeOrderUpdated {
+		t.Fatalf("unexpected pending %+v", pending)
+	}
+	if len(dead) != 1 || dead[0].ID != "b" || dead[0].LastError != "boom" {
+		t.Fatalf("unexpected dead letters %+v", dead)
+	}
+	if err := reopened.Update(ctx, QueuedWebhook{ID: "missing"}); !errors.Is(err, ErrQueuedWebhookNotFound) {
+		t.Fatalf("expected ErrQueuedWebhookNotFound, got %v", err)
+	}
+}
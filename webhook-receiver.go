@@ -0,0 +1,465 @@
+package bigcommerce
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookEvent is the envelope BigCommerce posts for every webhook delivery. Data
+// holds the event specific part, such as {"type": "order", "id": 250}.
+type WebhookEvent struct {
+	Scope     WebhookScope    `json:"scope"`
+	StoreID   string          `json:"store_id"`
+	Data      json.RawMessage `json:"data"`
+	Hash      string          `json:"hash"`
+	CreatedAt int64           `json:"created_at"`
+	Producer  string          `json:"producer"`
+}
+
+// UnmarshalJSON accepts store_id as a string or a number, as BigCommerce sends
+// both.
+func (event *WebhookEvent) UnmarshalJSON(b []byte) error {
+	type envelope WebhookEvent
+	var raw struct {
+		envelope
+		StoreID json.RawMessage `json:"store_id"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	*event = WebhookEvent(raw.envelope)
+	event.StoreID = strings.Trim(string(raw.StoreID), `"`)
+	if event.StoreID == "null" {
+		event.StoreID = ""
+	}
+	return nil
+}
+
+// Time returns when the event happened.
+func (event WebhookEvent) Time() time.Time {
+	return time.Unix(event.CreatedAt, 0)
+}
+
+type webhookEventData struct {
+	Type      string                  `json:"type"`
+	ID        int                     `json:"id"`
+	Status    *OrderStatusChange      `json:"status,omitempty"`
+	Inventory *ProductInventoryChange `json:"inventory,omitempty"`
+}
+
+type OrderStatusChange struct {
+	PreviousStatusID int `json:"previous_status_id"`
+	NewStatusID      int `json:"new_status_id"`
+}
+
+type ProductInventoryChange struct {
+	ProductID int `json:"product_id"`
+	VariantID int `json:"variant_id,omitempty"`
+	// Method is "absolute" when Value is the new level and "relative" when it is
+	// the change.
+	Method string `json:"method"`
+	Value  int    `json:"value"`
+}
+
+type OrderEvent struct {
+	WebhookEvent
+	OrderID int
+	// Status is set for store/order/statusUpdated events.
+	Status *OrderStatusChange
+	// Order is the full order when the receiver hydrates orders.
+	Order *Order
+}
+
+type ProductEvent struct {
+	WebhookEvent
+	ProductID int
+	// Inventory is set for inventory events.
+	Inventory *ProductInventoryChange
+	// Product is the full product when the receiver hydrates products. It is never
+	// set for deletions.
+	Product *Product
+}
+
+type CategoryEvent struct {
+	WebhookEvent
+	CategoryID int
+}
+
+type CustomerEvent struct {
+	WebhookEvent
+	CustomerID int
+}
+
+// WebhookDeduplicator remembers which deliveries have been handled so that
+// redeliveries of the same event are skipped.
+type WebhookDeduplicator interface {
+	// MarkSeen records hash and reports whether it had already been recorded.
+	MarkSeen(ctx context.Context, hash string) (bool, error)
+	// Forget removes hash, so that a delivery that failed is handled when it is
+	// redelivered.
+	Forget(ctx context.Context, hash string) error
+}
+
+// MemoryWebhookDeduplicator keeps hashes in memory for a while. It is enough for
+// a single process; use a shared store behind WebhookDeduplicator when several
+// processes receive webhooks.
+type MemoryWebhookDeduplicator struct {
+	ttl  time.Duration
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewMemoryWebhookDeduplicator remembers hashes for ttl, which defaults to 48
+// hours, longer than BigCommerce keeps retrying a delivery.
+func NewMemoryWebhookDeduplicator(ttl time.Duration) *MemoryWebhookDeduplicator {
+	if ttl <= 0 {
+		ttl = 48 * time.Hour
+	}
+	return &MemoryWebhookDeduplicator{ttl: ttl, seen: map[string]time.Time{}}
+}
+
+func (dedupe *MemoryWebhookDeduplicator) MarkSeen(ctx context.Context, hash string) (bool, error) {
+	dedupe.mu.Lock()
+	defer dedupe.mu.Unlock()
+
+	now := time.Now()
+	for h, expires := range dedupe.seen {
+		if now.After(expires) {
+			delete(dedupe.seen, h)
+		}
+	}
+
+	if _, ok := dedupe.seen[hash]; ok {
+		return true, nil
+	}
+	dedupe.seen[hash] = now.Add(dedupe.ttl)
+	return false, nil
+}
+
+func (dedupe *MemoryWebhookDeduplicator) Forget(ctx context.Context, hash string) error {
+	dedupe.mu.Lock()
+	defer dedupe.mu.Unlock()
+
+	delete(dedupe.seen, hash)
+	return nil
+}
+
+type WebhookReceiverOptions struct {
+	// Headers must be present with these values on every delivery. Set the same
+	// headers on the hooks, see WebhookSubscription.Headers.
+	Headers map[string]string
+	// Client is used to hydrate events. Required when HydrateOrders or
+	// HydrateProducts is set.
+	Client *Client
+	// HydrateOrders fetches the order for every order event before its handler runs.
+	HydrateOrders bool
+	// HydrateProducts fetches the product for every product event other than
+	// deletions before its handler runs.
+	HydrateProducts bool
+	// Deduplicator defaults to NewMemoryWebhookDeduplicator(0).
+	Deduplicator WebhookDeduplicator
+	// MaxBodyBytes limits the size of a delivery. Defaults to 1MB.
+	MaxBodyBytes int64
+	// Queue, when set, makes the receiver acknowledge deliveries as soon as they are
+	// queued. Handlers then run from Run, see WebhookQueue.
+	Queue WebhookQueue
+	// Processing configures Run. It is only used with Queue.
+	Processing WebhookProcessingOptions
+}
+
+// WebhookReceiver is an http.Handler for webhook deliveries. It checks the
+// configured headers, skips redeliveries and calls the handler registered for the
+// event's scope.
+//
+// A delivery is acknowledged with 200 once its handler succeeds or when no
+// handler is registered for its scope. When a handler or hydration fails the
+// receiver responds 500, so BigCommerce delivers the event again later. With a
+// Queue, deliveries are acknowledged once queued instead.
+type WebhookReceiver struct {
+	options  WebhookReceiverOptions
+	mu       sync.RWMutex
+	handlers map[WebhookScope]func(ctx context.Context, event WebhookEvent) error
+}
+
+func NewWebhookReceiver(options WebhookReceiverOptions) *WebhookReceiver {
+	if options.Deduplicator == nil {
+		options.Deduplicator = NewMemoryWebhookDeduplicator(0)
+	}
+	if options.MaxBodyBytes <= 0 {
+		options.MaxBodyBytes = 1 << 20
+	}
+	return &WebhookReceiver{
+		options:  options,
+		handlers: map[WebhookScope]func(ctx context.Context, event WebhookEvent) error{},
+	}
+}
+
+func (receiver *WebhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !receiver.verifyHeaders(r.Header) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, receiver.options.MaxBodyBytes+1))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > receiver.options.MaxBodyBytes {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil || event.Scope == "" {
+		http.Error(w, "invalid webhook payload", http.StatusBadRequest)
+		return
+	}
+
+	handle := receiver.Handle
+	if receiver.options.Queue != nil {
+		handle = receiver.enqueue
+	}
+
+	if err := handle(r.Context(), event, body); err != nil {
+		receiver.logf("webhook %s failed: %v", event.Scope, err)
+		http.Error(w, "webhook handler failed", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Handle runs the handler for event unless it has been handled before. body is
+// the raw delivery and is only used to identify events that have no hash.
+func (receiver *WebhookReceiver) Handle(ctx context.Context, event WebhookEvent, body []byte) error {
+	handler := receiver.handler(event.Scope)
+	if handler == nil {
+		return nil
+	}
+
+	hash := webhookEventID(event, body)
+
+	seen, err := receiver.options.Deduplicator.MarkSeen(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to check webhook hash: %w", err)
+	}
+	if seen {
+		return nil
+	}
+
+	if err := handler(ctx, event); err != nil {
+		if forgetErr := receiver.options.Deduplicator.Forget(ctx, hash); forgetErr != nil {
+			return fmt.Errorf("%w (and failed to forget hash: %v)", err, forgetErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// webhookEventID identifies a delivery by its hash, or by the body for events
+// without one. BigCommerce's hash covers data alone, so the same change made
+// twice, such as an order moved back to a status it had, shares a hash; the
+// scope, store and created_at tell those apart while redeliveries still match.
+func webhookEventID(event WebhookEvent, body []byte) string {
+	if event.Hash != "" {
+		body = []byte(fmt.Sprintf("%s\n%s\n%d\n%s", event.Scope, event.StoreID, event.CreatedAt, event.Hash))
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+func (receiver *WebhookReceiver) verifyHeaders(headers http.Header) bool {
+	ok := true
+	for name, want := range receiver.options.Headers {
+		got := headers.Get(name)
+		if subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+			ok = false
+		}
+	}
+	return ok
+}
+
+// handler returns the handler for scope, falling back to a wildcard handler such
+// as one for store/order/*.
+func (receiver *WebhookReceiver) handler(scope WebhookScope) func(ctx context.Context, event WebhookEvent) error {
+	receiver.mu.RLock()
+	defer receiver.mu.RUnlock()
+
+	if handler, ok := receiver.handlers[scope]; ok {
+		return handler
+	}
+
+	parts := strings.Split(string(scope), "/")
+	for i := len(parts) - 1; i > 0; i-- {
+		wildcard := WebhookScope(strings.Join(parts[:i], "/") + "/*")
+		if handler, ok := receiver.handlers[wildcard]; ok {
+			return handler
+		}
+	}
+
+	return nil
+}
+
+// On registers handler for scope, replacing any handler already registered for
+// it. scope may be a wildcard like store/cart/*, which is used for events without
+// a handler of their own.
+func (receiver *WebhookReceiver) On(scope WebhookScope, handler func(ctx context.Context, event WebhookEvent) error) {
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+
+	receiver.handlers[scope] = handler
+}
+
+func (receiver *WebhookReceiver) OnOrderCreated(handler func(ctx context.Context, event OrderEvent) error) {
+	receiver.onOrder(WebhookScopeOrderCreated, handler)
+}
+
+func (receiver *WebhookReceiver) OnOrderUpdated(handler func(ctx context.Context, event OrderEvent) error) {
+	receiver.onOrder(WebhookScopeOrderUpdated, handler)
+}
+
+func (receiver *WebhookReceiver) OnOrderStatusUpdated(handler func(ctx context.Context, event OrderEvent) error) {
+	receiver.onOrder(WebhookScopeOrderStatusUpdated, handler)
+}
+
+func (receiver *WebhookReceiver) OnOrderArchived(handler func(ctx context.Context, event OrderEvent) error) {
+	receiver.onOrder(WebhookScopeOrderArchived, handler)
+}
+
+func (receiver *WebhookReceiver) OnProductCreated(handler func(ctx context.Context, event ProductEvent) error) {
+	receiver.onProduct(WebhookScopeProductCreated, handler)
+}
+
+func (receiver *WebhookReceiver) OnProductUpdated(handler func(ctx context.Context, event ProductEvent) error) {
+	receiver.onProduct(WebhookScopeProductUpdated, handler)
+}
+
+func (receiver *WebhookReceiver) OnProductDeleted(handler func(ctx context.Context, event ProductEvent) error) {
+	receiver.onProduct(WebhookScopeProductDeleted, handler)
+}
+
+func (receiver *WebhookReceiver) OnProductInventoryUpdated(handler func(ctx context.Context, event ProductEvent) error) {
+	receiver.onProduct(WebhookScopeProductInventoryUpdated, handler)
+}
+
+func (receiver *WebhookReceiver) OnCategoryCreated(handler func(ctx context.Context, event CategoryEvent) error) {
+	receiver.onCategory(WebhookScopeCategoryCreated, handler)
+}
+
+func (receiver *WebhookReceiver) OnCategoryUpdated(handler func(ctx context.Context, event CategoryEvent) error) {
+	receiver.onCategory(WebhookScopeCategoryUpdated, handler)
+}
+
+func (receiver *WebhookReceiver) OnCategoryDeleted(handler func(ctx context.Context, event CategoryEvent) error) {
+	receiver.onCategory(WebhookScopeCategoryDeleted, handler)
+}
+
+func (receiver *WebhookReceiver) OnCustomerCreated(handler func(ctx context.Context, event CustomerEvent) error) {
+	receiver.onCustomer(WebhookScopeCustomerCreated, handler)
+}
+
+func (receiver *WebhookReceiver) OnCustomerUpdated(handler func(ctx context.Context, event CustomerEvent) error) {
+	receiver.onCustomer(WebhookScopeCustomerUpdated, handler)
+}
+
+func (receiver *WebhookReceiver) OnCustomerDeleted(handler func(ctx context.Context, event CustomerEvent) error) {
+	receiver.onCustomer(WebhookScopeCustomerDeleted, handler)
+}
+
+func (receiver *WebhookReceiver) onOrder(scope WebhookScope, handler func(ctx context.Context, event OrderEvent) error) {
+	receiver.On(scope, func(ctx context.Context, event WebhookEvent) error {
+		data, err := decodeWebhookData(event)
+		if err != nil {
+			return err
+		}
+
+		orderEvent := OrderEvent{WebhookEvent: event, OrderID: data.ID, Status: data.Status}
+
+		if receiver.options.HydrateOrders {
+			if receiver.options.Client == nil {
+				return fmt.Errorf("webhook receiver: HydrateOrders needs a Client")
+			}
+			order, err := receiver.options.Client.V2.GetOrder(data.ID)
+			if err != nil {
+				return fmt.Errorf("failed to hydrate order %d: %w", data.ID, err)
+			}
+			orderEvent.Order = &order
+		}
+
+		return handler(ctx, orderEvent)
+	})
+}
+
+func (receiver *WebhookReceiver) onProduct(scope WebhookScope, handler func(ctx context.Context, event ProductEvent) error) {
+	receiver.On(scope, func(ctx context.Context, event WebhookEvent) error {
+		data, err := decodeWebhookData(event)
+		if err != nil {
+			return err
+		}
+
+		productEvent := ProductEvent{WebhookEvent: event, ProductID: data.ID, Inventory: data.Inventory}
+
+		if receiver.options.HydrateProducts && scope != WebhookScopeProductDeleted {
+			if receiver.options.Client == nil {
+				return fmt.Errorf("webhook receiver: HydrateProducts needs a Client")
+			}
+			product, err := receiver.options.Client.V3.GetProduct(data.ID, LimitedProductQueryParams{})
+			if err != nil {
+				return fmt.Errorf("failed to hydrate product %d: %w", data.ID, err)
+			}
+			productEvent.Product = &product
+		}
+
+		return handler(ctx, productEvent)
+	})
+}
+
+func (receiver *WebhookReceiver) onCategory(scope WebhookScope, handler func(ctx context.Context, event CategoryEvent) error) {
+	receiver.On(scope, func(ctx context.Context, event WebhookEvent) error {
+		data, err := decodeWebhookData(event)
+		if err != nil {
+			return err
+		}
+		return handler(ctx, CategoryEvent{WebhookEvent: event, CategoryID: data.ID})
+	})
+}
+
+func (receiver *WebhookReceiver) onCustomer(scope WebhookScope, handler func(ctx context.Context, event CustomerEvent) error) {
+	receiver.On(scope, func(ctx context.Context, event WebhookEvent) error {
+		data, err := decodeWebhookData(event)
+		if err != nil {
+			return err
+		}
+		return handler(ctx, CustomerEvent{WebhookEvent: event, CustomerID: data.ID})
+	})
+}
+
+func decodeWebhookData(event WebhookEvent) (webhookEventData, error) {
+	var data webhookEventData
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return data, fmt.Errorf("failed to decode %s webhook data: %w", event.Scope, err)
+	}
+	if data.ID == 0 {
+		return data, fmt.Errorf("%s webhook data has no id: %s", event.Scope, strconv.Quote(string(event.Data)))
+	}
+	return data, nil
+}
@@ -0,0 +1,123 @@
+package bigcommerce
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func deliverWebhook(receiver http.Handler, body string, headers map[string]string) int {
+	r := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	for name, value := range headers {
+		r.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	receiver.ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestWebhookReceiver(t *testing.T) {
+	orders := 0
+	client := newTestServerClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stores/test/v2/orders/250" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		orders++
+		json.NewEncoder(w).Encode(Order{ID: 250, Status: "Awaiting Fulfillment"})
+	}))
+
+	secret := map[string]string{"X-Webhook-Secret": "s3cret"}
+	receiver := NewWebhookReceiver(WebhookReceiverOptions{
+		Headers:       secret,
+		Client:        client,
+		HydrateOrders: true,
+	})
+
+	var created []OrderEvent
+	receiver.OnOrderCreated(func(ctx context.Context, event OrderEvent) error {
+		created = append(created, event)
+		return nil
+	})
+
+	var statusChanges []OrderEvent
+	failNext := true
+	receiver.OnOrderStatusUpdated(func(ctx context.Context, event OrderEvent) error {
+		if failNext {
+			failNext = false
+			return errors.New("database down")
+		}
+		statusChanges = append(statusChanges, event)
+		return nil
+	})
+
+	var carts []WebhookScope
+	receiver.On(WebhookScopeCartAll, func(ctx context.Context, event WebhookEvent) error {
+		carts = append(carts, event.Scope)
+		return nil
+	})
+
+	orderCreated := `{"scope":"store/order/created","store_id":"1025646","data":{"type":"order","id":250},"hash":"abc","created_at":1561479335,"producer":"stores/abc123"}`
+
+	if code := deliverWebhook(receiver, orderCreated, nil); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 without headers, got %d", code)
+	}
+	if code := deliverWebhook(receiver, orderCreated, map[string]string{"X-Webhook-Secret": "wrong"}); code != http.StatusUnauthorized {
+		t.Errorf("expected 401 with wrong header, got %d", code)
+	}
+	if code := deliverWebhook(receiver, `{"scope":`, secret); code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a broken payload, got %d", code)
+	}
+
+	// Redeliveries of the same hash are only handled once.
+	for i := 0; i < 2; i++ {
+		if code := deliverWebhook(receiver, orderCreated, secret); code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", code)
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("expected 1 order event, got %d", len(created))
+	}
+	event := created[0]
+	if event.OrderID != 250 || event.StoreID != "1025646" || event.Order == nil || event.Order.Status != "Awaiting Fulfillment" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if orders != 1 {
+		t.Errorf("expected the order fetched once, got %d", orders)
+	}
+
+	// The hash only covers data, so a later event with the same data is new.
+	later := strings.Replace(orderCreated, `"created_at":1561479335`, `"created_at":1561479400`, 1)
+	if code := deliverWebhook(receiver, later, secret); code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if len(created) != 2 {
+		t.Fatalf("expected a second order event for a later created_at, got %d", len(created))
+	}
+
+	// A failed handler is retried when the event is redelivered.
+	statusUpdated := `{"scope":"store/order/statusUpdated","store_id":1025646,"data":{"type":"order","id":250,"status":{"previous_status_id":0,"new_status_id":11}},"hash":"def","created_at":1561479335}`
+	if code := deliverWebhook(receiver, statusUpdated, secret); code != http.StatusInternalServerError {
+		t.Errorf("expected 500 when the handler fails, got %d", code)
+	}
+	if code := deliverWebhook(receiver, statusUpdated, secret); code != http.StatusOK {
+		t.Errorf("expected 200 on redelivery, got %d", code)
+	}
+	if len(statusChanges) != 1 || statusChanges[0].Status.NewStatusID != 11 || statusChanges[0].StoreID != "1025646" {
+		t.Errorf("unexpected status events %+v", statusChanges)
+	}
+
+	// Wildcard handlers catch scopes without their own handler, and unhandled
+	// scopes are acknowledged.
+	deliverWebhook(receiver, `{"scope":"store/cart/abandoned","data":{"type":"cart","id":"x"},"hash":"ghi"}`, secret)
+	if len(carts) != 1 || carts[0] != WebhookScopeCartAbandoned {
+		t.Errorf("expected wildcard handler to run, got %v", carts)
+	}
+	if code := deliverWebhook(receiver, `{"scope":"store/customer/created","data":{"type":"customer","id":1},"hash":"jkl"}`, secret); code != http.StatusOK {
+		t.Errorf("expected 200 for an unhandled scope, got %d", code)
+	}
+}
@@ -0,0 +1,218 @@
+package bigcommerce
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// WebhookScope is the event a webhook subscribes to. Scopes ending in /* match
+// every event under them.
+type WebhookScope string
+
+const (
+	WebhookScopeStoreAll            WebhookScope = "store/*"
+	WebhookScopeAppUninstalled      WebhookScope = "store/app/uninstalled"
+	WebhookScopeInformationUpdated  WebhookScope = "store/information/updated"
+	WebhookScopeOrderAll            WebhookScope = "store/order/*"
+	WebhookScopeOrderCreated        WebhookScope = "store/order/created"
+	WebhookScopeOrderUpdated        WebhookScope = "store/order/updated"
+	WebhookScopeOrderArchived       WebhookScope = "store/order/archived"
+	WebhookScopeOrderStatusUpdated  WebhookScope = "store/order/statusUpdated"
+	WebhookScopeOrderMessageCreated WebhookScope = "store/order/message/created"
+	WebhookScopeOrderRefundCreated  WebhookScope = "store/order/refund/created"
+
+	WebhookScopeProductAll     WebhookScope = "store/product/*"
+	WebhookScopeProductCreated WebhookScope = "store/product/created"
+	WebhookScopeProductUpdated WebhookScope = "store/product/updated"
+	WebhookScopeProductDeleted WebhookScope = "store/product/deleted"
+
+	WebhookScopeProductInventoryUpdated      WebhookScope = "store/product/inventory/updated"
+	WebhookScopeProductInventoryOrderUpdated WebhookScope = "store/product/inventory/order/updated"
+	WebhookScopeSKUAll                       WebhookScope = "store/sku/*"
+	WebhookScopeSKUCreated                   WebhookScope = "store/sku/created"
+	WebhookScopeSKUUpdated                   WebhookScope = "store/sku/updated"
+	WebhookScopeSKUDeleted                   WebhookScope = "store/sku/deleted"
+	WebhookScopeSKUInventoryUpdated          WebhookScope = "store/sku/inventory/updated"
+	WebhookScopeSKUInventoryOrderUpdated     WebhookScope = "store/sku/inventory/order/updated"
+
+	WebhookScopeCategoryAll     WebhookScope = "store/category/*"
+	WebhookScopeCategoryCreated WebhookScope = "store/category/created"
+	WebhookScopeCategoryUpdated WebhookScope = "store/category/updated"
+	WebhookScopeCategoryDeleted WebhookScope = "store/category/deleted"
+
+	WebhookScopeCustomerAll            WebhookScope = "store/customer/*"
+	WebhookScopeCustomerCreated        WebhookScope = "store/customer/created"
+	WebhookScopeCustomerUpdated        WebhookScope = "store/customer/updated"
+	WebhookScopeCustomerDeleted        WebhookScope = "store/customer/deleted"
+	WebhookScopeCustomerAddressCreated WebhookScope = "store/customer/address/created"
+	WebhookScopeCustomerAddressUpdated WebhookScope = "store/customer/address/updated"
+	WebhookScopeCustomerAddressDeleted WebhookScope = "store/customer/address/deleted"
+
+	WebhookScopeCartAll             WebhookScope = "store/cart/*"
+	WebhookScopeCartCreated         WebhookScope = "store/cart/created"
+	WebhookScopeCartUpdated         WebhookScope = "store/cart/updated"
+	WebhookScopeCartDeleted         WebhookScope = "store/cart/deleted"
+	WebhookScopeCartCouponApplied   WebhookScope = "store/cart/couponApplied"
+	WebhookScopeCartAbandoned       WebhookScope = "store/cart/abandoned"
+	WebhookScopeCartConverted       WebhookScope = "store/cart/converted"
+	WebhookScopeCartLineItemAll     WebhookScope = "store/cart/lineItem/*"
+	WebhookScopeCartLineItemCreated WebhookScope = "store/cart/lineItem/created"
+	WebhookScopeCartLineItemUpdated WebhookScope = "store/cart/lineItem/updated"
+	WebhookScopeCartLineItemDeleted WebhookScope = "store/cart/lineItem/deleted"
+
+	WebhookScopeShipmentAll     WebhookScope = "store/shipment/*"
+	WebhookScopeShipmentCreated WebhookScope = "store/shipment/created"
+	WebhookScopeShipmentUpdated WebhookScope = "store/shipment/updated"
+	WebhookScopeShipmentDeleted WebhookScope = "store/shipment/deleted"
+)
+
+type Webhook struct {
+	ID                   int               `json:"id"`
+	ClientID             string            `json:"client_id"`
+	StoreHash            string            `json:"store_hash"`
+	Scope                WebhookScope      `json:"scope"`
+	Destination          string            `json:"destination"`
+	Headers              map[string]string `json:"headers"`
+	IsActive             bool              `json:"is_active"`
+	EventsHistoryEnabled bool              `json:"events_history_enabled"`
+	CreatedAt            int64             `json:"created_at"`
+	UpdatedAt            int64             `json:"updated_at"`
+}
+
+type WebhookQueryParams struct {
+	Page        int          `url:"page,omitempty"`
+	Limit       int          `url:"limit,omitempty"`
+	IsActive    *bool        `url:"is_active,omitempty"`
+	Scope       WebhookScope `url:"scope,omitempty"`
+	Destination string       `url:"destination,omitempty"`
+}
+
+type CreateWebhookParams struct {
+	Scope                WebhookScope      `json:"scope"`
+	Destination          string            `json:"destination"`
+	IsActive             bool              `json:"is_active"`
+	EventsHistoryEnabled bool              `json:"events_history_enabled,omitempty"`
+	Headers              map[string]string `json:"headers,omitempty"`
+}
+
+type UpdateWebhookParams struct {
+	Scope                WebhookScope `json:"scope,omitempty"`
+	Destination          string       `json:"destination,omitempty"`
+	IsActive             *bool        `json:"is_active,omitempty"`
+	EventsHistoryEnabled *bool        `json:"events_history_enabled,omitempty"`
+	// Headers is a pointer so that a webhook's headers can be cleared by sending an
+	// empty map. Nil leaves them unchanged.
+	Headers *map[string]string `json:"headers,omitempty"`
+}
+
+func (client *V3Client) GetWebhooks(params WebhookQueryParams) ([]Webhook, MetaData, error) {
+	type ResponseObject struct {
+		Data []Webhook `json:"data"`
+		Meta MetaData  `json:"meta"`
+	}
+	var response ResponseObject
+
+	path, err := urlWithQueryParams(client.constructURL("/hooks"), params)
+	if err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to construct URL for GetWebhooks: %w", err)
+	}
+
+	if err := client.Get(path, &response); err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	return response.Data, response.Meta, nil
+}
+
+func (client *V3Client) GetAllWebhooks(params WebhookQueryParams) ([]Webhook, error) {
+	var webhooks []Webhook
+	params.Page = 1
+	if params.Limit < 1 {
+		params.Limit = 250
+	}
+
+	for {
+		w, meta, err := client.GetWebhooks(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get all webhooks at page %d: %w", params.Page, err)
+		}
+		webhooks = append(webhooks, w...)
+
+		if meta.Pagination.CurrentPage >= meta.Pagination.TotalPages {
+			break
+		}
+
+		params.Page++
+	}
+
+	return webhooks, nil
+}
+
+func (client *V3Client) GetWebhook(id int) (Webhook, error) {
+	type ResponseObject struct {
+		Data Webhook  `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	path := client.constructURL("/hooks", strconv.Itoa(id))
+
+	if err := client.Get(path, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to get webhook %d: %w", id, err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) CreateWebhook(params CreateWebhookParams) (Webhook, error) {
+	type ResponseObject struct {
+		Data Webhook  `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	if params.Scope == "" || params.Destination == "" {
+		return response.Data, fmt.Errorf("failed to create webhook: Scope and Destination are required")
+	}
+
+	path := client.constructURL("/hooks")
+
+	if err := client.Post(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to create webhook for %s: %w", params.Scope, err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) UpdateWebhook(id int, params UpdateWebhookParams) (Webhook, error) {
+	type ResponseObject struct {
+		Data Webhook  `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	path := client.constructURL("/hooks", strconv.Itoa(id))
+
+	if err := client.Put(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to update webhook %d: %w", id, err)
+	}
+
+	return response.Data, nil
+}
+
+// DeleteWebhook deletes a webhook and returns it as it was.
+func (client *V3Client) DeleteWebhook(id int) (Webhook, error) {
+	type ResponseObject struct {
+		Data Webhook  `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	path := client.constructURL("/hooks", strconv.Itoa(id))
+
+	if err := client.Delete(path, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to delete webhook %d: %w", id, err)
+	}
+
+	return response.Data, nil
+}
@@ -0,0 +1,182 @@
+package bigcommerce
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+type InstallWidgetParams struct {
+	// Template is installed unless TemplateUUID names an existing template. A
+	// template with the same name on the same channel, channel 1 when
+	// Template.ChannelID is zero, is reused rather than duplicated. If its
+	// template, schema or query differ it is updated with a new version first.
+	Template     CreateWidgetTemplateParams
+	TemplateUUID string
+
+	// WidgetName defaults to the template name.
+	WidgetName          string
+	WidgetDescription   string
+	WidgetConfiguration map[string]interface{}
+
+	TemplateFile WidgetTemplateFile
+	Region       string
+	// EntityID limits the placement to one product, category, brand or page. Zero
+	// places the widget on every page using TemplateFile.
+	EntityID  int
+	SortOrder int
+	// Status defaults to active.
+	Status    PlacementStatus
+	ChannelID int
+}
+
+type WidgetInstallation struct {
+	Template  WidgetTemplate
+	Widget    Widget
+	Placement Placement
+}
+
+// InstallWidget installs a widget template, creates a widget from it and places the
+// widget in a region of a template file, in one call. The region is checked
+// against the theme first. If a later step fails, whatever this call created is
+// deleted again so no half-installed widget is left behind. An existing template
+// that was updated is not restored, though widgets already made from it keep the
+// version they were made from.
+func (client *V3Client) InstallWidget(params InstallWidgetParams) (WidgetInstallation, error) {
+	var installation WidgetInstallation
+
+	if params.TemplateFile == "" || params.Region == "" {
+		return installation, fmt.Errorf("install widget: TemplateFile and Region are required")
+	}
+	if params.Status == "" {
+		params.Status = PlacementActive
+	}
+
+	regions, err := client.GetRegions(params.TemplateFile)
+	if err != nil {
+		return installation, fmt.Errorf("install widget: %w", err)
+	}
+	if !hasRegion(regions, params.Region) {
+		return installation, fmt.Errorf("install widget: %s has no region %q", params.TemplateFile, params.Region)
+	}
+
+	template, createdTemplate, err := client.installWidgetTemplate(params)
+	if err != nil {
+		return installation, fmt.Errorf("install widget: %w", err)
+	}
+	installation.Template = template
+
+	rollback := func(cause error, widgetUUID string) error {
+		if widgetUUID != "" {
+			if err := client.DeleteWidget(widgetUUID); err != nil {
+				return fmt.Errorf("install widget: %w (rollback also failed: %v)", cause, err)
+			}
+		}
+		if createdTemplate {
+			if err := client.DeleteWidgetTemplate(template.UUID); err != nil {
+				return fmt.Errorf("install widget: %w (rollback also failed: %v)", cause, err)
+			}
+		}
+		return fmt.Errorf("install widget: %w", cause)
+	}
+
+	name := params.WidgetName
+	if name == "" {
+		name = template.Name
+	}
+
+	widget, err := client.CreateWidget(CreateWidgetParams{
+		Name:                name,
+		Description:         params.WidgetDescription,
+		WidgetConfiguration: params.WidgetConfiguration,
+		WidgetTemplateUUID:  template.UUID,
+		ChannelID:           params.ChannelID,
+	})
+	if err != nil {
+		return installation, rollback(err, "")
+	}
+	installation.Widget = widget
+
+	placementParams := CreatePlacementParams{
+		WidgetUUID:   widget.UUID,
+		SortOrder:    params.SortOrder,
+		Region:       params.Region,
+		TemplateFile: params.TemplateFile,
+		Status:       params.Status,
+		ChannelID:    params.ChannelID,
+	}
+	if params.EntityID != 0 {
+		placementParams.EntityID = strconv.Itoa(params.EntityID)
+	}
+
+	placement, err := client.CreatePlacement(placementParams)
+	if err != nil {
+		return installation, rollback(err, widget.UUID)
+	}
+	installation.Placement = placement
+
+	return installation, nil
+}
+
+// installWidgetTemplate returns the template to build the widget from and whether
+// it was created by this call.
+func (client *V3Client) installWidgetTemplate(params InstallWidgetParams) (WidgetTemplate, bool, error) {
+	if params.TemplateUUID != "" {
+		template, err := client.GetWidgetTemplate(params.TemplateUUID)
+		return template, false, err
+	}
+
+	// Templates are created on the default channel when none is given.
+	channelID := params.Template.ChannelID
+	if channelID == 0 {
+		channelID = 1
+	}
+
+	existing, err := client.GetAllWidgetTemplates(WidgetTemplateQueryParams{
+		WidgetTemplateKind: WidgetTemplateKindCustom,
+		ChannelIDIn:        []int{channelID},
+	})
+	if err != nil {
+		return WidgetTemplate{}, false, err
+	}
+
+	for _, template := range existing {
+		if template.Name != params.Template.Name || (template.ChannelID != 0 && template.ChannelID != channelID) {
+			continue
+		}
+		if template.Template == params.Template.Template && template.StorefrontAPIQuery == params.Template.StorefrontAPIQuery && equalWidgetSchemas(template.Schema, params.Template.Schema) {
+			return template, false, nil
+		}
+		updated, err := client.UpdateWidgetTemplate(template.UUID, UpdateWidgetTemplateParams{
+			Template:           params.Template.Template,
+			Schema:             params.Template.Schema,
+			StorefrontAPIQuery: params.Template.StorefrontAPIQuery,
+			CreateNewVersion:   true,
+		})
+		return updated, false, err
+	}
+
+	template, err := client.CreateWidgetTemplate(params.Template)
+	return template, err == nil, err
+}
+
+// equalWidgetSchemas compares schemas by their JSON, so numbers decoded from a
+// response match the ints they were declared with.
+func equalWidgetSchemas(a, b []WidgetSchemaElement) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aJSON, bJSON)
+}
+
+func hasRegion(regions []Region, name string) bool {
+	for _, region := range regions {
+		if region.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,219 @@
+package bigcommerce
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeWidgetStore struct {
+	mu         sync.Mutex
+	templates  []WidgetTemplate
+	widgets    []Widget
+	placements []Placement
+	nextID     int
+	failPlace  bool
+	updated    []string
+}
+
+func (store *fakeWidgetStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/stores/test/v3/content/")
+	segments := strings.Split(path, "/")
+	store.nextID++
+	uuid := fmt.Sprintf("uuid-%d", store.nextID)
+
+	respond := func(data interface{}) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": data,
+			"meta": map[string]interface{}{"pagination": map[string]int{"current_page": 1, "total_pages": 1}},
+		})
+	}
+
+	switch {
+	case segments[0] == "regions":
+		if r.URL.Query().Get("templateFile") != "pages/product" {
+			respond([]Region{})
+			return
+		}
+		respond([]Region{{Name: "header_bottom"}, {Name: "product_below_content"}})
+	case segments[0] == "widget-templates" && r.Method == http.MethodGet:
+		templates := []WidgetTemplate{}
+		for _, template := range store.templates {
+			if channels := r.URL.Query().Get("channel_id:in"); channels == "" || channels == strconv.Itoa(template.ChannelID) {
+				templates = append(templates, template)
+			}
+		}
+		respond(templates)
+	case segments[0] == "widget-templates" && r.Method == http.MethodPost:
+		var params CreateWidgetTemplateParams
+		json.NewDecoder(r.Body).Decode(&params)
+		if params.ChannelID == 0 {
+			params.ChannelID = 1
+		}
+		template := WidgetTemplate{UUID: uuid, Name: params.Name, Template: params.Template, Schema: params.Schema, ChannelID: params.ChannelID}
+		store.templates = append(store.templates, template)
+		respond(template)
+	case segments[0] == "widget-templates" && r.Method == http.MethodPut:
+		var params UpdateWidgetTemplateParams
+		json.NewDecoder(r.Body).Decode(&params)
+		for i, template := range store.templates {
+			if template.UUID == segments[1] {
+				template.Template = params.Template
+				template.Schema = params.Schema
+				store.templates[i] = template
+				store.updated = append(store.updated, template.UUID)
+				respond(template)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case segments[0] == "widget-templates" && r.Method == http.MethodDelete:
+		for i, template := range store.templates {
+			if template.UUID == segments[1] {
+				store.templates = append(store.templates[:i], store.templates[i+1:]...)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case segments[0] == "widgets" && r.Method == http.MethodPost:
+		var params CreateWidgetParams
+		json.NewDecoder(r.Body).Decode(&params)
+		widget := Widget{UUID: uuid, Name: params.Name, WidgetConfiguration: params.WidgetConfiguration}
+		store.widgets = append(store.widgets, widget)
+		respond(widget)
+	case segments[0] == "widgets" && r.Method == http.MethodDelete:
+		for i, widget := range store.widgets {
+			if widget.UUID == segments[1] {
+				store.widgets = append(store.widgets[:i], store.widgets[i+1:]...)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case segments[0] == "placements" && r.Method == http.MethodPost:
+		if store.failPlace {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"title":"invalid placement"}`))
+			return
+		}
+		var params CreatePlacementParams
+		json.NewDecoder(r.Body).Decode(&params)
+		placement := Placement{UUID: uuid, EntityID: params.EntityID, Region: params.Region, TemplateFile: params.TemplateFile, Status: params.Status}
+		store.placements = append(store.placements, placement)
+		respond(placement)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestInstallWidget(t *testing.T) {
+	store := &fakeWidgetStore{}
+	client := newTestServerClient(t, store)
+
+	params := InstallWidgetParams{
+		Template: CreateWidgetTemplateParams{
+			Name:     "Promo block",
+			Template: "<div>{{headline}}</div>",
+			Schema: []WidgetSchemaElement{{
+				Type:  "tab",
+				Label: "Content",
+				Sections: []WidgetSchemaSection{{Settings: []WidgetSchemaSetting{
+					{Type: "input", Label: "Headline", ID: "headline", Default: "Sale"},
+				}}},
+			}},
+		},
+		WidgetConfiguration: map[string]interface{}{"headline": "Half price"},
+		TemplateFile:        TemplateFileProduct,
+		Region:              "product_below_content",
+		EntityID:            77,
+	}
+
+	installation, err := client.V3.InstallWidget(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if installation.Widget.Name != "Promo block" {
+		t.Errorf("expected widget named after template, got %q", installation.Widget.Name)
+	}
+	if installation.Placement.EntityID != "77" || installation.Placement.Status != PlacementActive {
+		t.Errorf("unexpected placement %+v", installation.Placement)
+	}
+
+	// A second install reuses the template.
+	if _, err := client.V3.InstallWidget(params); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.templates) != 1 || len(store.widgets) != 2 {
+		t.Errorf("expected 1 template and 2 widgets, got %d and %d", len(store.templates), len(store.widgets))
+	}
+
+	params.Region = "nowhere"
+	if _, err := client.V3.InstallWidget(params); err == nil {
+		t.Error("expected error for unknown region")
+	}
+}
+
+func TestInstallWidget_TemplateMatching(t *testing.T) {
+	schema := func(label string) []WidgetSchemaElement {
+		return []WidgetSchemaElement{{Type: "tab", Label: label, Sections: []WidgetSchemaSection{{Settings: []WidgetSchemaSetting{
+			{Type: "number", ID: "count", Default: 3},
+		}}}}}
+	}
+	store := &fakeWidgetStore{templates: []WidgetTemplate{
+		{UUID: "other-channel", Name: "Promo", Template: "<div></div>", ChannelID: 2},
+	}}
+	client := newTestServerClient(t, store)
+
+	params := InstallWidgetParams{
+		Template:     CreateWidgetTemplateParams{Name: "Promo", Template: "<div></div>", Schema: schema("Content")},
+		TemplateFile: TemplateFileProduct,
+		Region:       "header_bottom",
+	}
+
+	// Another channel's template of the same name is left alone.
+	installation, err := client.V3.InstallWidget(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if installation.Template.UUID == "other-channel" || len(store.templates) != 2 || len(store.updated) != 0 {
+		t.Fatalf("expected a template on the default channel, got %+v", store.templates)
+	}
+
+	// The same schema, with numbers decoded from JSON, is not a change.
+	if _, err := client.V3.InstallWidget(params); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.updated) != 0 {
+		t.Fatalf("expected the template to be reused, got updates %v", store.updated)
+	}
+
+	// A schema change alone updates the template.
+	params.Template.Schema = schema("Settings")
+	if _, err := client.V3.InstallWidget(params); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.updated) != 1 || store.updated[0] != installation.Template.UUID {
+		t.Fatalf("expected the schema change to update the template, got %v", store.updated)
+	}
+}
+
+func TestInstallWidget_RollsBack(t *testing.T) {
+	store := &fakeWidgetStore{failPlace: true}
+	client := newTestServerClient(t, store)
+
+	_, err := client.V3.InstallWidget(InstallWidgetParams{
+		Template:     CreateWidgetTemplateParams{Name: "Promo", Template: "<div></div>"},
+		TemplateFile: TemplateFileProduct,
+		Region:       "header_bottom",
+	})
+	if err == nil {
+		t.Fatal("expected placement error")
+	}
+	if len(store.templates) != 0 || len(store.widgets) != 0 {
+		t.Errorf("expected rollback, got %d templates and %d widgets", len(store.templates), len(store.widgets))
+	}
+}
@@ -0,0 +1,528 @@
+package bigcommerce
+
+import "fmt"
+
+type WidgetTemplateKind string
+
+const (
+	WidgetTemplateKindCustom WidgetTemplateKind = "custom"
+)
+
+type PlacementStatus string
+
+const (
+	PlacementActive   PlacementStatus = "active"
+	PlacementInactive PlacementStatus = "inactive"
+)
+
+// WidgetTemplateFile is a storefront template that widgets can be placed on.
+type WidgetTemplateFile string
+
+const (
+	TemplateFileHome     WidgetTemplateFile = "pages/home"
+	TemplateFileProduct  WidgetTemplateFile = "pages/product"
+	TemplateFileCategory WidgetTemplateFile = "pages/category"
+	TemplateFileBrand    WidgetTemplateFile = "pages/brand"
+	TemplateFileBrands   WidgetTemplateFile = "pages/brands"
+	TemplateFilePage     WidgetTemplateFile = "pages/page"
+	TemplateFileBlog     WidgetTemplateFile = "pages/blog"
+	TemplateFileBlogPost WidgetTemplateFile = "pages/blog-post"
+	TemplateFileCart     WidgetTemplateFile = "pages/cart"
+	TemplateFileSearch   WidgetTemplateFile = "pages/search"
+)
+
+type WidgetTemplate struct {
+	UUID               string                `json:"uuid"`
+	Name               string                `json:"name"`
+	Schema             []WidgetSchemaElement `json:"schema"`
+	Template           string                `json:"template"`
+	StorefrontAPIQuery string                `json:"storefront_api_query"`
+	Kind               WidgetTemplateKind    `json:"kind"`
+	TemplateEngine     string                `json:"template_engine"`
+	CurrentVersionUUID string                `json:"current_version_uuid"`
+	IconName           string                `json:"icon_name"`
+	ChannelID          int                   `json:"channel_id"`
+	DateCreated        string                `json:"date_created"`
+	DateModified       string                `json:"date_modified"`
+}
+
+// WidgetSchemaElement is a top level entry of a widget template schema: a "tab" of
+// sections, an "array" of repeated entries, or "hidden" settings.
+type WidgetSchemaElement struct {
+	Type         string                `json:"type"`
+	Label        string                `json:"label,omitempty"`
+	ID           string                `json:"id,omitempty"`
+	EntryLabel   string                `json:"entryLabel,omitempty"`
+	DefaultCount int                   `json:"defaultCount,omitempty"`
+	Schema       []WidgetSchemaElement `json:"schema,omitempty"`
+	Sections     []WidgetSchemaSection `json:"sections,omitempty"`
+	Settings     []WidgetSchemaSetting `json:"settings,omitempty"`
+}
+
+type WidgetSchemaSection struct {
+	Label    string                `json:"label,omitempty"`
+	Settings []WidgetSchemaSetting `json:"settings"`
+}
+
+// WidgetSchemaSetting is a single control in the Page Builder sidebar. ID is the
+// key the value is stored under in the widget configuration.
+type WidgetSchemaSetting struct {
+	Type        string      `json:"type"`
+	Label       string      `json:"label,omitempty"`
+	ID          string      `json:"id"`
+	Default     interface{} `json:"default,omitempty"`
+	TypeMeta    interface{} `json:"typeMeta,omitempty"`
+	Conditional interface{} `json:"conditional,omitempty"`
+}
+
+type WidgetTemplateQueryParams struct {
+	Page               int                `url:"page,omitempty"`
+	Limit              int                `url:"limit,omitempty"`
+	WidgetTemplateKind WidgetTemplateKind `url:"widget_template_kind,omitempty"`
+	ChannelIDIn        []int              `url:"channel_id:in,omitempty,comma"`
+}
+
+type CreateWidgetTemplateParams struct {
+	Name               string                `json:"name"`
+	Template           string                `json:"template"`
+	Schema             []WidgetSchemaElement `json:"schema,omitempty"`
+	StorefrontAPIQuery string                `json:"storefront_api_query,omitempty"`
+	ChannelID          int                   `json:"channel_id,omitempty"`
+}
+
+type UpdateWidgetTemplateParams struct {
+	Name               string                `json:"name,omitempty"`
+	Template           string                `json:"template,omitempty"`
+	Schema             []WidgetSchemaElement `json:"schema,omitempty"`
+	StorefrontAPIQuery string                `json:"storefront_api_query,omitempty"`
+	// CreateNewVersion keeps widgets made from the previous version unchanged.
+	CreateNewVersion bool `json:"create_new_version,omitempty"`
+}
+
+type Widget struct {
+	UUID                string                 `json:"uuid"`
+	Name                string                 `json:"name"`
+	Description         string                 `json:"description"`
+	WidgetConfiguration map[string]interface{} `json:"widget_configuration"`
+	WidgetTemplate      WidgetTemplate         `json:"widget_template"`
+	VersionUUID         string                 `json:"version_uuid"`
+	ChannelID           int                    `json:"channel_id"`
+	DateCreated         string                 `json:"date_created"`
+	DateModified        string                 `json:"date_modified"`
+}
+
+type WidgetQueryParams struct {
+	Page               int                `url:"page,omitempty"`
+	Limit              int                `url:"limit,omitempty"`
+	WidgetTemplateKind WidgetTemplateKind `url:"widget_template_kind,omitempty"`
+	WidgetTemplateUUID string             `url:"widget_template_uuid,omitempty"`
+	ChannelIDIn        []int              `url:"channel_id:in,omitempty,comma"`
+}
+
+type CreateWidgetParams struct {
+	Name                string                 `json:"name"`
+	Description         string                 `json:"description,omitempty"`
+	WidgetConfiguration map[string]interface{} `json:"widget_configuration,omitempty"`
+	WidgetTemplateUUID  string                 `json:"widget_template_uuid"`
+	ChannelID           int                    `json:"channel_id,omitempty"`
+}
+
+type UpdateWidgetParams struct {
+	Name                string                 `json:"name,omitempty"`
+	Description         string                 `json:"description,omitempty"`
+	WidgetConfiguration map[string]interface{} `json:"widget_configuration,omitempty"`
+	WidgetTemplateUUID  string                 `json:"widget_template_uuid,omitempty"`
+	// UpgradeToLatestVersion moves the widget to the template's current version.
+	UpgradeToLatestVersion bool `json:"upgrade,omitempty"`
+}
+
+// Placement puts a widget in a region of a template file. EntityID narrows the
+// placement to one product, category, brand or page; without it the widget shows
+// on every page rendered with the template file.
+type Placement struct {
+	UUID         string             `json:"uuid"`
+	Widget       Widget             `json:"widget"`
+	EntityID     string             `json:"entity_id"`
+	SortOrder    int                `json:"sort_order"`
+	Region       string             `json:"region"`
+	TemplateFile WidgetTemplateFile `json:"template_file"`
+	Status       PlacementStatus    `json:"status"`
+	ChannelID    int                `json:"channel_id"`
+	DateCreated  string             `json:"date_created"`
+	DateModified string             `json:"date_modified"`
+}
+
+type PlacementQueryParams struct {
+	Page               int                `url:"page,omitempty"`
+	Limit              int                `url:"limit,omitempty"`
+	WidgetTemplateKind WidgetTemplateKind `url:"widget_template_kind,omitempty"`
+	TemplateFile       WidgetTemplateFile `url:"template_file,omitempty"`
+	WidgetUUID         string             `url:"widget_uuid,omitempty"`
+	ChannelIDIn        []int              `url:"channel_id:in,omitempty,comma"`
+}
+
+type CreatePlacementParams struct {
+	WidgetUUID   string             `json:"widget_uuid"`
+	EntityID     string             `json:"entity_id,omitempty"`
+	SortOrder    int                `json:"sort_order,omitempty"`
+	Region       string             `json:"region"`
+	TemplateFile WidgetTemplateFile `json:"template_file"`
+	Status       PlacementStatus    `json:"status,omitempty"`
+	ChannelID    int                `json:"channel_id,omitempty"`
+}
+
+type UpdatePlacementParams struct {
+	WidgetUUID   string             `json:"widget_uuid,omitempty"`
+	EntityID     string             `json:"entity_id,omitempty"`
+	SortOrder    *int               `json:"sort_order,omitempty"`
+	Region       string             `json:"region,omitempty"`
+	TemplateFile WidgetTemplateFile `json:"template_file,omitempty"`
+	Status       PlacementStatus    `json:"status,omitempty"`
+}
+
+// Region is a named slot in a template file that placements can fill.
+type Region struct {
+	Name string `json:"name"`
+}
+
+func (client *V3Client) GetWidgetTemplates(params WidgetTemplateQueryParams) ([]WidgetTemplate, MetaData, error) {
+	type ResponseObject struct {
+		Data []WidgetTemplate `json:"data"`
+		Meta MetaData         `json:"meta"`
+	}
+	var response ResponseObject
+
+	path, err := urlWithQueryParams(client.constructURL("/content/widget-templates"), params)
+	if err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to construct URL for GetWidgetTemplates: %w", err)
+	}
+
+	if err := client.Get(path, &response); err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to get widget templates: %w", err)
+	}
+
+	return response.Data, response.Meta, nil
+}
+
+func (client *V3Client) GetAllWidgetTemplates(params WidgetTemplateQueryParams) ([]WidgetTemplate, error) {
+	var templates []WidgetTemplate
+	params.Page = 1
+	if params.Limit < 1 {
+		params.Limit = 250
+	}
+
+	for {
+		t, meta, err := client.GetWidgetTemplates(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get all widget templates at page %d: %w", params.Page, err)
+		}
+		templates = append(templates, t...)
+
+		if meta.Pagination.CurrentPage >= meta.Pagination.TotalPages {
+			break
+		}
+
+		params.Page++
+	}
+
+	return templates, nil
+}
+
+func (client *V3Client) GetWidgetTemplate(uuid string) (WidgetTemplate, error) {
+	type ResponseObject struct {
+		Data WidgetTemplate `json:"data"`
+		Meta MetaData       `json:"meta"`
+	}
+	var response ResponseObject
+
+	path := client.constructURL("content", "widget-templates", uuid)
+
+	if err := client.Get(path, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to get widget template %s: %w", uuid, err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) CreateWidgetTemplate(params CreateWidgetTemplateParams) (WidgetTemplate, error) {
+	type ResponseObject struct {
+		Data WidgetTemplate `json:"data"`
+		Meta MetaData       `json:"meta"`
+	}
+	var response ResponseObject
+
+	if params.Name == "" || params.Template == "" {
+		return response.Data, fmt.Errorf("failed to create widget template: Name and Template are required")
+	}
+
+	path := client.constructURL("/content/widget-templates")
+
+	if err := client.Post(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to create widget template: %w", err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) UpdateWidgetTemplate(uuid string, params UpdateWidgetTemplateParams) (WidgetTemplate, error) {
+	type ResponseObject struct {
+		Data WidgetTemplate `json:"data"`
+		Meta MetaData       `json:"meta"`
+	}
+	var response ResponseObject
+
+	path := client.constructURL("content", "widget-templates", uuid)
+
+	if err := client.Put(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to update widget template %s: %w", uuid, err)
+	}
+
+	return response.Data, nil
+}
+
+// DeleteWidgetTemplate deletes a template. BigCommerce refuses while widgets made
+// from it still exist.
+func (client *V3Client) DeleteWidgetTemplate(uuid string) error {
+	path := client.constructURL("content", "widget-templates", uuid)
+
+	if err := client.Delete(path, nil); err != nil {
+		return fmt.Errorf("failed to delete widget template %s: %w", uuid, err)
+	}
+
+	return nil
+}
+
+func (client *V3Client) GetWidgets(params WidgetQueryParams) ([]Widget, MetaData, error) {
+	type ResponseObject struct {
+		Data []Widget `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	path, err := urlWithQueryParams(client.constructURL("/content/widgets"), params)
+	if err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to construct URL for GetWidgets: %w", err)
+	}
+
+	if err := client.Get(path, &response); err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to get widgets: %w", err)
+	}
+
+	return response.Data, response.Meta, nil
+}
+
+func (client *V3Client) GetAllWidgets(params WidgetQueryParams) ([]Widget, error) {
+	var widgets []Widget
+	params.Page = 1
+	if params.Limit < 1 {
+		params.Limit = 250
+	}
+
+	for {
+		w, meta, err := client.GetWidgets(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get all widgets at page %d: %w", params.Page, err)
+		}
+		widgets = append(widgets, w...)
+
+		if meta.Pagination.CurrentPage >= meta.Pagination.TotalPages {
+			break
+		}
+
+		params.Page++
+	}
+
+	return widgets, nil
+}
+
+func (client *V3Client) GetWidget(uuid string) (Widget, error) {
+	type ResponseObject struct {
+		Data Widget   `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	path := client.constructURL("content", "widgets", uuid)
+
+	if err := client.Get(path, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to get widget %s: %w", uuid, err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) CreateWidget(params CreateWidgetParams) (Widget, error) {
+	type ResponseObject struct {
+		Data Widget   `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	if params.Name == "" || params.WidgetTemplateUUID == "" {
+		return response.Data, fmt.Errorf("failed to create widget: Name and WidgetTemplateUUID are required")
+	}
+
+	path := client.constructURL("/content/widgets")
+
+	if err := client.Post(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to create widget: %w", err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) UpdateWidget(uuid string, params UpdateWidgetParams) (Widget, error) {
+	type ResponseObject struct {
+		Data Widget   `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	path := client.constructURL("content", "widgets", uuid)
+
+	if err := client.Put(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to update widget %s: %w", uuid, err)
+	}
+
+	return response.Data, nil
+}
+
+// DeleteWidget deletes a widget along with its placements.
+func (client *V3Client) DeleteWidget(uuid string) error {
+	path := client.constructURL("content", "widgets", uuid)
+
+	if err := client.Delete(path, nil); err != nil {
+		return fmt.Errorf("failed to delete widget %s: %w", uuid, err)
+	}
+
+	return nil
+}
+
+func (client *V3Client) GetPlacements(params PlacementQueryParams) ([]Placement, MetaData, error) {
+	type ResponseObject struct {
+		Data []Placement `json:"data"`
+		Meta MetaData    `json:"meta"`
+	}
+	var response ResponseObject
+
+	path, err := urlWithQueryParams(client.constructURL("/content/placements"), params)
+	if err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to construct URL for GetPlacements: %w", err)
+	}
+
+	if err := client.Get(path, &response); err != nil {
+		return nil, MetaData{}, fmt.Errorf("failed to get placements: %w", err)
+	}
+
+	return response.Data, response.Meta, nil
+}
+
+func (client *V3Client) GetAllPlacements(params PlacementQueryParams) ([]Placement, error) {
+	var placements []Placement
+	params.Page = 1
+	if params.Limit < 1 {
+		params.Limit = 250
+	}
+
+	for {
+		p, meta, err := client.GetPlacements(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get all placements at page %d: %w", params.Page, err)
+		}
+		placements = append(placements, p...)
+
+		if meta.Pagination.CurrentPage >= meta.Pagination.TotalPages {
+			break
+		}
+
+		params.Page++
+	}
+
+	return placements, nil
+}
+
+func (client *V3Client) GetPlacement(uuid string) (Placement, error) {
+	type ResponseObject struct {
+		Data Placement `json:"data"`
+		Meta MetaData  `json:"meta"`
+	}
+	var response ResponseObject
+
+	path := client.constructURL("content", "placements", uuid)
+
+	if err := client.Get(path, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to get placement %s: %w", uuid, err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) CreatePlacement(params CreatePlacementParams) (Placement, error) {
+	type ResponseObject struct {
+		Data Placement `json:"data"`
+		Meta MetaData  `json:"meta"`
+	}
+	var response ResponseObject
+
+	if params.WidgetUUID == "" || params.TemplateFile == "" || params.Region == "" {
+		return response.Data, fmt.Errorf("failed to create placement: WidgetUUID, TemplateFile and Region are required")
+	}
+
+	path := client.constructURL("/content/placements")
+
+	if err := client.Post(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to create placement: %w", err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) UpdatePlacement(uuid string, params UpdatePlacementParams) (Placement, error) {
+	type ResponseObject struct {
+		Data Placement `json:"data"`
+		Meta MetaData  `json:"meta"`
+	}
+	var response ResponseObject
+
+	path := client.constructURL("content", "placements", uuid)
+
+	if err := client.Put(path, params, &response); err != nil {
+		return response.Data, fmt.Errorf("failed to update placement %s: %w", uuid, err)
+	}
+
+	return response.Data, nil
+}
+
+func (client *V3Client) DeletePlacement(uuid string) error {
+	path := client.constructURL("content", "placements", uuid)
+
+	if err := client.Delete(path, nil); err != nil {
+		return fmt.Errorf("failed to delete placement %s: %w", uuid, err)
+	}
+
+	return nil
+}
+
+// GetRegions lists the regions the active theme defines in templateFile.
+func (client *V3Client) GetRegions(templateFile WidgetTemplateFile) ([]Region, error) {
+	type ResponseObject struct {
+		Data []Region `json:"data"`
+		Meta MetaData `json:"meta"`
+	}
+	var response ResponseObject
+
+	params := struct {
+		TemplateFile WidgetTemplateFile `url:"templateFile"`
+	}{TemplateFile: templateFile}
+
+	path, err := urlWithQueryParams(client.constructURL("/content/regions"), params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct URL for GetRegions: %w", err)
+	}
+
+	if err := client.Get(path, &response); err != nil {
+		return nil, fmt.Errorf("failed to get regions for %s: %w", templateFile, err)
+	}
+
+	return response.Data, nil
+}